@@ -17,12 +17,14 @@ import (
 	"time"
 
 	"github.com/avast/retry-go/v4"
+	abcitypes "github.com/cometbft/cometbft/abci/types"
 	tmjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/p2p"
 	rpcclient "github.com/cometbft/cometbft/rpc/client"
 	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 	libclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	tmtypes "github.com/cometbft/cometbft/types"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/types"
@@ -58,6 +60,7 @@ type ChainNode struct {
 	// Ports set during StartContainer.
 	hostRPCPort  string
 	hostGRPCPort string
+	hostAPIPort  string
 }
 
 func NewChainNode(log *zap.Logger, validator bool, chain *CosmosChain, dockerClient *dockerclient.Client, networkID string, testName string, image ibc.DockerImage, index int) *ChainNode {
@@ -145,6 +148,15 @@ func (tn *ChainNode) Name() string {
 	return fmt.Sprintf("%s-%s-%d-%s", tn.Chain.Config().ChainID, nodeType, tn.Index, dockerutil.SanitizeContainerName(tn.TestName))
 }
 
+// nodeKey identifies the node within its chain, independently of the test name.
+func (tn *ChainNode) nodeKey() string {
+	nodeType := "fn"
+	if tn.Validator {
+		nodeType = "val"
+	}
+	return fmt.Sprintf("%s-%d", nodeType, tn.Index)
+}
+
 // hostname of the test node container
 func (tn *ChainNode) HostName() string {
 	return dockerutil.CondenseHostName(tn.Name())
@@ -318,6 +330,82 @@ func (tn *ChainNode) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx,
 	if err := eg.Wait(); err != nil {
 		return nil, err
 	}
+	return append(tn.blockTxs(height, block, blockRes), blockEventTxs(blockRes)...), nil
+}
+
+// blockEventTxs returns artificial transactions holding the begin and end block events,
+// so that the views and queries over transaction events also see the events emitted outside of transactions,
+// such as packets sent in EndBlock.
+func blockEventTxs(blockRes *coretypes.ResultBlockResults) []blockdb.Tx {
+	var txs []blockdb.Tx
+	if len(blockRes.BeginBlockEvents) > 0 {
+		txs = append(txs, blockdb.Tx{
+			Data:   []byte(`{"data":"begin_block","note":"this is a transaction artificially created for debugging purposes"}`),
+			Events: blockEvents(blockRes.BeginBlockEvents),
+		})
+	}
+	if len(blockRes.EndBlockEvents) > 0 {
+		txs = append(txs, blockdb.Tx{
+			Data:   []byte(`{"data":"end_block","note":"this is a transaction artificially created for debugging purposes"}`),
+			Events: blockEvents(blockRes.EndBlockEvents),
+		})
+	}
+	return txs
+}
+
+// FindBlock implements blockdb.BlockFinder.
+// The begin and end block events are part of the block, and are also appended to its transactions
+// as the artificial transactions of FindTxs.
+func (tn *ChainNode) FindBlock(ctx context.Context, height uint64) (blockdb.Block, error) {
+	h := int64(height)
+	var eg errgroup.Group
+	var blockRes *coretypes.ResultBlockResults
+	var block *coretypes.ResultBlock
+	var commit *coretypes.ResultCommit
+	eg.Go(func() (err error) {
+		blockRes, err = tn.Client.BlockResults(ctx, &h)
+		return err
+	})
+	eg.Go(func() (err error) {
+		block, err = tn.Client.Block(ctx, &h)
+		return err
+	})
+	eg.Go(func() (err error) {
+		commit, err = tn.Client.Commit(ctx, &h)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return blockdb.Block{}, err
+	}
+
+	header := block.Block.Header
+	res := blockdb.Block{
+		Height:           height,
+		Time:             header.Time,
+		Proposer:         header.ProposerAddress.String(),
+		AppHash:          header.AppHash.String(),
+		ValidatorsHash:   header.ValidatorsHash.String(),
+		BeginBlockEvents: blockEvents(blockRes.BeginBlockEvents),
+		EndBlockEvents:   blockEvents(blockRes.EndBlockEvents),
+		Txs:              append(tn.blockTxs(height, block, blockRes), blockEventTxs(blockRes)...),
+	}
+	if commit.Commit != nil {
+		res.Signatures = make([]blockdb.CommitSig, len(commit.Commit.Signatures))
+		for i, sig := range commit.Commit.Signatures {
+			res.Signatures[i] = blockdb.CommitSig{
+				ValidatorAddress: sig.ValidatorAddress.String(),
+				Flag:             commitSigFlag(sig.BlockIDFlag),
+				Timestamp:        sig.Timestamp,
+				Signature:        sig.Signature,
+			}
+		}
+	}
+	return res, nil
+}
+
+// blockTxs decodes the transactions of the block as JSON, with their events.
+// Transactions that fail to decode are skipped.
+func (tn *ChainNode) blockTxs(height uint64, block *coretypes.ResultBlock, blockRes *coretypes.ResultBlockResults) []blockdb.Tx {
 	interfaceRegistry := tn.Chain.Config().EncodingConfig.InterfaceRegistry
 	txs := make([]blockdb.Tx, 0, len(block.Block.Txs)+2)
 	for i, tx := range block.Block.Txs {
@@ -335,67 +423,41 @@ func (tn *ChainNode) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx,
 			continue
 		}
 		newTx.Data = b
-
-		rTx := blockRes.TxsResults[i]
-
-		newTx.Events = make([]blockdb.Event, len(rTx.Events))
-		for j, e := range rTx.Events {
-			attrs := make([]blockdb.EventAttribute, len(e.Attributes))
-			for k, attr := range e.Attributes {
-				attrs[k] = blockdb.EventAttribute{
-					Key:   string(attr.Key),
-					Value: string(attr.Value),
-				}
-			}
-			newTx.Events[j] = blockdb.Event{
-				Type:       e.Type,
-				Attributes: attrs,
-			}
-		}
+		newTx.Events = blockEvents(blockRes.TxsResults[i].Events)
 		txs = append(txs, newTx)
 	}
-	if len(blockRes.BeginBlockEvents) > 0 {
-		beginBlockTx := blockdb.Tx{
-			Data: []byte(`{"data":"begin_block","note":"this is a transaction artificially created for debugging purposes"}`),
-		}
-		beginBlockTx.Events = make([]blockdb.Event, len(blockRes.BeginBlockEvents))
-		for i, e := range blockRes.BeginBlockEvents {
-			attrs := make([]blockdb.EventAttribute, len(e.Attributes))
-			for j, attr := range e.Attributes {
-				attrs[j] = blockdb.EventAttribute{
-					Key:   string(attr.Key),
-					Value: string(attr.Value),
-				}
-			}
-			beginBlockTx.Events[i] = blockdb.Event{
-				Type:       e.Type,
-				Attributes: attrs,
+	return txs
+}
+
+// blockEvents converts ABCI events to blockdb events.
+func blockEvents(events []abcitypes.Event) []blockdb.Event {
+	res := make([]blockdb.Event, len(events))
+	for i, e := range events {
+		attrs := make([]blockdb.EventAttribute, len(e.Attributes))
+		for j, attr := range e.Attributes {
+			attrs[j] = blockdb.EventAttribute{
+				Key:   string(attr.Key),
+				Value: string(attr.Value),
 			}
 		}
-		txs = append(txs, beginBlockTx)
-	}
-	if len(blockRes.EndBlockEvents) > 0 {
-		endBlockTx := blockdb.Tx{
-			Data: []byte(`{"data":"end_block","note":"this is a transaction artificially created for debugging purposes"}`),
-		}
-		endBlockTx.Events = make([]blockdb.Event, len(blockRes.EndBlockEvents))
-		for i, e := range blockRes.EndBlockEvents {
-			attrs := make([]blockdb.EventAttribute, len(e.Attributes))
-			for j, attr := range e.Attributes {
-				attrs[j] = blockdb.EventAttribute{
-					Key:   string(attr.Key),
-					Value: string(attr.Value),
-				}
-			}
-			endBlockTx.Events[i] = blockdb.Event{
-				Type:       e.Type,
-				Attributes: attrs,
-			}
+		res[i] = blockdb.Event{
+			Type:       e.Type,
+			Attributes: attrs,
 		}
-		txs = append(txs, endBlockTx)
 	}
+	return res
+}
 
-	return txs, nil
+// commitSigFlag returns the blockdb name of a commit signature's flag.
+func commitSigFlag(flag tmtypes.BlockIDFlag) string {
+	switch flag {
+	case tmtypes.BlockIDFlagCommit:
+		return blockdb.SignatureCommit
+	case tmtypes.BlockIDFlagNil:
+		return blockdb.SignatureNil
+	default:
+		return blockdb.SignatureAbsent
+	}
 }
 
 // TxCommand is a helper to retrieve a full command for broadcasting a tx
@@ -980,11 +1042,11 @@ func (tn *ChainNode) StartContainer(ctx context.Context) error {
 	}
 
 	// Set the host ports once since they will not change after the container has started.
-	hostPorts, err := tn.containerLifecycle.GetHostPorts(ctx, rpcPort, grpcPort)
+	hostPorts, err := tn.containerLifecycle.GetHostPorts(ctx, rpcPort, grpcPort, apiPort)
 	if err != nil {
 		return err
 	}
-	tn.hostRPCPort, tn.hostGRPCPort = hostPorts[0], hostPorts[1]
+	tn.hostRPCPort, tn.hostGRPCPort, tn.hostAPIPort = hostPorts[0], hostPorts[1], hostPorts[2]
 
 	err = tn.NewClient("tcp://" + tn.hostRPCPort)
 	if err != nil {
@@ -1014,6 +1076,18 @@ func (tn *ChainNode) RemoveContainer(ctx context.Context) error {
 	return tn.containerLifecycle.RemoveContainer(ctx)
 }
 
+// ContainerID returns the ID of the node's docker container.
+// It is empty until the container is created.
+func (tn *ChainNode) ContainerID() string {
+	return tn.containerLifecycle.ContainerID()
+}
+
+// ContainerIDs returns the node's container ID as a single element slice,
+// so that an individual node can be targeted wherever a set of containers is accepted.
+func (tn *ChainNode) ContainerIDs() []string {
+	return []string{tn.ContainerID()}
+}
+
 // InitValidatorFiles creates the node files and signs a genesis transaction
 func (tn *ChainNode) InitValidatorGenTx(
 	ctx context.Context,
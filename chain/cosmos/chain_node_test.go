@@ -0,0 +1,62 @@
+package cosmos
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockEventTxs_PacketFlows(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := blockdb.ConnectDB(ctx, ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, blockdb.Migrate(db, "test"))
+
+	tc, err := blockdb.CreateTestCase(ctx, db, "test", "sha")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+
+	// A packet sent in EndBlock, such as by the interchain queries module.
+	blockRes := &coretypes.ResultBlockResults{
+		Height: 7,
+		EndBlockEvents: []abcitypes.Event{{Type: "send_packet", Attributes: []abcitypes.EventAttribute{
+			{Key: "packet_sequence", Value: "3"},
+			{Key: "packet_src_port", Value: "icqcontroller"},
+			{Key: "packet_src_channel", Value: "channel-1"},
+			{Key: "packet_dst_port", Value: "icqhost"},
+			{Key: "packet_dst_channel", Value: "channel-2"},
+		}}},
+	}
+	txs := blockEventTxs(blockRes)
+	require.Len(t, txs, 1)
+	require.Contains(t, string(txs[0].Data), "end_block")
+
+	require.NoError(t, chain.SaveFullBlock(ctx, blockdb.Block{
+		Height:         7,
+		EndBlockEvents: blockEvents(blockRes.EndBlockEvents),
+		Txs:            txs,
+	}))
+
+	q := blockdb.NewQuery(db)
+	testCases, err := q.RecentTestCases(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, testCases, 1)
+
+	flows, err := q.PacketFlows(ctx, testCases[0].ID)
+	require.NoError(t, err)
+	require.Len(t, flows, 1)
+	require.Equal(t, blockdb.PacketFlowResult{
+		Sequence:   3,
+		SrcChainID: "chain-a", SrcPort: "icqcontroller", SrcChannel: "channel-1",
+		DstPort: "icqhost", DstChannel: "channel-2",
+		SendHeight: sql.NullInt64{Int64: 7, Valid: true},
+	}, flows[0])
+}
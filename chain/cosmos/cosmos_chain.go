@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/avast/retry-go/v4"
+	abcitypes "github.com/cometbft/cometbft/abci/types"
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
@@ -109,6 +110,16 @@ func (c *CosmosChain) Nodes() ChainNodes {
 	return append(c.Validators, c.FullNodes...)
 }
 
+// ContainerIDs returns the docker container IDs of all validators and fullnodes.
+func (c *CosmosChain) ContainerIDs() []string {
+	nodes := c.Nodes()
+	ids := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		ids = append(ids, n.ContainerID())
+	}
+	return ids
+}
+
 // AddFullNodes adds new fullnodes to the network, peering with the existing nodes.
 func (c *CosmosChain) AddFullNodes(ctx context.Context, configFileOverrides map[string]any, inc int) error {
 	// Get peer string for existing nodes
@@ -214,6 +225,12 @@ func (c *CosmosChain) GetHostGRPCAddress() string {
 	return c.getFullNode().hostGRPCPort
 }
 
+// GetHostAPIAddress returns the address of the REST API server accessible by the host.
+// This will not return a valid address until the chain has been started.
+func (c *CosmosChain) GetHostAPIAddress() string {
+	return "http://" + c.getFullNode().hostAPIPort
+}
+
 // HomeDir implements ibc.Chain.
 func (c *CosmosChain) HomeDir() string {
 	return c.getFullNode().HomeDir()
@@ -888,16 +905,7 @@ func (c *CosmosChain) Acknowledgements(ctx context.Context, height uint64) ([]ib
 		ack := ack
 		ibcAcks[i] = ibc.PacketAcknowledgement{
 			Acknowledgement: ack.Acknowledgement,
-			Packet: ibc.Packet{
-				Sequence:         ack.Packet.Sequence,
-				SourcePort:       ack.Packet.SourcePort,
-				SourceChannel:    ack.Packet.SourceChannel,
-				DestPort:         ack.Packet.DestinationPort,
-				DestChannel:      ack.Packet.DestinationChannel,
-				Data:             ack.Packet.Data,
-				TimeoutHeight:    ack.Packet.TimeoutHeight.String(),
-				TimeoutTimestamp: ibc.Nanoseconds(ack.Packet.TimeoutTimestamp),
-			},
+			Packet:          ibc.PacketFromChannel(ack.Packet),
 		}
 	}
 	return ibcAcks, nil
@@ -920,21 +928,80 @@ func (c *CosmosChain) Timeouts(ctx context.Context, height uint64) ([]ibc.Packet
 	for i, ack := range timeouts {
 		ack := ack
 		ibcTimeouts[i] = ibc.PacketTimeout{
-			Packet: ibc.Packet{
-				Sequence:         ack.Packet.Sequence,
-				SourcePort:       ack.Packet.SourcePort,
-				SourceChannel:    ack.Packet.SourceChannel,
-				DestPort:         ack.Packet.DestinationPort,
-				DestChannel:      ack.Packet.DestinationChannel,
-				Data:             ack.Packet.Data,
-				TimeoutHeight:    ack.Packet.TimeoutHeight.String(),
-				TimeoutTimestamp: ibc.Nanoseconds(ack.Packet.TimeoutTimestamp),
-			},
+			Packet: ibc.PacketFromChannel(ack.Packet),
 		}
 	}
 	return ibcTimeouts, nil
 }
 
+var (
+	_ testutil.PacketSourceChain      = (*CosmosChain)(nil)
+	_ testutil.PacketDestinationChain = (*CosmosChain)(nil)
+	_ testutil.ChainBlockTimer        = (*CosmosChain)(nil)
+)
+
+// ReceivedPackets returns all packets received in block at height.
+// Redundant receives of packets that were already received are not included.
+func (c *CosmosChain) ReceivedPackets(ctx context.Context, height uint64) ([]ibc.Packet, error) {
+	var packets []ibc.Packet
+	err := rangeBlockEvents(ctx, c.getFullNode().Client, height, "recv_packet", func(e abcitypes.Event) error {
+		packet, err := tendermint.PacketFromEvent(e)
+		if err != nil {
+			return err
+		}
+		packets = append(packets, packet)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find received packets at height %d: %w", height, err)
+	}
+	return packets, nil
+}
+
+// AcknowledgedPackets returns all packets whose acknowledgement was processed in block at height,
+// from the acknowledge_packet events of successful transactions.
+// Unlike Acknowledgements, redundant acknowledgements of packets that were already acknowledged are not included,
+// as they fail or are no-ops that emit no event.
+func (c *CosmosChain) AcknowledgedPackets(ctx context.Context, height uint64) ([]ibc.Packet, error) {
+	packets, err := eventPackets(ctx, c.getFullNode().Client, height, "acknowledge_packet")
+	if err != nil {
+		return nil, fmt.Errorf("find acknowledged packets at height %d: %w", height, err)
+	}
+	return packets, nil
+}
+
+// WrittenAcknowledgements returns all acknowledgements written for received packets in block at height.
+func (c *CosmosChain) WrittenAcknowledgements(ctx context.Context, height uint64) ([]ibc.PacketAcknowledgement, error) {
+	var acks []ibc.PacketAcknowledgement
+	err := rangeBlockEvents(ctx, c.getFullNode().Client, height, "write_acknowledgement", func(e abcitypes.Event) error {
+		packet, err := tendermint.PacketFromEvent(e)
+		if err != nil {
+			return err
+		}
+		ackHex, _ := tendermint.AttributeValue([]abcitypes.Event{e}, e.Type, "packet_ack_hex")
+		ack, err := hex.DecodeString(ackHex)
+		if err != nil {
+			return fmt.Errorf("invalid acknowledgement in %s event: %w", e.Type, err)
+		}
+		acks = append(acks, ibc.PacketAcknowledgement{Packet: packet, Acknowledgement: ack})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find written acknowledgements at height %d: %w", height, err)
+	}
+	return acks, nil
+}
+
+// BlockTime returns the time of the block at height.
+func (c *CosmosChain) BlockTime(ctx context.Context, height uint64) (time.Time, error) {
+	h := int64(height)
+	block, err := c.getFullNode().Client.Block(ctx, &h)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("tendermint rpc get block: %w", err)
+	}
+	return block.Block.Time, nil
+}
+
 // FindTxs implements blockdb.BlockSaver.
 func (c *CosmosChain) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx, error) {
 	fn := c.getFullNode()
@@ -943,6 +1010,14 @@ func (c *CosmosChain) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx,
 	return fn.FindTxs(ctx, height)
 }
 
+// FindBlock implements blockdb.BlockFinder.
+func (c *CosmosChain) FindBlock(ctx context.Context, height uint64) (blockdb.Block, error) {
+	fn := c.getFullNode()
+	c.findTxMu.Lock()
+	defer c.findTxMu.Unlock()
+	return fn.FindBlock(ctx, height)
+}
+
 // StopAllNodes stops and removes all long running containers (validators and full nodes)
 func (c *CosmosChain) StopAllNodes(ctx context.Context) error {
 	var eg errgroup.Group
@@ -977,6 +1052,57 @@ func (c *CosmosChain) StartAllNodes(ctx context.Context) error {
 	return eg.Wait()
 }
 
+// NodeVolumes returns the name of the Docker volume holding each node's home directory.
+// Nodes are keyed by their type and index, e.g. "val-0" or "fn-1",
+// which identify the same node across chains built from the same configuration, regardless of the test name.
+func (c *CosmosChain) NodeVolumes() map[string]string {
+	nodes := c.Nodes()
+	volumes := make(map[string]string, len(nodes))
+	for _, n := range nodes {
+		volumes[n.nodeKey()] = n.VolumeName
+	}
+	return volumes
+}
+
+// StartFromState creates and starts containers for each node from the state already present in the node volumes,
+// such as volumes restored from a snapshot, rather than from a new genesis.
+// It must be called after Initialize, in place of Start.
+func (c *CosmosChain) StartFromState(ctx context.Context) error {
+	chainNodes := c.Nodes()
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, n := range chainNodes {
+		n := n
+		eg.Go(func() error {
+			return n.CreateNodeContainer(egCtx)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	// Host names depend on the test name, so the peers saved in the volumes may be stale.
+	peers := chainNodes.PeerString(ctx)
+
+	eg, egCtx = errgroup.WithContext(ctx)
+	for _, n := range chainNodes {
+		n := n
+		c.log.Info("Starting container", zap.String("container", n.Name()))
+		eg.Go(func() error {
+			if err := n.SetPeers(egCtx, peers); err != nil {
+				return err
+			}
+			return n.StartContainer(egCtx)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	// Wait for 5 blocks before considering the chains "started", as in Start.
+	return testutil.WaitForBlocks(ctx, 5, c.getFullNode())
+}
+
 func (c *CosmosChain) VoteOnProposalAllValidators(ctx context.Context, proposalID string, vote string) error {
 	var eg errgroup.Group
 	for _, n := range c.Nodes() {
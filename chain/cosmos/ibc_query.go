@@ -0,0 +1,356 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	conntypes "github.com/cosmos/ibc-go/v7/modules/core/03-connection/types"
+	chanTypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	ibcexported "github.com/cosmos/ibc-go/v7/modules/core/exported"
+	ibctm "github.com/cosmos/ibc-go/v7/modules/light-clients/07-tendermint"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var _ ibc.IBCQuerier = (*CosmosChain)(nil)
+
+// ibcQuery dials the gRPC server of the full node for the duration of f.
+func (c *CosmosChain) ibcQuery(f func(conn *grpc.ClientConn) error) error {
+	conn, err := grpc.Dial(c.getFullNode().hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return f(conn)
+}
+
+// QueryClients implements ibc.IBCQuerier.
+func (c *CosmosChain) QueryClients(ctx context.Context) ([]ibc.ClientStateOutput, error) {
+	var clients []ibc.ClientStateOutput
+	err := c.ibcQuery(func(conn *grpc.ClientConn) error {
+		qc := clienttypes.NewQueryClient(conn)
+		var next []byte
+		for {
+			res, err := qc.ClientStates(ctx, &clienttypes.QueryClientStatesRequest{Pagination: &query.PageRequest{Key: next}})
+			if err != nil {
+				return err
+			}
+			for _, cs := range res.ClientStates {
+				out, err := c.clientStateOutput(ctx, qc, cs.ClientId, cs.ClientState)
+				if err != nil {
+					return err
+				}
+				clients = append(clients, out)
+			}
+			if next = res.Pagination.GetNextKey(); len(next) == 0 {
+				return nil
+			}
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query client states: %w", err)
+	}
+	return clients, nil
+}
+
+// QueryClientState implements ibc.IBCQuerier.
+func (c *CosmosChain) QueryClientState(ctx context.Context, clientID string) (ibc.ClientStateOutput, error) {
+	var out ibc.ClientStateOutput
+	err := c.ibcQuery(func(conn *grpc.ClientConn) error {
+		qc := clienttypes.NewQueryClient(conn)
+		res, err := qc.ClientState(ctx, &clienttypes.QueryClientStateRequest{ClientId: clientID})
+		if err != nil {
+			return err
+		}
+		out, err = c.clientStateOutput(ctx, qc, clientID, res.ClientState)
+		return err
+	})
+	if err != nil {
+		return out, fmt.Errorf("query client state %s: %w", clientID, err)
+	}
+	return out, nil
+}
+
+func (c *CosmosChain) clientStateOutput(ctx context.Context, qc clienttypes.QueryClient, clientID string, anyState *codectypes.Any) (ibc.ClientStateOutput, error) {
+	status, err := qc.ClientStatus(ctx, &clienttypes.QueryClientStatusRequest{ClientId: clientID})
+	if err != nil {
+		return ibc.ClientStateOutput{}, fmt.Errorf("query client status %s: %w", clientID, err)
+	}
+	var cs ibcexported.ClientState
+	if err := c.cfg.EncodingConfig.InterfaceRegistry.UnpackAny(anyState, &cs); err != nil {
+		// The client type is not registered with the chain's codec, such as 09-localhost on ibc-go v7.1
+		// or 08-wasm, so only the fields that do not depend on the client state are set.
+		return ibc.ClientStateOutput{
+			ClientID:   clientID,
+			ClientType: clientTypeFromTypeURL(anyState.GetTypeUrl()),
+			Status:     status.Status,
+			Frozen:     status.Status == ibcexported.Frozen.String(),
+		}, nil
+	}
+	return clientStateOutput(clientID, cs, status.Status), nil
+}
+
+// Client types by the type URL of their client state, for client states the chain's codec cannot unpack.
+var clientTypesByTypeURL = map[string]string{
+	"/ibc.lightclients.solomachine.v2.ClientState": ibcexported.Solomachine,
+	"/ibc.lightclients.solomachine.v3.ClientState": ibcexported.Solomachine,
+	"/ibc.lightclients.tendermint.v1.ClientState":  ibcexported.Tendermint,
+	"/ibc.lightclients.localhost.v2.ClientState":   "09-localhost",
+	"/ibc.lightclients.wasm.v1.ClientState":        "08-wasm",
+}
+
+// clientTypeFromTypeURL returns the client type of a client state with typeURL,
+// or the type URL itself for unknown client types.
+func clientTypeFromTypeURL(typeURL string) string {
+	if typ, ok := clientTypesByTypeURL[typeURL]; ok {
+		return typ
+	}
+	return typeURL
+}
+
+// clientStateOutput converts a light client state and its status.
+func clientStateOutput(clientID string, cs ibcexported.ClientState, status string) ibc.ClientStateOutput {
+	out := ibc.ClientStateOutput{
+		ClientID:   clientID,
+		ClientType: cs.ClientType(),
+		Status:     status,
+		Frozen:     status == ibcexported.Frozen.String(),
+	}
+	if h, ok := cs.GetLatestHeight().(clienttypes.Height); ok {
+		out.LatestHeight = h
+	}
+	if tm, ok := cs.(*ibctm.ClientState); ok {
+		out.ChainID = tm.ChainId
+		out.TrustingPeriod = tm.TrustingPeriod
+		out.Frozen = out.Frozen || !tm.FrozenHeight.IsZero()
+	}
+	return out
+}
+
+// QueryConsensusState implements ibc.IBCQuerier.
+func (c *CosmosChain) QueryConsensusState(ctx context.Context, clientID string, height clienttypes.Height) (ibc.ConsensusStateOutput, error) {
+	var out ibc.ConsensusStateOutput
+	err := c.ibcQuery(func(conn *grpc.ClientConn) error {
+		qc := clienttypes.NewQueryClient(conn)
+		if height.IsZero() {
+			res, err := qc.ClientState(ctx, &clienttypes.QueryClientStateRequest{ClientId: clientID})
+			if err != nil {
+				return err
+			}
+			cs, err := c.clientStateOutput(ctx, qc, clientID, res.ClientState)
+			if err != nil {
+				return err
+			}
+			if cs.LatestHeight.IsZero() {
+				// The chain's codec could not unpack the client state, so its latest height is unknown.
+				return fmt.Errorf("latest height of %s client is unknown, query at an explicit height", cs.ClientType)
+			}
+			height = cs.LatestHeight
+		}
+		res, err := qc.ConsensusState(ctx, &clienttypes.QueryConsensusStateRequest{
+			ClientId:       clientID,
+			RevisionNumber: height.RevisionNumber,
+			RevisionHeight: height.RevisionHeight,
+		})
+		if err != nil {
+			return err
+		}
+		var cs ibcexported.ConsensusState
+		if err := c.cfg.EncodingConfig.InterfaceRegistry.UnpackAny(res.ConsensusState, &cs); err != nil {
+			return fmt.Errorf("unpack consensus state: %w", err)
+		}
+		out = consensusStateOutput(height, cs)
+		return nil
+	})
+	if err != nil {
+		return out, fmt.Errorf("query consensus state %s at %s: %w", clientID, height, err)
+	}
+	return out, nil
+}
+
+// consensusStateOutput converts a light client consensus state at height.
+func consensusStateOutput(height clienttypes.Height, cs ibcexported.ConsensusState) ibc.ConsensusStateOutput {
+	out := ibc.ConsensusStateOutput{
+		Height:    height,
+		Timestamp: time.Unix(0, int64(cs.GetTimestamp())).UTC(),
+	}
+	if tm, ok := cs.(*ibctm.ConsensusState); ok {
+		out.Root = tm.Root.GetHash()
+		out.NextValidatorsHash = tm.NextValidatorsHash
+	}
+	return out
+}
+
+// QueryConnections implements ibc.IBCQuerier.
+func (c *CosmosChain) QueryConnections(ctx context.Context) (ibc.ConnectionOutputs, error) {
+	var connections ibc.ConnectionOutputs
+	err := c.ibcQuery(func(conn *grpc.ClientConn) error {
+		qc := conntypes.NewQueryClient(conn)
+		var next []byte
+		for {
+			res, err := qc.Connections(ctx, &conntypes.QueryConnectionsRequest{Pagination: &query.PageRequest{Key: next}})
+			if err != nil {
+				return err
+			}
+			for _, ic := range res.Connections {
+				connections = append(connections, connectionOutput(ic))
+			}
+			if next = res.Pagination.GetNextKey(); len(next) == 0 {
+				return nil
+			}
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query connections: %w", err)
+	}
+	return connections, nil
+}
+
+func connectionOutput(ic *conntypes.IdentifiedConnection) *ibc.ConnectionOutput {
+	counterparty := ic.Counterparty
+	return &ibc.ConnectionOutput{
+		ID:           ic.Id,
+		ClientID:     ic.ClientId,
+		Versions:     ic.Versions,
+		State:        ic.State.String(),
+		Counterparty: &counterparty,
+		DelayPeriod:  fmt.Sprint(ic.DelayPeriod),
+	}
+}
+
+// QueryChannels implements ibc.IBCQuerier.
+func (c *CosmosChain) QueryChannels(ctx context.Context) ([]ibc.ChannelOutput, error) {
+	var channels []ibc.ChannelOutput
+	err := c.ibcQuery(func(conn *grpc.ClientConn) error {
+		qc := chanTypes.NewQueryClient(conn)
+		var next []byte
+		for {
+			res, err := qc.Channels(ctx, &chanTypes.QueryChannelsRequest{Pagination: &query.PageRequest{Key: next}})
+			if err != nil {
+				return err
+			}
+			for _, ic := range res.Channels {
+				channels = append(channels, channelOutput(ic))
+			}
+			if next = res.Pagination.GetNextKey(); len(next) == 0 {
+				return nil
+			}
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query channels: %w", err)
+	}
+	return channels, nil
+}
+
+func channelOutput(ic *chanTypes.IdentifiedChannel) ibc.ChannelOutput {
+	return ibc.ChannelOutput{
+		State:    ic.State.String(),
+		Ordering: ic.Ordering.String(),
+		Counterparty: ibc.ChannelCounterparty{
+			PortID:    ic.Counterparty.PortId,
+			ChannelID: ic.Counterparty.ChannelId,
+		},
+		ConnectionHops: ic.ConnectionHops,
+		Version:        ic.Version,
+		PortID:         ic.PortId,
+		ChannelID:      ic.ChannelId,
+	}
+}
+
+// QueryPacketCommitments implements ibc.IBCQuerier.
+func (c *CosmosChain) QueryPacketCommitments(ctx context.Context, portID, channelID string) ([]uint64, error) {
+	var sequences []uint64
+	err := c.ibcQuery(func(conn *grpc.ClientConn) error {
+		qc := chanTypes.NewQueryClient(conn)
+		var next []byte
+		for {
+			res, err := qc.PacketCommitments(ctx, &chanTypes.QueryPacketCommitmentsRequest{
+				PortId:     portID,
+				ChannelId:  channelID,
+				Pagination: &query.PageRequest{Key: next},
+			})
+			if err != nil {
+				return err
+			}
+			for _, pc := range res.Commitments {
+				sequences = append(sequences, pc.Sequence)
+			}
+			if next = res.Pagination.GetNextKey(); len(next) == 0 {
+				return nil
+			}
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query packet commitments on %s/%s: %w", portID, channelID, err)
+	}
+	return sequences, nil
+}
+
+// QueryPacketReceipt implements ibc.IBCQuerier.
+func (c *CosmosChain) QueryPacketReceipt(ctx context.Context, portID, channelID string, sequence uint64) (bool, error) {
+	var received bool
+	err := c.ibcQuery(func(conn *grpc.ClientConn) error {
+		res, err := chanTypes.NewQueryClient(conn).PacketReceipt(ctx, &chanTypes.QueryPacketReceiptRequest{
+			PortId:    portID,
+			ChannelId: channelID,
+			Sequence:  sequence,
+		})
+		if err != nil {
+			return err
+		}
+		received = res.Received
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("query packet receipt %d on %s/%s: %w", sequence, portID, channelID, err)
+	}
+	return received, nil
+}
+
+// QueryUnreceivedPackets implements ibc.IBCQuerier.
+func (c *CosmosChain) QueryUnreceivedPackets(ctx context.Context, portID, channelID string, sequences []uint64) ([]uint64, error) {
+	var unreceived []uint64
+	err := c.ibcQuery(func(conn *grpc.ClientConn) error {
+		res, err := chanTypes.NewQueryClient(conn).UnreceivedPackets(ctx, &chanTypes.QueryUnreceivedPacketsRequest{
+			PortId:                    portID,
+			ChannelId:                 channelID,
+			PacketCommitmentSequences: sequences,
+		})
+		if err != nil {
+			return err
+		}
+		unreceived = res.Sequences
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query unreceived packets on %s/%s: %w", portID, channelID, err)
+	}
+	return unreceived, nil
+}
+
+// QueryUnreceivedAcks implements ibc.IBCQuerier.
+func (c *CosmosChain) QueryUnreceivedAcks(ctx context.Context, portID, channelID string, sequences []uint64) ([]uint64, error) {
+	var unreceived []uint64
+	err := c.ibcQuery(func(conn *grpc.ClientConn) error {
+		res, err := chanTypes.NewQueryClient(conn).UnreceivedAcks(ctx, &chanTypes.QueryUnreceivedAcksRequest{
+			PortId:             portID,
+			ChannelId:          channelID,
+			PacketAckSequences: sequences,
+		})
+		if err != nil {
+			return err
+		}
+		unreceived = res.Sequences
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query unreceived acks on %s/%s: %w", portID, channelID, err)
+	}
+	return unreceived, nil
+}
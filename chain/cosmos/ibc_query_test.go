@@ -0,0 +1,87 @@
+package cosmos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v7/modules/core/23-commitment/types"
+	ibcexported "github.com/cosmos/ibc-go/v7/modules/core/exported"
+	ibctm "github.com/cosmos/ibc-go/v7/modules/light-clients/07-tendermint"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestClientStateOutput(t *testing.T) {
+	cs := &ibctm.ClientState{
+		ChainId:        "gaia-1",
+		TrustingPeriod: 336 * time.Hour,
+		LatestHeight:   clienttypes.NewHeight(1, 100),
+	}
+
+	out := clientStateOutput("07-tendermint-0", cs, ibcexported.Active.String())
+	require.Equal(t, ibc.ClientStateOutput{
+		ClientID:       "07-tendermint-0",
+		ClientType:     "07-tendermint",
+		Status:         "Active",
+		ChainID:        "gaia-1",
+		TrustingPeriod: 336 * time.Hour,
+		LatestHeight:   clienttypes.NewHeight(1, 100),
+	}, out)
+
+	cs.FrozenHeight = clienttypes.NewHeight(0, 1)
+	require.True(t, clientStateOutput("07-tendermint-0", cs, ibcexported.Active.String()).Frozen)
+}
+
+// clientStatusQuerier answers client status queries with status.
+type clientStatusQuerier struct {
+	clienttypes.QueryClient
+	status string
+}
+
+func (q clientStatusQuerier) ClientStatus(context.Context, *clienttypes.QueryClientStatusRequest, ...grpc.CallOption) (*clienttypes.QueryClientStatusResponse, error) {
+	return &clienttypes.QueryClientStatusResponse{Status: q.status}, nil
+}
+
+func TestCosmosChain_ClientStateOutput_Unregistered(t *testing.T) {
+	enc := DefaultEncoding()
+	c := &CosmosChain{cfg: ibc.ChainConfig{EncodingConfig: &enc}}
+	qc := clientStatusQuerier{status: ibcexported.Active.String()}
+
+	// The localhost client state of ibc-go v7.1 is not registered with the default codec.
+	anyState := &codectypes.Any{TypeUrl: "/ibc.lightclients.localhost.v2.ClientState", Value: []byte{}}
+	out, err := c.clientStateOutput(context.Background(), qc, "09-localhost", anyState)
+	require.NoError(t, err)
+	require.Equal(t, ibc.ClientStateOutput{
+		ClientID:   "09-localhost",
+		ClientType: "09-localhost",
+		Status:     "Active",
+	}, out)
+
+	anyState.TypeUrl = "/ibc.lightclients.unknown.v1.ClientState"
+	out, err = c.clientStateOutput(context.Background(), qc, "100-unknown-0", anyState)
+	require.NoError(t, err)
+	require.Equal(t, "/ibc.lightclients.unknown.v1.ClientState", out.ClientType)
+
+	anyState, err = codectypes.NewAnyWithValue(&ibctm.ClientState{ChainId: "gaia-1", LatestHeight: clienttypes.NewHeight(1, 100)})
+	require.NoError(t, err)
+	out, err = c.clientStateOutput(context.Background(), qc, "07-tendermint-0", anyState)
+	require.NoError(t, err)
+	require.Equal(t, "07-tendermint", out.ClientType)
+	require.Equal(t, "gaia-1", out.ChainID)
+}
+
+func TestConsensusStateOutput(t *testing.T) {
+	ts := time.Date(2023, 4, 5, 6, 7, 8, 9, time.UTC)
+	cs := ibctm.NewConsensusState(ts, commitmenttypes.NewMerkleRoot([]byte("root")), []byte("next"))
+
+	require.Equal(t, ibc.ConsensusStateOutput{
+		Height:             clienttypes.NewHeight(1, 100),
+		Timestamp:          ts,
+		Root:               []byte("root"),
+		NextValidatorsHash: []byte("next"),
+	}, consensusStateOutput(clienttypes.NewHeight(1, 100), cs))
+}
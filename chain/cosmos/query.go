@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 
+	abcitypes "github.com/cometbft/cometbft/abci/types"
 	tmtypes "github.com/cometbft/cometbft/rpc/core/types"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/internal/tendermint"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 )
 
 type blockClient interface {
@@ -34,3 +37,44 @@ func rangeBlockMessages(ctx context.Context, interfaceRegistry codectypes.Interf
 	}
 	return nil
 }
+
+type blockResultsClient interface {
+	BlockResults(ctx context.Context, height *int64) (*tmtypes.ResultBlockResults, error)
+}
+
+// rangeBlockEvents iterates through the events of all a block's transactions, yielding each event of the given type to f.
+func rangeBlockEvents(ctx context.Context, client blockResultsClient, height uint64, eventType string, f func(abcitypes.Event) error) error {
+	h := int64(height)
+	res, err := client.BlockResults(ctx, &h)
+	if err != nil {
+		return fmt.Errorf("tendermint rpc get block results: %w", err)
+	}
+	for _, txRes := range res.TxsResults {
+		if txRes.Code != 0 {
+			continue
+		}
+		for _, e := range txRes.Events {
+			if e.Type != eventType {
+				continue
+			}
+			if err := f(e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// eventPackets returns the packets of the events of the given type emitted by the successful transactions of a block.
+func eventPackets(ctx context.Context, client blockResultsClient, height uint64, eventType string) ([]ibc.Packet, error) {
+	var packets []ibc.Packet
+	err := rangeBlockEvents(ctx, client, height, eventType, func(e abcitypes.Event) error {
+		packet, err := tendermint.PacketFromEvent(e)
+		if err != nil {
+			return err
+		}
+		packets = append(packets, packet)
+		return nil
+	})
+	return packets, err
+}
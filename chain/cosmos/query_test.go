@@ -0,0 +1,49 @@
+package cosmos
+
+import (
+	"context"
+	"testing"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+type mockBlockResultsClient struct {
+	res *coretypes.ResultBlockResults
+}
+
+func (m mockBlockResultsClient) BlockResults(context.Context, *int64) (*coretypes.ResultBlockResults, error) {
+	return m.res, nil
+}
+
+func TestEventPackets(t *testing.T) {
+	ackEvent := func(seq string) abcitypes.Event {
+		return abcitypes.Event{Type: "acknowledge_packet", Attributes: []abcitypes.EventAttribute{
+			{Key: "packet_sequence", Value: seq},
+			{Key: "packet_src_port", Value: "transfer"},
+			{Key: "packet_src_channel", Value: "channel-0"},
+			{Key: "packet_dst_port", Value: "transfer"},
+			{Key: "packet_dst_channel", Value: "channel-1"},
+		}}
+	}
+	client := mockBlockResultsClient{res: &coretypes.ResultBlockResults{TxsResults: []*abcitypes.ResponseDeliverTx{
+		{Events: []abcitypes.Event{{Type: "message"}, ackEvent("1")}},
+		// A second relayer's acknowledgement of the same packet, which failed.
+		{Code: 22, Events: []abcitypes.Event{ackEvent("1")}},
+		// A redundant acknowledgement that succeeded as a no-op emits no packet event.
+		{Events: []abcitypes.Event{{Type: "message"}}},
+		{Events: []abcitypes.Event{ackEvent("2")}},
+	}}}
+
+	packets, err := eventPackets(context.Background(), client, 5, "acknowledge_packet")
+	require.NoError(t, err)
+	require.Len(t, packets, 2)
+	require.Equal(t, uint64(1), packets[0].Sequence)
+	require.Equal(t, "channel-0", packets[0].SourceChannel)
+	require.Equal(t, uint64(2), packets[1].Sequence)
+
+	packets, err = eventPackets(context.Background(), client, 5, "timeout_packet")
+	require.NoError(t, err)
+	require.Empty(t, packets)
+}
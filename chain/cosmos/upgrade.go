@@ -0,0 +1,234 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+)
+
+const (
+	// DefaultUpgradeHaltHeightDelta is the default number of blocks after the proposal
+	// at which the chain halts for the upgrade.
+	DefaultUpgradeHaltHeightDelta = 10
+
+	// DefaultUpgradeBlocksAfter is the default number of blocks the upgraded chain must produce.
+	DefaultUpgradeBlocksAfter = 5
+
+	// DefaultUpgradeTimeout is the default time to wait for the chain to halt, and to produce blocks after the upgrade.
+	DefaultUpgradeTimeout = 2 * time.Minute
+
+	// How long the height must stay at the halt height for the chain to be considered halted.
+	upgradeHaltConfirmation = 10 * time.Second
+	upgradeHaltPollInterval = 500 * time.Millisecond
+)
+
+// UpgradePlan describes a software upgrade performed by PerformUpgrade.
+type UpgradePlan struct {
+	// Name of the upgrade, which must match an upgrade handler of the new version.
+	Name string
+
+	// Key submitting the upgrade proposal, holding at least Deposit.
+	KeyName string
+
+	// Proposal deposit, e.g. "500000000ustake". Must reach the chain's minimum deposit
+	// for the proposal to enter its voting period.
+	Deposit string
+
+	// Optional proposal title and description. Default to the upgrade name.
+	Title       string
+	Description string
+
+	// Version of the upgraded chain.
+	// The repository defaults to the repository of the chain's current image.
+	Image ibc.DockerImage
+
+	// Number of blocks after the proposal at which the chain halts.
+	// It must leave enough blocks for the voting period to end.
+	// Defaults to DefaultUpgradeHaltHeightDelta.
+	HaltHeightDelta uint64
+
+	// Number of blocks the upgraded chain must produce. Defaults to DefaultUpgradeBlocksAfter.
+	BlocksAfterUpgrade uint64
+
+	// Maximum time to wait for the chain to halt, and for the upgraded chain to produce blocks.
+	// Defaults to DefaultUpgradeTimeout.
+	Timeout time.Duration
+
+	// If set, checks that IBC transfers are relayed before and after the upgrade.
+	IBCCheck *UpgradeIBCCheck
+}
+
+// UpgradeIBCCheck describes an IBC transfer sent before and after an upgrade,
+// to check that a relayer keeps relaying across the upgrade.
+// The relayer must be running for the duration of the upgrade.
+type UpgradeIBCCheck struct {
+	// Source channel on the upgraded chain.
+	ChannelID string
+
+	// Key sending the transfers, and the recipient address on the counterparty chain.
+	KeyName   string
+	Recipient string
+
+	// Amount of the chain's denom sent in each transfer.
+	Amount int64
+
+	// Maximum number of blocks to wait for each transfer to be acknowledged. Defaults to 30.
+	AckBlocks uint64
+}
+
+// PerformUpgrade upgrades the chain through governance:
+// it submits a software upgrade proposal, votes yes with all validators,
+// waits for the proposal to pass and the chain to halt at the upgrade height,
+// then restarts all nodes with the new version.
+// It returns the height of the chain once the upgraded chain has produced plan.BlocksAfterUpgrade blocks.
+//
+// The chain's voting period must be short enough to end within plan.HaltHeightDelta blocks.
+func (c *CosmosChain) PerformUpgrade(ctx context.Context, plan UpgradePlan) (uint64, error) {
+	if plan.Name == "" || plan.KeyName == "" || plan.Deposit == "" || plan.Image.Version == "" {
+		return 0, errors.New("upgrade plan requires a name, key name, deposit and image version")
+	}
+	if plan.Title == "" {
+		plan.Title = plan.Name
+	}
+	if plan.Description == "" {
+		plan.Description = "Upgrade to " + plan.Name
+	}
+	if plan.Image.Repository == "" {
+		plan.Image.Repository = c.Config().Images[0].Repository
+	}
+	if plan.HaltHeightDelta == 0 {
+		plan.HaltHeightDelta = DefaultUpgradeHaltHeightDelta
+	}
+	if plan.BlocksAfterUpgrade == 0 {
+		plan.BlocksAfterUpgrade = DefaultUpgradeBlocksAfter
+	}
+	if plan.Timeout == 0 {
+		plan.Timeout = DefaultUpgradeTimeout
+	}
+
+	if plan.IBCCheck != nil {
+		if err := c.checkUpgradeIBC(ctx, *plan.IBCCheck); err != nil {
+			return 0, fmt.Errorf("ibc transfer before upgrade: %w", err)
+		}
+	}
+
+	height, err := c.Height(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get height before upgrade proposal: %w", err)
+	}
+	haltHeight := height + plan.HaltHeightDelta
+
+	tx, err := c.UpgradeProposal(ctx, plan.KeyName, SoftwareUpgradeProposal{
+		Deposit:     plan.Deposit,
+		Title:       plan.Title,
+		Name:        plan.Name,
+		Description: plan.Description,
+		Height:      haltHeight,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := c.VoteOnProposalAllValidators(ctx, tx.ProposalID, ProposalVoteYes); err != nil {
+		return 0, fmt.Errorf("failed to vote on upgrade proposal %s: %w", tx.ProposalID, err)
+	}
+
+	// The proposal must pass before the halt height, or the chain never halts.
+	if _, err := PollForProposalStatus(ctx, c, height, haltHeight-1, tx.ProposalID, ProposalStatusPassed); err != nil {
+		return 0, fmt.Errorf("upgrade proposal %s did not pass before halt height %d: %w", tx.ProposalID, haltHeight, err)
+	}
+
+	haltCtx, cancel := context.WithTimeout(ctx, plan.Timeout)
+	defer cancel()
+	if err := waitForHalt(haltCtx, c, haltHeight, upgradeHaltConfirmation, upgradeHaltPollInterval); err != nil {
+		return 0, err
+	}
+
+	if err := c.StopAllNodes(ctx); err != nil {
+		return 0, fmt.Errorf("failed to stop nodes for upgrade: %w", err)
+	}
+	c.UpgradeVersion(ctx, c.Validators[0].DockerClient, plan.Image.Repository, plan.Image.Version)
+	// Validators reach consensus on the first block after the halt height, resuming block production.
+	if err := c.StartAllNodes(ctx); err != nil {
+		return 0, fmt.Errorf("failed to start upgraded nodes: %w", err)
+	}
+
+	blocksCtx, cancel := context.WithTimeout(ctx, plan.Timeout)
+	defer cancel()
+	if err := testutil.WaitForBlocks(blocksCtx, int(plan.BlocksAfterUpgrade), c); err != nil {
+		return 0, fmt.Errorf("upgraded chain did not produce %d blocks: %w", plan.BlocksAfterUpgrade, err)
+	}
+	height, err = c.Height(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get height after upgrade: %w", err)
+	}
+	if height < haltHeight+plan.BlocksAfterUpgrade {
+		return 0, fmt.Errorf("upgraded chain at height %d, expected at least %d", height, haltHeight+plan.BlocksAfterUpgrade)
+	}
+
+	if plan.IBCCheck != nil {
+		if err := c.checkUpgradeIBC(ctx, *plan.IBCCheck); err != nil {
+			return 0, fmt.Errorf("ibc transfer after upgrade: %w", err)
+		}
+	}
+
+	return height, nil
+}
+
+// checkUpgradeIBC sends an IBC transfer and waits for its acknowledgement.
+func (c *CosmosChain) checkUpgradeIBC(ctx context.Context, check UpgradeIBCCheck) error {
+	ackBlocks := check.AckBlocks
+	if ackBlocks == 0 {
+		ackBlocks = 30
+	}
+	tx, err := c.SendIBCTransfer(ctx, check.ChannelID, check.KeyName, ibc.WalletAmount{
+		Address: check.Recipient,
+		Denom:   c.Config().Denom,
+		Amount:  check.Amount,
+	}, ibc.TransferOptions{})
+	if err != nil {
+		return err
+	}
+	if err := tx.Validate(); err != nil {
+		return err
+	}
+	_, err = testutil.PollForAck(ctx, c, tx.Height, tx.Height+ackBlocks, tx.Packet)
+	return err
+}
+
+// waitForHalt waits for the chain to stop at haltHeight, which it must not exceed.
+// The chain is considered halted once its height stays at haltHeight for the confirmation period.
+// Errors querying the height are retried, as nodes may be unresponsive while halting.
+func waitForHalt(ctx context.Context, chain testutil.ChainHeighter, haltHeight uint64, confirmation, interval time.Duration) error {
+	var (
+		last    uint64
+		reached time.Time
+	)
+	for {
+		h, err := chain.Height(ctx)
+		switch {
+		case err != nil:
+		case h > haltHeight:
+			return fmt.Errorf("chain did not halt at upgrade height %d, reached height %d", haltHeight, h)
+		case h == haltHeight:
+			if reached.IsZero() {
+				reached = time.Now()
+			}
+			if time.Since(reached) >= confirmation {
+				return nil
+			}
+		}
+		if err == nil {
+			last = h
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("chain did not halt at upgrade height %d, last height %d: %w", haltHeight, last, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
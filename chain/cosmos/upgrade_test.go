@@ -0,0 +1,50 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// haltingChain reports each height of a sequence on successive queries, then repeats the last one.
+type haltingChain struct {
+	heights []uint64
+	errs    []error
+}
+
+func (c *haltingChain) Height(context.Context) (uint64, error) {
+	h, err := c.heights[0], c.errs[0]
+	if len(c.heights) > 1 {
+		c.heights, c.errs = c.heights[1:], c.errs[1:]
+	}
+	return h, err
+}
+
+func TestWaitForHalt(t *testing.T) {
+	ctx := context.Background()
+	unavailable := errors.New("unavailable")
+
+	t.Run("halted", func(t *testing.T) {
+		// Node queries may fail while the chain halts.
+		chain := &haltingChain{heights: []uint64{8, 9, 0, 10}, errs: []error{nil, nil, unavailable, nil}}
+		require.NoError(t, waitForHalt(ctx, chain, 10, 5*time.Millisecond, time.Millisecond))
+	})
+
+	t.Run("past halt height", func(t *testing.T) {
+		chain := &haltingChain{heights: []uint64{10, 11}, errs: []error{nil, nil}}
+		err := waitForHalt(ctx, chain, 10, time.Second, time.Millisecond)
+		require.ErrorContains(t, err, "reached height 11")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		chain := &haltingChain{heights: []uint64{9}, errs: []error{nil}}
+		err := waitForHalt(ctx, chain, 10, time.Millisecond, time.Millisecond)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "last height 9")
+	})
+}
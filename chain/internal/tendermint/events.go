@@ -2,8 +2,11 @@ package tendermint
 
 import (
 	"encoding/base64"
+	"fmt"
+	"strconv"
 
 	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 )
 
 // AttributeValue returns an event attribute value given the eventType and attribute key tuple.
@@ -35,3 +38,35 @@ func AttributeValue(events []abcitypes.Event, eventType, attrKey string) (string
 	}
 	return "", false
 }
+
+// PacketFromEvent returns the packet described by the attributes of an ICS-4 packet event,
+// such as send_packet, recv_packet or write_acknowledgement.
+func PacketFromEvent(e abcitypes.Event) (ibc.Packet, error) {
+	attr := func(key string) string {
+		v, _ := AttributeValue([]abcitypes.Event{e}, e.Type, key)
+		return v
+	}
+
+	seq, err := strconv.ParseUint(attr("packet_sequence"), 10, 64)
+	if err != nil {
+		return ibc.Packet{}, fmt.Errorf("invalid packet sequence in %s event: %w", e.Type, err)
+	}
+	var timeoutTs uint64
+	if v := attr("packet_timeout_timestamp"); v != "" {
+		timeoutTs, err = strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return ibc.Packet{}, fmt.Errorf("invalid packet timeout timestamp in %s event: %w", e.Type, err)
+		}
+	}
+
+	return ibc.Packet{
+		Sequence:         seq,
+		SourcePort:       attr("packet_src_port"),
+		SourceChannel:    attr("packet_src_channel"),
+		DestPort:         attr("packet_dst_port"),
+		DestChannel:      attr("packet_dst_channel"),
+		Data:             []byte(attr("packet_data")),
+		TimeoutHeight:    attr("packet_timeout_height"),
+		TimeoutTimestamp: ibc.Nanoseconds(timeoutTs),
+	}, nil
+}
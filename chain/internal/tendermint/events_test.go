@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/stretchr/testify/require"
 )
 
@@ -36,3 +37,38 @@ func TestAttributeValue(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, "found2", found)
 }
+
+func TestPacketFromEvent(t *testing.T) {
+	attrs := func(kv ...string) []abcitypes.EventAttribute {
+		var attrs []abcitypes.EventAttribute
+		for i := 0; i < len(kv); i += 2 {
+			attrs = append(attrs, abcitypes.EventAttribute{Key: kv[i], Value: kv[i+1]})
+		}
+		return attrs
+	}
+
+	packet, err := PacketFromEvent(abcitypes.Event{Type: "send_packet", Attributes: attrs(
+		"packet_data", `{"amount":"1"}`,
+		"packet_timeout_height", "0-100",
+		"packet_timeout_timestamp", "1700000000000000000",
+		"packet_sequence", "7",
+		"packet_src_port", "transfer",
+		"packet_src_channel", "channel-0",
+		"packet_dst_port", "transfer",
+		"packet_dst_channel", "channel-3",
+	)})
+	require.NoError(t, err)
+	require.Equal(t, ibc.Packet{
+		Sequence:         7,
+		SourcePort:       "transfer",
+		SourceChannel:    "channel-0",
+		DestPort:         "transfer",
+		DestChannel:      "channel-3",
+		Data:             []byte(`{"amount":"1"}`),
+		TimeoutHeight:    "0-100",
+		TimeoutTimestamp: 1700000000000000000,
+	}, packet)
+
+	_, err = PacketFromEvent(abcitypes.Event{Type: "send_packet", Attributes: attrs("packet_sequence", "x")})
+	require.Error(t, err)
+}
@@ -233,6 +233,12 @@ func (tn *TendermintNode) StopContainer(ctx context.Context) error {
 	return tn.containerLifecycle.StopContainer(ctx)
 }
 
+// ContainerID returns the ID of the node's docker container.
+// It is empty until the container is created.
+func (tn *TendermintNode) ContainerID() string {
+	return tn.containerLifecycle.ContainerID()
+}
+
 func (tn *TendermintNode) StartContainer(ctx context.Context) error {
 	if err := tn.containerLifecycle.StartContainer(ctx); err != nil {
 		return err
@@ -0,0 +1,103 @@
+package penumbra
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+)
+
+// Balance is an amount of a denom held by a key, as reported by pcli.
+type Balance struct {
+	// Decimal amount, which may have a fractional part when the denom is a display denom.
+	Amount string
+	Denom  string
+}
+
+// balancePattern matches an amount directly followed by its denom, such as "1.5penumbra" or "100transfer/channel-0/uatom".
+var balancePattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)([a-zA-Z][a-zA-Z0-9/_.\-]*)$`)
+
+// parseBalances returns the balances listed in the output of pcli view balance.
+// Lines that do not list a balance, such as table headers and borders, are ignored.
+func parseBalances(out string) []Balance {
+	var balances []Balance
+	for _, line := range strings.Split(out, "\n") {
+		for _, field := range strings.Fields(line) {
+			if m := balancePattern.FindStringSubmatch(field); m != nil {
+				balances = append(balances, Balance{Amount: m[1], Denom: m[2]})
+			}
+		}
+	}
+	return balances
+}
+
+// displayExponent is the exponent of Penumbra display denoms relative to their base denoms,
+// e.g. 1penumbra is 1000000upenumbra.
+const displayExponent = 6
+
+// balanceAmount returns the total amount of the base denom in the balances.
+// The denom may also be an ICS-20 IBC denom, such as "ibc/27394F...",
+// matching the balances of the denom trace, as pcli reports them.
+func balanceAmount(balances []Balance, denom string) (int64, error) {
+	var total int64
+	for _, b := range balances {
+		var (
+			amount int64
+			err    error
+		)
+		switch {
+		case b.Denom == denom:
+			amount, err = strconv.ParseInt(b.Amount, 10, 64)
+		case "u"+b.Denom == denom:
+			amount, err = scaleAmount(b.Amount, displayExponent)
+		case strings.HasPrefix(denom, "ibc/") && strings.Contains(b.Denom, "/") &&
+			transfertypes.ParseDenomTrace(b.Denom).IBCDenom() == denom:
+			amount, err = strconv.ParseInt(b.Amount, 10, 64)
+		default:
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("invalid amount %q of %s: %w", b.Amount, b.Denom, err)
+		}
+		total += amount
+	}
+	return total, nil
+}
+
+// scaleAmount multiplies the decimal amount by 10^exp, which must result in an integer.
+func scaleAmount(amount string, exp int) (int64, error) {
+	whole, frac, _ := strings.Cut(amount, ".")
+	if len(frac) > exp {
+		return 0, errors.New("too many decimal places")
+	}
+	return strconv.ParseInt(whole+frac+strings.Repeat("0", exp-len(frac)), 10, 64)
+}
+
+// parseAddress returns the address at index 0 in the output of pcli addr list.
+func parseAddress(out, prefix string) (string, error) {
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "0" {
+			continue
+		}
+		for _, f := range fields[1:] {
+			if strings.HasPrefix(f, prefix) {
+				return f, nil
+			}
+		}
+	}
+	return "", errors.New("address not found")
+}
+
+// channelNumber returns the number of an IBC channel identifier, e.g. 3 for "channel-3",
+// which is how pcli refers to channels.
+func channelNumber(channelID string) (uint64, error) {
+	n, err := strconv.ParseUint(strings.TrimPrefix(channelID, "channel-"), 10, 64)
+	if err != nil || !strings.HasPrefix(channelID, "channel-") {
+		return 0, fmt.Errorf("invalid channel identifier %q", channelID)
+	}
+	return n, nil
+}
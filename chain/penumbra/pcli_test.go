@@ -0,0 +1,109 @@
+package penumbra
+
+import (
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	chantypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestBalances(t *testing.T) {
+	const out = `
+ Account  Amount
+ 0        1.5penumbra
+ 0        100transfer/channel-0/uatom
+ 1        2penumbra
+`
+	balances := parseBalances(out)
+	require.Equal(t, []Balance{
+		{Amount: "1.5", Denom: "penumbra"},
+		{Amount: "100", Denom: "transfer/channel-0/uatom"},
+		{Amount: "2", Denom: "penumbra"},
+	}, balances)
+
+	amount, err := balanceAmount(balances, "upenumbra")
+	require.NoError(t, err)
+	require.Equal(t, int64(3_500_000), amount)
+
+	ibcDenom := transfertypes.ParseDenomTrace("transfer/channel-0/uatom").IBCDenom()
+	amount, err = balanceAmount(balances, ibcDenom)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), amount)
+
+	amount, err = balanceAmount(balances, "uother")
+	require.NoError(t, err)
+	require.Zero(t, amount)
+
+	_, err = balanceAmount([]Balance{{Amount: "0.0000001", Denom: "penumbra"}}, "upenumbra")
+	require.Error(t, err)
+}
+
+func TestParseAddress(t *testing.T) {
+	const out = `
+ Index  Label    Address
+ 0      Default  penumbrav2t1abc
+ 1      Other    penumbrav2t1def
+`
+	addr, err := parseAddress(out, "penumbra")
+	require.NoError(t, err)
+	require.Equal(t, "penumbrav2t1abc", addr)
+
+	_, err = parseAddress("", "penumbra")
+	require.Error(t, err)
+}
+
+func TestChannelNumber(t *testing.T) {
+	n, err := channelNumber("channel-12")
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), n)
+
+	_, err = channelNumber("connection-1")
+	require.Error(t, err)
+}
+
+func TestFindAnyValues(t *testing.T) {
+	msg := chantypes.MsgAcknowledgement{
+		Packet: chantypes.Packet{
+			Sequence:      3,
+			SourcePort:    "transfer",
+			SourceChannel: "channel-0",
+			Data:          []byte("data"),
+			TimeoutHeight: clienttypes.NewHeight(0, 100),
+		},
+		Acknowledgement: []byte(`{"result":"AQ=="}`),
+	}
+	any, err := codectypes.NewAnyWithValue(&msg)
+	require.NoError(t, err)
+	anyBz, err := any.Marshal()
+	require.NoError(t, err)
+
+	// A transaction nesting the message in an action, alongside unrelated fields.
+	var action []byte
+	action = protowire.AppendTag(action, 1, protowire.VarintType)
+	action = protowire.AppendVarint(action, 7)
+	action = protowire.AppendTag(action, 2, protowire.BytesType)
+	action = protowire.AppendBytes(action, anyBz)
+	var tx []byte
+	tx = protowire.AppendTag(tx, 1, protowire.BytesType)
+	tx = protowire.AppendBytes(tx, []byte("not a message"))
+	tx = protowire.AppendTag(tx, 3, protowire.BytesType)
+	tx = protowire.AppendBytes(tx, action)
+
+	values := findAnyValues(tx, typeURLAcknowledgement, 0)
+	require.Len(t, values, 1)
+	var got chantypes.MsgAcknowledgement
+	require.NoError(t, got.Unmarshal(values[0]))
+	require.Equal(t, msg.Acknowledgement, got.Acknowledgement)
+
+	p := ibc.PacketFromChannel(got.Packet)
+	require.Equal(t, uint64(3), p.Sequence)
+	require.Equal(t, "0-100", p.TimeoutHeight)
+	require.Equal(t, []byte("data"), p.Data)
+
+	require.Empty(t, findAnyValues(tx, typeURLTimeout, 0))
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/docker/docker/client"
@@ -35,6 +36,7 @@ type PenumbraAppNode struct {
 
 const (
 	valKey         = "validator"
+	faucetKey      = "faucet"
 	rpcPort        = "26657/tcp"
 	tendermintPort = "26658/tcp"
 	grpcPort       = "9090/tcp"
@@ -155,50 +157,36 @@ func (p *PenumbraAppNode) GenerateGenesisFile(
 	return err
 }
 
+// GetAddress returns the default address of the key, as the bytes of its bech32m encoding.
 func (p *PenumbraAppNode) GetAddress(ctx context.Context, keyName string) ([]byte, error) {
-	cmd := []string{"pcli", "-d", p.HomeDir(), "addr", "list"}
-	stdout, _, err := p.Exec(ctx, cmd, nil)
+	addr, err := p.GetAddressBech32m(ctx, keyName)
 	if err != nil {
 		return nil, err
 	}
-	addresses := strings.Split(string(stdout), "\n")
-	for _, address := range addresses {
-		fields := strings.Fields(address)
-		if len(fields) < 3 {
-			continue
-		}
-		if fields[1] == keyName {
-			// TODO penumbra address is bech32m. need to decode to bytes here
-			return []byte(fields[2]), nil
-		}
-	}
-	return []byte{}, errors.New("address not found")
+	return []byte(addr), nil
 }
 
+// GetAddressBech32m returns the default address of the key.
 func (p *PenumbraAppNode) GetAddressBech32m(ctx context.Context, keyName string) (string, error) {
-	cmd := []string{"pcli", "-d", p.HomeDir(), "addr", "list"}
-	stdout, _, err := p.Exec(ctx, cmd, nil)
+	stdout, _, err := p.Exec(ctx, p.pcliCommand(keyName, "addr", "list"), nil)
 	if err != nil {
 		return "", err
 	}
-	addresses := strings.Split(string(stdout), "\n")
-	for _, address := range addresses {
-		fields := strings.Fields(address)
-		if len(fields) < 3 {
-			continue
-		}
-		if fields[1] == keyName {
-			return fields[2], nil
-		}
-	}
-	return "", errors.New("address not found")
-
+	return parseAddress(string(stdout), p.Chain.Config().Bech32Prefix)
 }
 
+// SendFunds sends funds from the key to the address on the chain.
 func (p *PenumbraAppNode) SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
-	return errors.New("not yet implemented")
+	cmd := p.pcliCommand(keyName,
+		"tx", "send", fmt.Sprintf("%d%s", amount.Amount, amount.Denom),
+		"--to", amount.Address,
+	)
+	_, _, err := p.Exec(ctx, cmd, nil)
+	return err
 }
 
+// SendIBCTransfer withdraws funds from the key to the address on the counterparty chain of the channel,
+// and returns the transaction that sent the transfer packet once it is committed.
 func (p *PenumbraAppNode) SendIBCTransfer(
 	ctx context.Context,
 	channelID string,
@@ -206,7 +194,57 @@ func (p *PenumbraAppNode) SendIBCTransfer(
 	amount ibc.WalletAmount,
 	options ibc.TransferOptions,
 ) (ibc.Tx, error) {
-	return ibc.Tx{}, errors.New("not yet implemented")
+	if options.Memo != "" {
+		return ibc.Tx{}, errors.New("penumbra transfers do not support memos")
+	}
+	chain, ok := p.Chain.(*PenumbraChain)
+	if !ok {
+		return ibc.Tx{}, fmt.Errorf("unexpected chain type %T", p.Chain)
+	}
+	channel, err := channelNumber(channelID)
+	if err != nil {
+		return ibc.Tx{}, err
+	}
+	cmd := p.pcliCommand(keyName,
+		"tx", "withdraw",
+		"--to", amount.Address,
+		"--channel", strconv.FormatUint(channel, 10),
+	)
+	if options.Timeout != nil {
+		if options.Timeout.NanoSeconds > 0 {
+			cmd = append(cmd, "--timeout-timestamp", strconv.FormatUint(options.Timeout.NanoSeconds, 10))
+		} else if options.Timeout.Height > 0 {
+			cmd = append(cmd, "--timeout-height", fmt.Sprintf("0-%d", options.Timeout.Height))
+		}
+	}
+	cmd = append(cmd, fmt.Sprintf("%d%s", amount.Amount, amount.Denom))
+
+	startHeight, err := chain.Height(ctx)
+	if err != nil {
+		return ibc.Tx{}, err
+	}
+	if _, _, err := p.Exec(ctx, cmd, nil); err != nil {
+		return ibc.Tx{}, fmt.Errorf("send ibc transfer: %w", err)
+	}
+	return chain.findSentTransfer(ctx, startHeight, channelID, amount)
+}
+
+// Balances returns the balances of the key, in the amounts and denoms reported by pcli.
+func (p *PenumbraAppNode) Balances(ctx context.Context, keyName string) ([]Balance, error) {
+	stdout, _, err := p.Exec(ctx, p.pcliCommand(keyName, "view", "balance"), nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseBalances(string(stdout)), nil
+}
+
+// pcliCommand returns a pcli command using the key's wallet, connected to the node.
+func (p *PenumbraAppNode) pcliCommand(keyName string, args ...string) []string {
+	return append([]string{
+		"pcli",
+		"-d", filepath.Join(p.HomeDir(), "keys", keyName),
+		"--node", fmt.Sprintf("http://%s:%s", p.HostName(), strings.Split(grpcPort, "/")[0]),
+	}, args...)
 }
 
 func (p *PenumbraAppNode) CreateNodeContainer(ctx context.Context) error {
@@ -219,6 +257,12 @@ func (p *PenumbraAppNode) StopContainer(ctx context.Context) error {
 	return p.containerLifecycle.StopContainer(ctx)
 }
 
+// ContainerID returns the ID of the node's docker container.
+// It is empty until the container is created.
+func (p *PenumbraAppNode) ContainerID() string {
+	return p.containerLifecycle.ContainerID()
+}
+
 func (p *PenumbraAppNode) StartContainer(ctx context.Context) error {
 	if err := p.containerLifecycle.StartContainer(ctx); err != nil {
 		return err
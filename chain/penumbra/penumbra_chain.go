@@ -8,6 +8,7 @@ import (
 	"io"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/BurntSushi/toml"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -15,6 +16,8 @@ import (
 	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	chantypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
 	"github.com/docker/docker/api/types"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
@@ -27,6 +30,14 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+const (
+	typeURLAcknowledgement = "/ibc.core.channel.v1.MsgAcknowledgement"
+	typeURLTimeout         = "/ibc.core.channel.v1.MsgTimeout"
+
+	// Maximum number of blocks to wait for a transfer sent by pcli to be committed.
+	sentTransferBlocks = 10
+)
+
 type PenumbraNode struct {
 	TendermintNode  *tendermint.TendermintNode
 	PenumbraAppNode *PenumbraAppNode
@@ -42,6 +53,20 @@ type PenumbraChain struct {
 	numFullNodes  int
 	PenumbraNodes PenumbraNodes
 	keyring       keyring.Keyring
+
+	// Key names by address, for querying balances, which requires the viewing key of the address.
+	addrKeysMu sync.Mutex
+	addrKeys   map[string]string
+
+	// Transfer packets already returned by SendIBCTransfer.
+	sentPacketsMu sync.Mutex
+	sentPackets   map[sentPacket]bool
+}
+
+// sentPacket identifies a packet sent by the chain.
+type sentPacket struct {
+	channelID string
+	sequence  uint64
 }
 
 type PenumbraValidatorDefinition struct {
@@ -85,15 +110,46 @@ func NewPenumbraChain(log *zap.Logger, testName string, chainConfig ibc.ChainCon
 		numValidators: numValidators,
 		numFullNodes:  numFullNodes,
 		keyring:       kr,
+		addrKeys:      make(map[string]string),
+		sentPackets:   make(map[sentPacket]bool),
 	}
 }
 
+// Acknowledgements implements ibc.Chain, returning all acknowledgments in block at height
 func (c *PenumbraChain) Acknowledgements(ctx context.Context, height uint64) ([]ibc.PacketAcknowledgement, error) {
-	panic("implement me")
+	var acks []ibc.PacketAcknowledgement
+	err := rangeBlockIBCMessages(ctx, c.getRelayerNode().TendermintNode.Client, height, typeURLAcknowledgement, func(bz []byte) error {
+		var msg chantypes.MsgAcknowledgement
+		if err := msg.Unmarshal(bz); err != nil {
+			return fmt.Errorf("decode acknowledgement: %w", err)
+		}
+		acks = append(acks, ibc.PacketAcknowledgement{
+			Packet:          ibc.PacketFromChannel(msg.Packet),
+			Acknowledgement: msg.Acknowledgement,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find acknowledgements at height %d: %w", height, err)
+	}
+	return acks, nil
 }
 
+// Timeouts implements ibc.Chain, returning all timeouts in block at height
 func (c *PenumbraChain) Timeouts(ctx context.Context, height uint64) ([]ibc.PacketTimeout, error) {
-	panic("implement me")
+	var timeouts []ibc.PacketTimeout
+	err := rangeBlockIBCMessages(ctx, c.getRelayerNode().TendermintNode.Client, height, typeURLTimeout, func(bz []byte) error {
+		var msg chantypes.MsgTimeout
+		if err := msg.Unmarshal(bz); err != nil {
+			return fmt.Errorf("decode timeout: %w", err)
+		}
+		timeouts = append(timeouts, ibc.PacketTimeout{Packet: ibc.PacketFromChannel(msg.Packet)})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find timeouts at height %d: %w", height, err)
+	}
+	return timeouts, nil
 }
 
 // Implements Chain interface
@@ -111,6 +167,15 @@ func (c *PenumbraChain) Exec(ctx context.Context, cmd []string, env []string) (s
 	return c.getRelayerNode().PenumbraAppNode.Exec(ctx, cmd, env)
 }
 
+// ContainerIDs returns the docker container IDs of the tendermint and pd containers for every node.
+func (c *PenumbraChain) ContainerIDs() []string {
+	ids := make([]string, 0, 2*len(c.PenumbraNodes))
+	for _, n := range c.PenumbraNodes {
+		ids = append(ids, n.TendermintNode.ContainerID(), n.PenumbraAppNode.ContainerID())
+	}
+	return ids
+}
+
 func (c *PenumbraChain) getRelayerNode() PenumbraNode {
 	if len(c.PenumbraNodes) > c.numValidators {
 		// use first full node
@@ -157,7 +222,16 @@ func (c *PenumbraChain) RecoverKey(ctx context.Context, name, mnemonic string) e
 
 // Implements Chain interface
 func (c *PenumbraChain) GetAddress(ctx context.Context, keyName string) ([]byte, error) {
-	return c.getRelayerNode().PenumbraAppNode.GetAddress(ctx, keyName)
+	addr, err := c.getRelayerNode().PenumbraAppNode.GetAddress(ctx, keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	c.addrKeysMu.Lock()
+	c.addrKeys[string(addr)] = keyName
+	c.addrKeysMu.Unlock()
+
+	return addr, nil
 }
 
 // BuildWallet will return a Penumbra wallet
@@ -185,6 +259,10 @@ func (c *PenumbraChain) BuildWallet(ctx context.Context, keyName string, mnemoni
 // BuildRelayerWallet will return a Penumbra wallet populated with the mnemonic so that the wallet can
 // be restored in the relayer node using the mnemonic. After it is built, that address is included in
 // genesis with some funds.
+//
+// The mnemonic is also imported as a key on the chain, whose address is the wallet address,
+// so that GetBalance can query the relayer's balance both by the wallet address
+// and by the address relayers derive from the mnemonic.
 func (c *PenumbraChain) BuildRelayerWallet(ctx context.Context, keyName string) (ibc.Wallet, error) {
 	coinType, err := strconv.ParseUint(c.cfg.CoinType, 10, 32)
 	if err != nil {
@@ -202,11 +280,23 @@ func (c *PenumbraChain) BuildRelayerWallet(ctx context.Context, keyName string)
 		return nil, fmt.Errorf("failed to create mnemonic: %w", err)
 	}
 
-	addrBytes, err := info.GetAddress()
+	relayerAddr, err := info.GetAddress()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get address: %w", err)
 	}
 
+	if err := c.RecoverKey(ctx, keyName, mnemonic); err != nil {
+		return nil, fmt.Errorf("failed to recover key with name %q on chain %s: %w", keyName, c.cfg.Name, err)
+	}
+	addrBytes, err := c.GetAddress(ctx, keyName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account address for key %q on chain %s: %w", keyName, c.cfg.Name, err)
+	}
+
+	c.addrKeysMu.Lock()
+	c.addrKeys[NewWallet(keyName, relayerAddr, mnemonic, c.cfg).FormattedAddress()] = keyName
+	c.addrKeysMu.Unlock()
+
 	return NewWallet(keyName, addrBytes, mnemonic, c.cfg), nil
 }
 
@@ -226,9 +316,83 @@ func (c *PenumbraChain) SendIBCTransfer(
 	return c.getRelayerNode().PenumbraAppNode.SendIBCTransfer(ctx, channelID, keyName, amount, options)
 }
 
+// findSentTransfer returns the first transaction after startHeight that sent the amount
+// in a transfer packet on the channel, skipping the packets already returned for earlier transfers.
+func (c *PenumbraChain) findSentTransfer(ctx context.Context, startHeight uint64, channelID string, amount ibc.WalletAmount) (ibc.Tx, error) {
+	client := c.getRelayerNode().TendermintNode.Client
+	poll := func(ctx context.Context, height uint64) (ibc.Tx, error) {
+		h := int64(height)
+		res, err := client.BlockResults(ctx, &h)
+		if err != nil {
+			return ibc.Tx{}, fmt.Errorf("tendermint rpc get block results: %w", err)
+		}
+		for i, txRes := range res.TxsResults {
+			if txRes.Code != 0 {
+				continue
+			}
+			for _, e := range txRes.Events {
+				if e.Type != "send_packet" {
+					continue
+				}
+				packet, err := tendermint.PacketFromEvent(e)
+				if err != nil {
+					return ibc.Tx{}, err
+				}
+				if packet.SourceChannel != channelID || !isTransferOf(packet, amount) || !c.claimSentPacket(packet) {
+					continue
+				}
+				block, err := client.Block(ctx, &h)
+				if err != nil {
+					return ibc.Tx{}, fmt.Errorf("tendermint rpc get block: %w", err)
+				}
+				return ibc.Tx{
+					Height:   height,
+					TxHash:   fmt.Sprintf("%X", block.Block.Txs[i].Hash()),
+					GasSpent: txRes.GasUsed,
+					Packet:   packet,
+				}, nil
+			}
+		}
+		return ibc.Tx{}, testutil.ErrNotFound
+	}
+	bp := testutil.BlockPoller[ibc.Tx]{CurrentHeight: c.Height, PollFunc: poll}
+	tx, err := bp.DoPoll(ctx, startHeight+1, startHeight+sentTransferBlocks)
+	if err != nil {
+		return ibc.Tx{}, fmt.Errorf("find sent transfer to %s on %s: %w", amount.Address, channelID, err)
+	}
+	return tx, nil
+}
+
+// claimSentPacket reports whether the packet was not yet returned for a transfer, and records it as returned.
+func (c *PenumbraChain) claimSentPacket(packet ibc.Packet) bool {
+	key := sentPacket{channelID: packet.SourceChannel, sequence: packet.Sequence}
+	c.sentPacketsMu.Lock()
+	defer c.sentPacketsMu.Unlock()
+	if c.sentPackets[key] {
+		return false
+	}
+	c.sentPackets[key] = true
+	return true
+}
+
+// isTransferOf reports whether the packet carries ICS-20 packet data transferring the amount to its address.
+// The denom matches either the denom trace of the packet or its IBC denom.
+func isTransferOf(packet ibc.Packet, amount ibc.WalletAmount) bool {
+	var data transfertypes.FungibleTokenPacketData
+	if err := json.Unmarshal(packet.Data, &data); err != nil {
+		return false
+	}
+	if data.Receiver != amount.Address || data.Amount != strconv.FormatInt(amount.Amount, 10) {
+		return false
+	}
+	return data.Denom == amount.Denom || transfertypes.ParseDenomTrace(data.Denom).IBCDenom() == amount.Denom
+}
+
 // Implements Chain interface
+//
+// Penumbra does not support exporting its state as a genesis file.
 func (c *PenumbraChain) ExportState(ctx context.Context, height int64) (string, error) {
-	panic("implement me")
+	return "", errors.New("penumbra does not support exporting state")
 }
 
 func (c *PenumbraChain) Height(ctx context.Context) (uint64, error) {
@@ -236,8 +400,22 @@ func (c *PenumbraChain) Height(ctx context.Context) (uint64, error) {
 }
 
 // Implements Chain interface
+//
+// Penumbra balances are private, so only the balances of addresses of keys on the chain can be queried,
+// such as wallets built with BuildWallet or BuildRelayerWallet.
 func (c *PenumbraChain) GetBalance(ctx context.Context, address string, denom string) (int64, error) {
-	panic("implement me")
+	c.addrKeysMu.Lock()
+	keyName, ok := c.addrKeys[address]
+	c.addrKeysMu.Unlock()
+	if !ok {
+		return 0, fmt.Errorf("no key for address %s", address)
+	}
+
+	balances, err := c.getRelayerNode().PenumbraAppNode.Balances(ctx, keyName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get balances of key %s: %w", keyName, err)
+	}
+	return balanceAmount(balances, denom)
 }
 
 // Implements Chain interface
@@ -466,6 +644,21 @@ func (c *PenumbraChain) Start(testName string, ctx context.Context, additionalGe
 		return fmt.Errorf("waiting to init full nodes' files: %w", err)
 	}
 
+	// Fund a faucet key on the node that submits transactions, so that test users can be funded from it.
+	faucet := c.getRelayerNode().PenumbraAppNode
+	if err := faucet.CreateKey(ctx, faucetKey); err != nil {
+		return fmt.Errorf("error generating faucet wallet on penumbra node: %w", err)
+	}
+	faucetAddr, err := faucet.GetAddressBech32m(ctx, faucetKey)
+	if err != nil {
+		return fmt.Errorf("error getting faucet address: %w", err)
+	}
+	allocations = append(allocations, PenumbraGenesisAppStateAllocation{
+		Amount:  1_000_000_000_000,
+		Denom:   chainCfg.Denom,
+		Address: faucetAddr,
+	})
+
 	firstVal := c.PenumbraNodes[0]
 	if err := firstVal.PenumbraAppNode.GenerateGenesisFile(ctx, chainCfg.ChainID, validatorDefinitions, allocations); err != nil {
 		return fmt.Errorf("generating genesis file: %w", err)
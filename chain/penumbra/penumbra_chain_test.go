@@ -0,0 +1,36 @@
+package penumbra
+
+import (
+	"encoding/json"
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTransferOf(t *testing.T) {
+	data, err := json.Marshal(transfertypes.NewFungibleTokenPacketData("transfer/channel-0/uatom", "100", "penumbrav2t1abc", "cosmos1receiver", ""))
+	require.NoError(t, err)
+	packet := ibc.Packet{Sequence: 1, SourceChannel: "channel-0", Data: data}
+
+	amount := ibc.WalletAmount{Address: "cosmos1receiver", Denom: "transfer/channel-0/uatom", Amount: 100}
+	require.True(t, isTransferOf(packet, amount))
+
+	amount.Denom = transfertypes.ParseDenomTrace("transfer/channel-0/uatom").IBCDenom()
+	require.True(t, isTransferOf(packet, amount))
+
+	require.False(t, isTransferOf(packet, ibc.WalletAmount{Address: "cosmos1receiver", Denom: "upenumbra", Amount: 100}))
+	require.False(t, isTransferOf(packet, ibc.WalletAmount{Address: "cosmos1receiver", Denom: "transfer/channel-0/uatom", Amount: 10}))
+	require.False(t, isTransferOf(packet, ibc.WalletAmount{Address: "cosmos1receiver2", Denom: "transfer/channel-0/uatom", Amount: 100}))
+	require.False(t, isTransferOf(ibc.Packet{Data: []byte("cosmos1receiver")}, amount))
+}
+
+func TestClaimSentPacket(t *testing.T) {
+	c := &PenumbraChain{sentPackets: make(map[sentPacket]bool)}
+
+	require.True(t, c.claimSentPacket(ibc.Packet{SourceChannel: "channel-0", Sequence: 1}))
+	require.False(t, c.claimSentPacket(ibc.Packet{SourceChannel: "channel-0", Sequence: 1}))
+	require.True(t, c.claimSentPacket(ibc.Packet{SourceChannel: "channel-0", Sequence: 2}))
+	require.True(t, c.claimSentPacket(ibc.Packet{SourceChannel: "channel-1", Sequence: 1}))
+}
@@ -0,0 +1,99 @@
+package penumbra
+
+import (
+	"context"
+	"fmt"
+
+	tmtypes "github.com/cometbft/cometbft/rpc/core/types"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Maximum depth of nested messages searched for IBC messages within a transaction.
+const maxMessageDepth = 16
+
+type blockClient interface {
+	Block(ctx context.Context, height *int64) (*tmtypes.ResultBlock, error)
+}
+
+// rangeBlockIBCMessages yields to f the encoded value of every IBC message of the given type URL
+// relayed by the block's transactions.
+//
+// Penumbra transactions are not Cosmos SDK transactions, but relay actions embed the relayed
+// IBC messages as google.protobuf.Any, which are found without decoding the rest of the transaction.
+func rangeBlockIBCMessages(ctx context.Context, client blockClient, height uint64, typeURL string, f func([]byte) error) error {
+	h := int64(height)
+	block, err := client.Block(ctx, &h)
+	if err != nil {
+		return fmt.Errorf("tendermint rpc get block: %w", err)
+	}
+	for _, tx := range block.Block.Txs {
+		for _, v := range findAnyValues(tx, typeURL, 0) {
+			if err := f(v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// findAnyValues returns the values of all google.protobuf.Any messages of the type URL nested in the encoded message b.
+func findAnyValues(b []byte, typeURL string, depth int) [][]byte {
+	if depth > maxMessageDepth {
+		return nil
+	}
+
+	if v, ok := anyValue(b, typeURL); ok {
+		return [][]byte{v}
+	}
+
+	var values [][]byte
+	for len(b) > 0 {
+		_, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			// Not a message, e.g. a string or packed scalars.
+			return values
+		}
+		b = b[n:]
+		if typ == protowire.BytesType {
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return values
+			}
+			values = append(values, findAnyValues(v, typeURL, depth+1)...)
+			b = b[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(0, typ, b)
+		if n < 0 {
+			return values
+		}
+		b = b[n:]
+	}
+	return values
+}
+
+// anyValue returns the value of b if it is an encoded google.protobuf.Any of the type URL.
+func anyValue(b []byte, typeURL string) ([]byte, bool) {
+	var (
+		url   string
+		value []byte
+	)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 || typ != protowire.BytesType || (num != 1 && num != 2) {
+			return nil, false
+		}
+		b = b[n:]
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return nil, false
+		}
+		b = b[n:]
+		if num == 1 {
+			url = string(v)
+		} else {
+			value = v
+		}
+	}
+	return value, url == typeURL
+}
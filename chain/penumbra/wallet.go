@@ -1,6 +1,8 @@
 package penumbra
 
 import (
+	"strings"
+
 	"github.com/cosmos/cosmos-sdk/types"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 )
@@ -27,8 +29,12 @@ func (w *PenumbraWallet) KeyName() string {
 	return w.keyName
 }
 
-// Get Address formatted with chain's prefix
+// Get Address formatted with chain's prefix.
+// Addresses of keys on the chain are already bech32m encoded, and returned as is.
 func (w *PenumbraWallet) FormattedAddress() string {
+	if strings.HasPrefix(string(w.address), w.chainCfg.Bech32Prefix) {
+		return string(w.address)
+	}
 	return types.MustBech32ifyAddressBytes(w.chainCfg.Bech32Prefix, w.address)
 }
 
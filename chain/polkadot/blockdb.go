@@ -0,0 +1,330 @@
+package polkadot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gsrpc "github.com/misko9/go-substrate-rpc-client/v4"
+	"github.com/misko9/go-substrate-rpc-client/v4/scale"
+	gstypes "github.com/misko9/go-substrate-rpc-client/v4/types"
+	"github.com/misko9/go-substrate-rpc-client/v4/types/codec"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"go.uber.org/zap"
+)
+
+// Extrinsic is the JSON saved in the block database for a decoded extrinsic.
+type Extrinsic struct {
+	Pallet string `json:"pallet"`
+	Call   string `json:"call"`
+	Signer any    `json:"signer,omitempty"` // Omitted for unsigned extrinsics, such as inherents.
+	Args   any    `json:"args,omitempty"`
+}
+
+// pallet-ibc packet events, and the equivalent ibc-go event types, so that packets relayed
+// to and from Substrate chains are found alongside those of Cosmos chains.
+var ibcPacketEventTypes = map[string]string{
+	"SendPacket":           "send_packet",
+	"ReceivePacket":        "recv_packet",
+	"WriteAcknowledgement": "write_acknowledgement",
+	"AcknowledgePacket":    "acknowledge_packet",
+	"TimeoutPacket":        "timeout_packet",
+}
+
+// pallet-ibc packet event fields, and the equivalent ibc-go event attributes.
+var ibcPacketAttrKeys = map[string]string{
+	"sequence":     "packet_sequence",
+	"port_id":      "packet_src_port",
+	"channel_id":   "packet_src_channel",
+	"dest_port":    "packet_dst_port",
+	"dest_channel": "packet_dst_channel",
+}
+
+// blockFinder finds the blocks of a relay chain or parachain, with their extrinsics and runtime events
+// decoded from the chain's metadata.
+type blockFinder struct {
+	log *zap.Logger
+	api *gsrpc.SubstrateAPI
+
+	mu sync.Mutex
+	// Metadata only changes on runtime upgrades, so decoders are cached by runtime spec version.
+	decoders map[uint32]typeDecoder
+}
+
+func newBlockFinder(log *zap.Logger, api *gsrpc.SubstrateAPI) *blockFinder {
+	return &blockFinder{log: log, api: api, decoders: make(map[uint32]typeDecoder)}
+}
+
+// FindTxs implements blockdb.TxFinder.
+func (f *blockFinder) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx, error) {
+	block, err := f.FindBlock(ctx, height)
+	return block.Txs, err
+}
+
+// FindBlock implements blockdb.BlockFinder.
+// Each extrinsic is a transaction with the runtime events it emitted.
+// The events emitted while initializing and finalizing the block are its begin and end block events.
+func (f *blockFinder) FindBlock(ctx context.Context, height uint64) (blockdb.Block, error) {
+	latest, err := f.api.RPC.Chain.GetHeaderLatest()
+	if err != nil {
+		return blockdb.Block{}, fmt.Errorf("get latest header: %w", err)
+	}
+	if uint64(latest.Number) < height {
+		// Worded like the Tendermint error, which the Collector expects while waiting for the next block.
+		return blockdb.Block{}, fmt.Errorf("height %d must be less than or equal to the current blockchain height %d", height, latest.Number)
+	}
+
+	hash, err := f.api.RPC.Chain.GetBlockHash(height)
+	if err != nil {
+		return blockdb.Block{}, fmt.Errorf("get block hash: %w", err)
+	}
+	dec, err := f.decoder(hash)
+	if err != nil {
+		return blockdb.Block{}, err
+	}
+
+	// The block is fetched raw, as the client can only decode extrinsics with the default signed extensions.
+	var raw struct {
+		Block struct {
+			Header struct {
+				StateRoot string `json:"stateRoot"`
+			} `json:"header"`
+			Extrinsics []string `json:"extrinsics"`
+		} `json:"block"`
+	}
+	if err := f.api.Client.Call(&raw, "chain_getBlock", hash.Hex()); err != nil {
+		return blockdb.Block{}, fmt.Errorf("get block: %w", err)
+	}
+
+	block := blockdb.Block{
+		Height:  height,
+		AppHash: raw.Block.Header.StateRoot,
+		Txs:     make([]blockdb.Tx, len(raw.Block.Extrinsics)),
+	}
+	for i, ext := range raw.Block.Extrinsics {
+		block.Txs[i].Data = []byte(fmt.Sprintf(`{"data":"%s"}`, ext))
+
+		b, err := codec.HexDecodeString(ext)
+		if err != nil {
+			f.log.Info("Failed to decode extrinsic hex", zap.Uint64("height", height), zap.Error(err))
+			continue
+		}
+		decoded, err := dec.DecodeExtrinsic(b)
+		if err != nil {
+			f.log.Info("Failed to decode extrinsic", zap.Uint64("height", height), zap.Error(err))
+			continue
+		}
+		data, err := json.Marshal(decoded)
+		if err != nil {
+			f.log.Info("Failed to marshal extrinsic to json", zap.Uint64("height", height), zap.Error(err))
+			continue
+		}
+		block.Txs[i].Data = data
+
+		if decoded.Pallet == "Timestamp" && decoded.Call == "set" {
+			block.Time = extrinsicTime(decoded.Args)
+		}
+	}
+
+	records, err := f.events(dec, hash)
+	if err != nil {
+		return blockdb.Block{}, err
+	}
+	for _, r := range records {
+		switch {
+		case r.Phase == "Initialization":
+			block.BeginBlockEvents = append(block.BeginBlockEvents, r.Events...)
+		case r.Phase == "Finalization":
+			block.EndBlockEvents = append(block.EndBlockEvents, r.Events...)
+		case r.Extrinsic < len(block.Txs):
+			block.Txs[r.Extrinsic].Events = append(block.Txs[r.Extrinsic].Events, r.Events...)
+		}
+	}
+
+	return block, nil
+}
+
+func (f *blockFinder) decoder(hash gstypes.Hash) (typeDecoder, error) {
+	rv, err := f.api.RPC.State.GetRuntimeVersion(hash)
+	if err != nil {
+		return typeDecoder{}, fmt.Errorf("get runtime version: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if dec, ok := f.decoders[uint32(rv.SpecVersion)]; ok {
+		return dec, nil
+	}
+	meta, err := f.api.RPC.State.GetMetadata(hash)
+	if err != nil {
+		return typeDecoder{}, fmt.Errorf("get metadata: %w", err)
+	}
+	dec, err := newTypeDecoder(meta)
+	if err != nil {
+		return typeDecoder{}, err
+	}
+	f.decoders[uint32(rv.SpecVersion)] = dec
+	return dec, nil
+}
+
+// eventRecord is the runtime events emitted in a phase of a block.
+type eventRecord struct {
+	Phase     string // Initialization, Finalization, or ApplyExtrinsic.
+	Extrinsic int    // Index of the extrinsic, in the ApplyExtrinsic phase.
+	Events    []blockdb.Event
+}
+
+// events returns the runtime events of the block, from the System.Events storage.
+func (f *blockFinder) events(dec typeDecoder, hash gstypes.Hash) ([]eventRecord, error) {
+	typ, ok := dec.StorageType("System", "Events")
+	if !ok {
+		return nil, fmt.Errorf("system events not found in metadata")
+	}
+	key := gstypes.NewStorageKey(gstypes.CreateStorageKeyPrefix("System", "Events"))
+	raw, err := f.api.RPC.State.GetStorageRaw(key, hash)
+	if err != nil {
+		return nil, fmt.Errorf("get system events: %w", err)
+	}
+	if raw == nil || len(*raw) == 0 {
+		return nil, nil
+	}
+	v, err := dec.Decode(scale.NewDecoder(bytes.NewReader(*raw)), typ)
+	if err != nil {
+		return nil, fmt.Errorf("decode system events: %w", err)
+	}
+	return eventRecords(v)
+}
+
+// eventRecords converts decoded System.Events storage into event records.
+func eventRecords(v any) ([]eventRecord, error) {
+	list, _ := v.([]any)
+	records := make([]eventRecord, 0, len(list))
+	for _, item := range list {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected event record %T", item)
+		}
+
+		var r eventRecord
+		switch phase := m["phase"].(type) {
+		case string:
+			r.Phase = phase
+		case map[string]any:
+			r.Phase = "ApplyExtrinsic"
+			idx, _ := phase[r.Phase].(int64)
+			r.Extrinsic = int(idx)
+		}
+
+		// Events are an enum of pallets, each with an enum of its events.
+		pallet, palletEvent := variant(m["event"])
+		event, fields := variant(palletEvent)
+		r.Events = substrateEvents(pallet, event, fields)
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// substrateEvents converts a runtime event into blockdb events, typed Pallet.Event.
+// The packet events of pallet-ibc are also converted into their ibc-go equivalent.
+func substrateEvents(pallet, event string, fields any) []blockdb.Event {
+	events := []blockdb.Event{{
+		Type:       pallet + "." + event,
+		Attributes: flattenAttrs("", fields),
+	}}
+	if pallet == "Ibc" {
+		events = append(events, ibcPacketEvents(fields)...)
+	}
+	return events
+}
+
+// ibcPacketEvents finds the packet events nested in the fields of a pallet-ibc event.
+func ibcPacketEvents(v any) []blockdb.Event {
+	var events []blockdb.Event
+	switch v := v.(type) {
+	case []any:
+		for _, elem := range v {
+			events = append(events, ibcPacketEvents(elem)...)
+		}
+	case map[string]any:
+		for k, elem := range v {
+			typ, ok := ibcPacketEventTypes[k]
+			fields, isMap := elem.(map[string]any)
+			if !ok || !isMap {
+				events = append(events, ibcPacketEvents(elem)...)
+				continue
+			}
+			var attrs []blockdb.EventAttribute
+			for _, attr := range flattenAttrs("", fields) {
+				if key, ok := ibcPacketAttrKeys[attr.Key]; ok {
+					attr.Key = key
+				}
+				attrs = append(attrs, attr)
+			}
+			events = append(events, blockdb.Event{Type: typ, Attributes: attrs})
+		}
+	}
+	return events
+}
+
+// flattenAttrs flattens decoded fields into attributes, joining the names of nested fields with dots.
+// Sequences are JSON encoded.
+func flattenAttrs(prefix string, v any) []blockdb.EventAttribute {
+	switch v := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var attrs []blockdb.EventAttribute
+		for _, k := range keys {
+			attrs = append(attrs, flattenAttrs(strings.TrimPrefix(prefix+"."+k, "."), v[k])...)
+		}
+		return attrs
+	case nil:
+		if prefix == "" {
+			return nil
+		}
+		return []blockdb.EventAttribute{{Key: prefix}}
+	}
+
+	if prefix == "" {
+		prefix = "value"
+	}
+	value := fmt.Sprint(v)
+	if list, ok := v.([]any); ok {
+		b, _ := json.Marshal(list)
+		value = string(b)
+	}
+	return []blockdb.EventAttribute{{Key: prefix, Value: value}}
+}
+
+// variant returns the name and fields of a decoded enum value.
+func variant(v any) (string, any) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case map[string]any:
+		for name, fields := range v {
+			return name, fields
+		}
+	}
+	return "", nil
+}
+
+// extrinsicTime returns the time set by the Timestamp.set inherent, in milliseconds.
+func extrinsicTime(args any) time.Time {
+	m, _ := args.(map[string]any)
+	switch now := m["now"].(type) {
+	case int64:
+		return time.UnixMilli(now).UTC()
+	case uint64:
+		return time.UnixMilli(int64(now)).UTC()
+	}
+	return time.Time{}
+}
@@ -0,0 +1,73 @@
+package polkadot
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventRecords(t *testing.T) {
+	sendPacket := map[string]any{
+		"revision_height": int64(12),
+		"revision_number": int64(0),
+		"port_id":         "transfer",
+		"channel_id":      "channel-0",
+		"dest_port":       "transfer",
+		"dest_channel":    "channel-1",
+		"sequence":        int64(3),
+	}
+	decoded := []any{
+		map[string]any{
+			"phase":  "Initialization",
+			"event":  map[string]any{"ParachainSystem": "ValidationFunctionApplied"},
+			"topics": []any{},
+		},
+		map[string]any{
+			"phase": map[string]any{"ApplyExtrinsic": int64(1)},
+			"event": map[string]any{"Ibc": map[string]any{"Events": map[string]any{
+				"events": []any{map[string]any{"Ok": map[string]any{"SendPacket": sendPacket}}},
+			}}},
+			"topics": []any{},
+		},
+		map[string]any{
+			"phase": map[string]any{"ApplyExtrinsic": int64(1)},
+			"event": map[string]any{"System": map[string]any{"ExtrinsicSuccess": map[string]any{
+				"dispatch_info": map[string]any{"weight": int64(100), "pays_fee": "Yes"},
+			}}},
+			"topics": []any{},
+		},
+	}
+
+	records, err := eventRecords(decoded)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	require.Equal(t, "Initialization", records[0].Phase)
+	require.Equal(t, []blockdb.Event{{Type: "ParachainSystem.ValidationFunctionApplied"}}, records[0].Events)
+
+	require.Equal(t, "ApplyExtrinsic", records[1].Phase)
+	require.Equal(t, 1, records[1].Extrinsic)
+	require.Len(t, records[1].Events, 2)
+	require.Equal(t, "Ibc.Events", records[1].Events[0].Type)
+	require.Equal(t, blockdb.Event{
+		Type: "send_packet",
+		Attributes: []blockdb.EventAttribute{
+			{Key: "packet_src_channel", Value: "channel-0"},
+			{Key: "packet_dst_channel", Value: "channel-1"},
+			{Key: "packet_dst_port", Value: "transfer"},
+			{Key: "packet_src_port", Value: "transfer"},
+			{Key: "revision_height", Value: "12"},
+			{Key: "revision_number", Value: "0"},
+			{Key: "packet_sequence", Value: "3"},
+		},
+	}, records[1].Events[1])
+
+	require.Equal(t, []blockdb.Event{{
+		Type: "System.ExtrinsicSuccess",
+		Attributes: []blockdb.EventAttribute{
+			{Key: "dispatch_info.pays_fee", Value: "Yes"},
+			{Key: "dispatch_info.weight", Value: "100"},
+		},
+	}}, records[2].Events)
+}
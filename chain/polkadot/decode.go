@@ -0,0 +1,365 @@
+package polkadot
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/misko9/go-substrate-rpc-client/v4/scale"
+	gstypes "github.com/misko9/go-substrate-rpc-client/v4/types"
+)
+
+// typeDecoder decodes SCALE encoded values from their type in the portable registry of V14 metadata,
+// so that the extrinsics and events of any pallet, such as pallet-ibc, are decoded without a Go type for each.
+//
+// Values are decoded into JSON friendly types: structs into maps keyed by field name, enums into
+// the variant name or a map of the variant name to its fields, sequences into slices,
+// byte sequences into strings if printable or else 0x prefixed hex, and integers wider than 64 bits into decimal strings.
+type typeDecoder struct {
+	meta *gstypes.MetadataV14
+}
+
+func newTypeDecoder(meta *gstypes.Metadata) (typeDecoder, error) {
+	if meta.Version != 14 {
+		return typeDecoder{}, fmt.Errorf("unsupported metadata version %d", meta.Version)
+	}
+	return typeDecoder{meta: &meta.AsMetadataV14}, nil
+}
+
+func (d typeDecoder) lookup(id gstypes.Si1LookupTypeID) (*gstypes.Si1Type, error) {
+	typ, ok := d.meta.EfficientLookup[id.Int64()]
+	if !ok {
+		return nil, fmt.Errorf("type %d not found in metadata", id.Int64())
+	}
+	return typ, nil
+}
+
+// Decode decodes the value of type id from dec.
+func (d typeDecoder) Decode(dec *scale.Decoder, id gstypes.Si1LookupTypeID) (any, error) {
+	typ, err := d.lookup(id)
+	if err != nil {
+		return nil, err
+	}
+	def := typ.Def
+	switch {
+	case def.IsComposite:
+		return d.decodeFields(dec, def.Composite.Fields)
+
+	case def.IsVariant:
+		b, err := dec.ReadOneByte()
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range def.Variant.Variants {
+			if byte(v.Index) != b {
+				continue
+			}
+			fields, err := d.decodeFields(dec, v.Fields)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", v.Name, err)
+			}
+			if isOption(typ) {
+				return fields, nil // None is nil, and Some is its value.
+			}
+			if len(v.Fields) == 0 {
+				return string(v.Name), nil
+			}
+			return map[string]any{string(v.Name): fields}, nil
+		}
+		return nil, fmt.Errorf("variant %d of type %d not found in metadata", b, id.Int64())
+
+	case def.IsSequence:
+		n, err := dec.DecodeUintCompact()
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeSequence(dec, def.Sequence.Type, n.Uint64())
+
+	case def.IsArray:
+		return d.decodeSequence(dec, def.Array.Type, uint64(def.Array.Len))
+
+	case def.IsTuple:
+		if len(def.Tuple) == 0 {
+			return nil, nil
+		}
+		vals := make([]any, len(def.Tuple))
+		for i, elem := range def.Tuple {
+			if vals[i], err = d.Decode(dec, elem); err != nil {
+				return nil, err
+			}
+		}
+		return vals, nil
+
+	case def.IsPrimitive:
+		return decodePrimitive(dec, def.Primitive.Si0TypeDefPrimitive)
+
+	case def.IsCompact:
+		n, err := dec.DecodeUintCompact()
+		if err != nil {
+			return nil, err
+		}
+		return bigValue(n), nil
+
+	case def.IsBitSequence:
+		return d.decodeBitSequence(dec, def.BitSequence)
+	}
+	return nil, fmt.Errorf("unsupported definition of type %d", id.Int64())
+}
+
+// decodeFields decodes the fields of a struct or enum variant.
+// Named fields are decoded into a map, a single unnamed field into its value, and several unnamed fields into a slice.
+func (d typeDecoder) decodeFields(dec *scale.Decoder, fields []gstypes.Si1Field) (any, error) {
+	switch {
+	case len(fields) == 0:
+		return nil, nil
+	case !fields[0].HasName && len(fields) == 1:
+		return d.Decode(dec, fields[0].Type)
+	case !fields[0].HasName:
+		vals := make([]any, len(fields))
+		for i, f := range fields {
+			v, err := d.Decode(dec, f.Type)
+			if err != nil {
+				return nil, err
+			}
+			vals[i] = v
+		}
+		return vals, nil
+	}
+	vals := make(map[string]any, len(fields))
+	for _, f := range fields {
+		v, err := d.Decode(dec, f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		vals[string(f.Name)] = v
+	}
+	return vals, nil
+}
+
+func (d typeDecoder) decodeSequence(dec *scale.Decoder, elem gstypes.Si1LookupTypeID, n uint64) (any, error) {
+	typ, err := d.lookup(elem)
+	if err != nil {
+		return nil, err
+	}
+	if typ.Def.IsPrimitive && typ.Def.Primitive.Si0TypeDefPrimitive == gstypes.IsU8 {
+		b := make([]byte, n)
+		if err := dec.Read(b); err != nil {
+			return nil, err
+		}
+		return bytesValue(b), nil
+	}
+	vals := make([]any, n)
+	for i := range vals {
+		if vals[i], err = d.Decode(dec, elem); err != nil {
+			return nil, err
+		}
+	}
+	return vals, nil
+}
+
+// decodeBitSequence decodes a bit sequence as hex, from the bytes of its store type.
+func (d typeDecoder) decodeBitSequence(dec *scale.Decoder, def gstypes.Si1TypeDefBitSequence) (any, error) {
+	bits, err := dec.DecodeUintCompact()
+	if err != nil {
+		return nil, err
+	}
+	store, err := d.lookup(def.BitStoreType)
+	if err != nil {
+		return nil, err
+	}
+	size := uint64(1)
+	if store.Def.IsPrimitive {
+		switch store.Def.Primitive.Si0TypeDefPrimitive {
+		case gstypes.IsU16:
+			size = 2
+		case gstypes.IsU32:
+			size = 4
+		case gstypes.IsU64:
+			size = 8
+		}
+	}
+	words := (bits.Uint64() + 8*size - 1) / (8 * size)
+	b := make([]byte, words*size)
+	if err := dec.Read(b); err != nil {
+		return nil, err
+	}
+	return "0x" + hex.EncodeToString(b), nil
+}
+
+func decodePrimitive(dec *scale.Decoder, p gstypes.Si0TypeDefPrimitive) (any, error) {
+	switch p {
+	case gstypes.IsBool:
+		b, err := dec.ReadOneByte()
+		return b == 1, err
+	case gstypes.IsStr:
+		n, err := dec.DecodeUintCompact()
+		if err != nil {
+			return nil, err
+		}
+		b := make([]byte, n.Uint64())
+		err = dec.Read(b)
+		return string(b), err
+	}
+
+	var size int
+	signed := false
+	switch p {
+	case gstypes.IsU8:
+		size = 1
+	case gstypes.IsU16:
+		size = 2
+	case gstypes.IsChar, gstypes.IsU32:
+		size = 4
+	case gstypes.IsU64:
+		size = 8
+	case gstypes.IsU128:
+		size = 16
+	case gstypes.IsU256:
+		size = 32
+	case gstypes.IsI8:
+		size, signed = 1, true
+	case gstypes.IsI16:
+		size, signed = 2, true
+	case gstypes.IsI32:
+		size, signed = 4, true
+	case gstypes.IsI64:
+		size, signed = 8, true
+	case gstypes.IsI128:
+		size, signed = 16, true
+	case gstypes.IsI256:
+		size, signed = 32, true
+	default:
+		return nil, fmt.Errorf("unsupported primitive %d", p)
+	}
+
+	b := make([]byte, size)
+	if err := dec.Read(b); err != nil {
+		return nil, err
+	}
+	// Integers are little endian.
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	n := new(big.Int).SetBytes(b)
+	if signed && b[0]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(8*size)))
+	}
+	if p == gstypes.IsChar {
+		return string(rune(n.Int64())), nil
+	}
+	return bigValue(n), nil
+}
+
+// bigValue returns n as an int64 or uint64 if it fits, or else as a decimal string.
+func bigValue(n *big.Int) any {
+	switch {
+	case n.IsInt64():
+		return n.Int64()
+	case n.IsUint64():
+		return n.Uint64()
+	}
+	return n.String()
+}
+
+// bytesValue returns b as a string if it is printable text, such as IBC port and channel IDs,
+// or else as 0x prefixed hex.
+func bytesValue(b []byte) string {
+	if len(b) > 0 && utf8.Valid(b) && bytes.IndexFunc(b, func(r rune) bool { return !unicode.IsPrint(r) }) < 0 {
+		return string(b)
+	}
+	return "0x" + hex.EncodeToString(b)
+}
+
+func isOption(typ *gstypes.Si1Type) bool {
+	return len(typ.Path) == 1 && typ.Path[0] == "Option"
+}
+
+// DecodeExtrinsic decodes an extrinsic, prefixed with its length as in blocks.
+func (d typeDecoder) DecodeExtrinsic(b []byte) (Extrinsic, error) {
+	var ext Extrinsic
+	dec := scale.NewDecoder(bytes.NewReader(b))
+	if _, err := dec.DecodeUintCompact(); err != nil {
+		return ext, fmt.Errorf("length: %w", err)
+	}
+	version, err := dec.ReadOneByte()
+	if err != nil {
+		return ext, fmt.Errorf("version: %w", err)
+	}
+
+	if version&0x80 != 0 {
+		// Signed extrinsics are followed by the signer, signature, and signed extensions.
+		typ, err := d.lookup(d.meta.Extrinsic.Type)
+		if err != nil {
+			return ext, err
+		}
+		params := make(map[string]gstypes.Si1LookupTypeID, len(typ.Params))
+		for _, p := range typ.Params {
+			if p.HasType {
+				params[string(p.Name)] = p.Type
+			}
+		}
+		addressType, ok := params["Address"]
+		if !ok {
+			return ext, fmt.Errorf("extrinsic address type not found in metadata")
+		}
+		signatureType, ok := params["Signature"]
+		if !ok {
+			return ext, fmt.Errorf("extrinsic signature type not found in metadata")
+		}
+
+		signer, err := d.Decode(dec, addressType)
+		if err != nil {
+			return ext, fmt.Errorf("signer: %w", err)
+		}
+		// Unwrap the account of a MultiAddress.
+		if m, ok := signer.(map[string]any); ok && len(m) == 1 {
+			_, signer = variant(m)
+		}
+		ext.Signer = signer
+
+		if _, err := d.Decode(dec, signatureType); err != nil {
+			return ext, fmt.Errorf("signature: %w", err)
+		}
+		for _, se := range d.meta.Extrinsic.SignedExtensions {
+			if _, err := d.Decode(dec, se.Type); err != nil {
+				return ext, fmt.Errorf("signed extension %s: %w", se.Identifier, err)
+			}
+		}
+	}
+
+	palletIndex, err := dec.ReadOneByte()
+	if err != nil {
+		return ext, fmt.Errorf("pallet index: %w", err)
+	}
+	for _, pallet := range d.meta.Pallets {
+		if !pallet.HasCalls || byte(pallet.Index) != palletIndex {
+			continue
+		}
+		ext.Pallet = string(pallet.Name)
+		call, err := d.Decode(dec, pallet.Calls.Type)
+		if err != nil {
+			return ext, fmt.Errorf("call of %s: %w", pallet.Name, err)
+		}
+		ext.Call, ext.Args = variant(call)
+		return ext, nil
+	}
+	return ext, fmt.Errorf("pallet %d not found in metadata", palletIndex)
+}
+
+// StorageType returns the type of a plain storage item.
+func (d typeDecoder) StorageType(prefix, name string) (gstypes.Si1LookupTypeID, bool) {
+	for _, pallet := range d.meta.Pallets {
+		if !pallet.HasStorage || string(pallet.Storage.Prefix) != prefix {
+			continue
+		}
+		for _, item := range pallet.Storage.Items {
+			if string(item.Name) == name && item.Type.IsPlainType {
+				return item.Type.AsPlainType, true
+			}
+		}
+	}
+	return gstypes.Si1LookupTypeID{}, false
+}
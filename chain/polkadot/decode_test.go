@@ -0,0 +1,117 @@
+package polkadot
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/misko9/go-substrate-rpc-client/v4/scale"
+	gstypes "github.com/misko9/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+)
+
+func typeID(id uint64) gstypes.Si1LookupTypeID { return gstypes.NewSi1LookupTypeIDFromUInt(id) }
+
+func primitiveType(p gstypes.Si0TypeDefPrimitive) *gstypes.Si1Type {
+	return &gstypes.Si1Type{Def: gstypes.Si1TypeDef{IsPrimitive: true, Primitive: gstypes.Si1TypeDefPrimitive{Si0TypeDefPrimitive: p}}}
+}
+
+func namedField(name string, id uint64) gstypes.Si1Field {
+	return gstypes.Si1Field{HasName: true, Name: gstypes.Text(name), Type: typeID(id)}
+}
+
+// testDecoder returns a decoder for a registry with a Transfer struct, an enum of pallet calls,
+// and the types they are made of.
+func testDecoder() typeDecoder {
+	lookup := map[int64]*gstypes.Si1Type{
+		0: primitiveType(gstypes.IsU8),
+		1: primitiveType(gstypes.IsU32),
+		2: primitiveType(gstypes.IsU128),
+		3: {Def: gstypes.Si1TypeDef{IsSequence: true, Sequence: gstypes.Si1TypeDefSequence{Type: typeID(0)}}},
+		4: {Def: gstypes.Si1TypeDef{IsCompact: true, Compact: gstypes.Si1TypeDefCompact{Type: typeID(1)}}},
+		5: {
+			Path: gstypes.Si1Path{"Option"},
+			Def: gstypes.Si1TypeDef{IsVariant: true, Variant: gstypes.Si1TypeDefVariant{Variants: []gstypes.Si1Variant{
+				{Name: "None", Index: 0},
+				{Name: "Some", Index: 1, Fields: []gstypes.Si1Field{{Type: typeID(1)}}},
+			}}},
+		},
+		// struct Transfer { port_id: Vec<u8>, amount: u128, memo: Option<u32>, nonce: Compact<u32> }
+		6: {Def: gstypes.Si1TypeDef{IsComposite: true, Composite: gstypes.Si1TypeDefComposite{Fields: []gstypes.Si1Field{
+			namedField("port_id", 3),
+			namedField("amount", 2),
+			namedField("memo", 5),
+			namedField("nonce", 4),
+		}}}},
+		// enum Call { remark, transfer(Transfer) }
+		7: {Def: gstypes.Si1TypeDef{IsVariant: true, Variant: gstypes.Si1TypeDefVariant{Variants: []gstypes.Si1Variant{
+			{Name: "remark", Index: 0},
+			{Name: "transfer", Index: 1, Fields: []gstypes.Si1Field{namedField("transfer", 6)}},
+		}}}},
+	}
+	meta := &gstypes.MetadataV14{
+		EfficientLookup: lookup,
+		Pallets: []gstypes.PalletMetadataV14{
+			{Name: "Ibc", Index: 9, HasCalls: true, Calls: gstypes.FunctionMetadataV14{Type: typeID(7)}},
+		},
+	}
+	return typeDecoder{meta: meta}
+}
+
+func TestTypeDecoder_Decode(t *testing.T) {
+	d := testDecoder()
+
+	transfer := []byte{
+		3 << 2, 'a', 'b', 'c', // port_id: compact length 3
+		0x10, 0x27, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, // amount: 10000, as 16 little endian bytes
+		1, 7, 0, 0, 0, // memo: Some(7)
+		5 << 2, // nonce: compact 5
+	}
+	v, err := d.Decode(scale.NewDecoder(bytes.NewReader(transfer)), typeID(6))
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"port_id": "abc",
+		"amount":  int64(10000),
+		"memo":    int64(7),
+		"nonce":   int64(5),
+	}, v)
+
+	v, err = d.Decode(scale.NewDecoder(bytes.NewReader([]byte{0})), typeID(5))
+	require.NoError(t, err)
+	require.Nil(t, v)
+
+	// Non printable bytes are hex encoded.
+	v, err = d.Decode(scale.NewDecoder(bytes.NewReader([]byte{2 << 2, 0, 0xff})), typeID(3))
+	require.NoError(t, err)
+	require.Equal(t, "0x00ff", v)
+
+	// The largest u128 does not fit in 64 bits.
+	maxU128 := bytes.Repeat([]byte{0xff}, 16)
+	v, err = d.Decode(scale.NewDecoder(bytes.NewReader(maxU128)), typeID(2))
+	require.NoError(t, err)
+	require.Equal(t, "340282366920938463463374607431768211455", v)
+
+	_, err = d.Decode(scale.NewDecoder(bytes.NewReader([]byte{9})), typeID(7))
+	require.ErrorContains(t, err, "variant 9")
+}
+
+func TestTypeDecoder_DecodeExtrinsic(t *testing.T) {
+	d := testDecoder()
+
+	// Unsigned extrinsic calling Ibc.remark, prefixed with its compact length.
+	ext, err := d.DecodeExtrinsic([]byte{3 << 2, 4, 9, 0})
+	require.NoError(t, err)
+	require.Equal(t, Extrinsic{Pallet: "Ibc", Call: "remark"}, ext)
+
+	ext, err = d.DecodeExtrinsic([]byte{11 << 2, 4, 9, 1, 1 << 2, 'x', 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+	require.NoError(t, err)
+	require.Equal(t, "transfer", ext.Call)
+	require.Equal(t, map[string]any{"transfer": map[string]any{
+		"port_id": "x",
+		"amount":  int64(1),
+		"memo":    nil,
+		"nonce":   int64(0),
+	}}, ext.Args)
+
+	_, err = d.DecodeExtrinsic([]byte{3 << 2, 4, 8, 0})
+	require.ErrorContains(t, err, "pallet 8 not found")
+}
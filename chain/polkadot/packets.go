@@ -0,0 +1,239 @@
+package polkadot
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	chanTypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"github.com/misko9/go-substrate-rpc-client/v4/types/codec"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+// packetID identifies a packet by its source port, channel and sequence.
+type packetID struct {
+	port, channel string
+	sequence      uint64
+}
+
+// packetAcknowledgements returns the packets acknowledged in the block, from the pallet-ibc AcknowledgePacket events.
+// The events do not carry the packet data or the acknowledgement, which are taken from the delivered
+// MsgAcknowledgement when it is found in the extrinsics of the block.
+func packetAcknowledgements(block blockdb.Block) ([]ibc.PacketAcknowledgement, error) {
+	packets, err := packetEvents(block, "acknowledge_packet")
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := ibcMessages(block)
+	if err != nil {
+		return nil, err
+	}
+	delivered := make(map[packetID]chanTypes.MsgAcknowledgement)
+	for _, m := range msgs {
+		if m.TypeURL != "/ibc.core.channel.v1.MsgAcknowledgement" {
+			continue
+		}
+		var msg chanTypes.MsgAcknowledgement
+		if err := msg.Unmarshal(m.Value); err != nil {
+			return nil, fmt.Errorf("unmarshal %s: %w", m.TypeURL, err)
+		}
+		delivered[packetID{msg.Packet.SourcePort, msg.Packet.SourceChannel, msg.Packet.Sequence}] = msg
+	}
+
+	acks := make([]ibc.PacketAcknowledgement, len(packets))
+	for i, p := range packets {
+		acks[i].Packet = p
+		if msg, ok := delivered[packetID{p.SourcePort, p.SourceChannel, p.Sequence}]; ok {
+			acks[i] = ibc.PacketAcknowledgement{
+				Packet:          ibc.PacketFromChannel(msg.Packet),
+				Acknowledgement: msg.Acknowledgement,
+			}
+		}
+	}
+	return acks, nil
+}
+
+// packetTimeouts returns the packets timed out in the block, including those timed out by closing their channel,
+// from the pallet-ibc TimeoutPacket events.
+// The packet data is taken from the delivered MsgTimeout or MsgTimeoutOnClose when it is found in the extrinsics of the block.
+func packetTimeouts(block blockdb.Block) ([]ibc.PacketTimeout, error) {
+	packets, err := packetEvents(block, "timeout_packet")
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := ibcMessages(block)
+	if err != nil {
+		return nil, err
+	}
+	delivered := make(map[packetID]chanTypes.Packet)
+	for _, m := range msgs {
+		var packet chanTypes.Packet
+		switch m.TypeURL {
+		case "/ibc.core.channel.v1.MsgTimeout":
+			var msg chanTypes.MsgTimeout
+			if err := msg.Unmarshal(m.Value); err != nil {
+				return nil, fmt.Errorf("unmarshal %s: %w", m.TypeURL, err)
+			}
+			packet = msg.Packet
+		case "/ibc.core.channel.v1.MsgTimeoutOnClose":
+			var msg chanTypes.MsgTimeoutOnClose
+			if err := msg.Unmarshal(m.Value); err != nil {
+				return nil, fmt.Errorf("unmarshal %s: %w", m.TypeURL, err)
+			}
+			packet = msg.Packet
+		default:
+			continue
+		}
+		delivered[packetID{packet.SourcePort, packet.SourceChannel, packet.Sequence}] = packet
+	}
+
+	timeouts := make([]ibc.PacketTimeout, len(packets))
+	for i, p := range packets {
+		timeouts[i].Packet = p
+		if packet, ok := delivered[packetID{p.SourcePort, p.SourceChannel, p.Sequence}]; ok {
+			timeouts[i].Packet = ibc.PacketFromChannel(packet)
+		}
+	}
+	return timeouts, nil
+}
+
+// packetEvents returns the packets of the ibc-go equivalent events of type typ, converted from pallet-ibc events
+// by the blockFinder, in the order of the block. Whether the packets were delivered by an Ibc.deliver extrinsic
+// or by a call nested in another, such as Utility.batch or Sudo.sudo, the pallet emits the same events.
+func packetEvents(block blockdb.Block, typ string) ([]ibc.Packet, error) {
+	events := append([]blockdb.Event(nil), block.BeginBlockEvents...)
+	for _, tx := range block.Txs {
+		events = append(events, tx.Events...)
+	}
+	events = append(events, block.EndBlockEvents...)
+
+	var packets []ibc.Packet
+	for _, e := range events {
+		if e.Type != typ {
+			continue
+		}
+		var p ibc.Packet
+		for _, attr := range e.Attributes {
+			switch attr.Key {
+			case "packet_sequence":
+				seq, err := strconv.ParseUint(attr.Value, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("parse %s sequence at height %d: %w", typ, block.Height, err)
+				}
+				p.Sequence = seq
+			case "packet_src_port":
+				p.SourcePort = attr.Value
+			case "packet_src_channel":
+				p.SourceChannel = attr.Value
+			case "packet_dst_port":
+				p.DestPort = attr.Value
+			case "packet_dst_channel":
+				p.DestChannel = attr.Value
+			}
+		}
+		packets = append(packets, p)
+	}
+	return packets, nil
+}
+
+// ibcMessage is an ibc-go message delivered to pallet-ibc, as a protobuf Any.
+type ibcMessage struct {
+	TypeURL string
+	Value   []byte
+}
+
+// ibcMessages returns the ibc-go messages delivered to pallet-ibc by the successful extrinsics of a block,
+// whether by an Ibc.deliver extrinsic or by an Ibc.deliver call nested in another call.
+// Relayers submit the same protobuf messages to pallet-ibc as to ibc-go, so packets are decoded
+// from them just like the messages of Cosmos transactions.
+func ibcMessages(block blockdb.Block) ([]ibcMessage, error) {
+	var msgs []ibcMessage
+	for _, tx := range block.Txs {
+		if extrinsicFailed(tx.Events) {
+			continue
+		}
+		var ext Extrinsic
+		// Extrinsics that failed to decode are saved as raw hex, and are not deliveries.
+		if err := json.Unmarshal(tx.Data, &ext); err != nil {
+			continue
+		}
+		var delivered []any
+		if ext.Pallet == "Ibc" && ext.Call == "deliver" {
+			delivered = append(delivered, ext.Args)
+		} else {
+			delivered = nestedDeliveries(ext.Args)
+		}
+		for _, args := range delivered {
+			m, err := deliveredMessages(args, block.Height)
+			if err != nil {
+				return nil, err
+			}
+			msgs = append(msgs, m...)
+		}
+	}
+	return msgs, nil
+}
+
+// nestedDeliveries returns the arguments of the Ibc.deliver calls nested in the arguments of a call.
+// Nested calls are decoded as {"Ibc": {"deliver": args}}.
+func nestedDeliveries(v any) []any {
+	var delivered []any
+	switch v := v.(type) {
+	case []any:
+		for _, elem := range v {
+			delivered = append(delivered, nestedDeliveries(elem)...)
+		}
+	case map[string]any:
+		if call, ok := v["Ibc"].(map[string]any); ok {
+			if args, ok := call["deliver"]; ok {
+				return append(delivered, args)
+			}
+		}
+		for _, elem := range v {
+			delivered = append(delivered, nestedDeliveries(elem)...)
+		}
+	}
+	return delivered
+}
+
+// deliveredMessages decodes the messages argument of an Ibc.deliver call.
+func deliveredMessages(args any, height uint64) ([]ibcMessage, error) {
+	b, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	var deliver struct {
+		Messages []struct {
+			TypeURL string `json:"type_url"`
+			Value   string `json:"value"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(b, &deliver); err != nil {
+		return nil, nil
+	}
+	msgs := make([]ibcMessage, 0, len(deliver.Messages))
+	for _, m := range deliver.Messages {
+		// Byte sequences are decoded as text if printable, though protobuf messages rarely are.
+		value := []byte(m.Value)
+		if strings.HasPrefix(m.Value, "0x") {
+			b, err := codec.HexDecodeString(m.Value)
+			if err != nil {
+				return nil, fmt.Errorf("decode %s at height %d: %w", m.TypeURL, height, err)
+			}
+			value = b
+		}
+		msgs = append(msgs, ibcMessage{TypeURL: m.TypeURL, Value: value})
+	}
+	return msgs, nil
+}
+
+func extrinsicFailed(events []blockdb.Event) bool {
+	for _, e := range events {
+		if e.Type == "System.ExtrinsicFailed" {
+			return true
+		}
+	}
+	return false
+}
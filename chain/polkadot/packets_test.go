@@ -0,0 +1,94 @@
+package polkadot
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	chanTypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacketAcknowledgementsAndTimeouts(t *testing.T) {
+	packet := chanTypes.Packet{
+		Sequence:           7,
+		SourcePort:         "transfer",
+		SourceChannel:      "channel-0",
+		DestinationPort:    "transfer",
+		DestinationChannel: "channel-1",
+		Data:               []byte(`{"amount":"1"}`),
+		TimeoutHeight:      clienttypes.NewHeight(1, 100),
+		TimeoutTimestamp:   1000,
+	}
+	ack, err := (&chanTypes.MsgAcknowledgement{Packet: packet, Acknowledgement: []byte(`{"result":"AQ=="}`)}).Marshal()
+	require.NoError(t, err)
+	timeoutPacket := packet
+	timeoutPacket.Sequence = 8
+	timeout, err := (&chanTypes.MsgTimeout{Packet: timeoutPacket}).Marshal()
+	require.NoError(t, err)
+
+	messages := func(typeURL string, value []byte) map[string]any {
+		return map[string]any{
+			"messages": []any{map[string]any{"type_url": typeURL, "value": "0x" + hex.EncodeToString(value)}},
+		}
+	}
+	extrinsic := func(ext Extrinsic, events ...blockdb.Event) blockdb.Tx {
+		data, err := json.Marshal(ext)
+		require.NoError(t, err)
+		return blockdb.Tx{Data: data, Events: events}
+	}
+	packetEvent := func(typ string, seq string) blockdb.Event {
+		return blockdb.Event{Type: typ, Attributes: []blockdb.EventAttribute{
+			{Key: "packet_src_channel", Value: "channel-0"},
+			{Key: "packet_dst_channel", Value: "channel-1"},
+			{Key: "packet_dst_port", Value: "transfer"},
+			{Key: "packet_src_port", Value: "transfer"},
+			{Key: "packet_sequence", Value: seq},
+		}}
+	}
+	block := blockdb.Block{Height: 10, Txs: []blockdb.Tx{
+		{Data: []byte(`{"data":"0x1234"}`)},
+		extrinsic(Extrinsic{Pallet: "Ibc", Call: "deliver", Args: messages("/ibc.core.channel.v1.MsgAcknowledgement", ack)},
+			blockdb.Event{Type: "Ibc.Events"}, packetEvent("acknowledge_packet", "7")),
+		// A delivery nested in a batch.
+		extrinsic(Extrinsic{Pallet: "Utility", Call: "batch", Args: map[string]any{"calls": []any{
+			map[string]any{"Ibc": map[string]any{"deliver": messages("/ibc.core.channel.v1.MsgTimeout", timeout)}},
+		}}}, blockdb.Event{Type: "Ibc.Events"}, packetEvent("timeout_packet", "8")),
+		// A failed redundant delivery emits no packet events.
+		extrinsic(Extrinsic{Pallet: "Ibc", Call: "deliver", Args: messages("/ibc.core.channel.v1.MsgTimeout", timeout)},
+			blockdb.Event{Type: "System.ExtrinsicFailed"}),
+		// An extrinsic that failed to decode still has its events.
+		{Data: []byte(`{"data":"0x5678"}`), Events: []blockdb.Event{packetEvent("acknowledge_packet", "9")}},
+	}}
+
+	msgs, err := ibcMessages(block)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+
+	want := ibc.Packet{
+		Sequence:         7,
+		SourcePort:       "transfer",
+		SourceChannel:    "channel-0",
+		DestPort:         "transfer",
+		DestChannel:      "channel-1",
+		Data:             []byte(`{"amount":"1"}`),
+		TimeoutHeight:    "1-100",
+		TimeoutTimestamp: 1000,
+	}
+	wantTimeout := want
+	wantTimeout.Sequence = 8
+
+	acks, err := packetAcknowledgements(block)
+	require.NoError(t, err)
+	require.Equal(t, []ibc.PacketAcknowledgement{
+		{Packet: want, Acknowledgement: []byte(`{"result":"AQ=="}`)},
+		{Packet: ibc.Packet{Sequence: 9, SourcePort: "transfer", SourceChannel: "channel-0", DestPort: "transfer", DestChannel: "channel-1"}},
+	}, acks)
+
+	timeouts, err := packetTimeouts(block)
+	require.NoError(t, err)
+	require.Equal(t, []ibc.PacketTimeout{{Packet: wantTimeout}}, timeouts)
+}
@@ -40,10 +40,13 @@ type ParachainNode struct {
 	Bin             string
 	NodeKey         p2pcrypto.PrivKey
 	ChainID         string
+	ParaID          int
 	Flags           []string
 	RelayChainFlags []string
+	ModifyGenesis   func(ibc.ChainConfig, []byte) ([]byte, error)
 
 	api         *gsrpc.SubstrateAPI
+	blocks      *blockFinder
 	hostWsPort  string
 	hostRpcPort string
 }
@@ -158,11 +161,26 @@ func (pn *ParachainNode) GenerateParachainGenesisFile(ctx context.Context, addit
 	if err := dyno.Set(chainSpec, balances, "genesis", "runtime", "balances", "balances"); err != nil {
 		return nil, fmt.Errorf("error setting parachain balances: %w", err)
 	}
+	if pn.ParaID != 0 {
+		if err := dyno.Set(chainSpec, pn.ParaID, "para_id"); err != nil {
+			return nil, fmt.Errorf("error setting parachain ID: %w", err)
+		}
+		if err := dyno.Set(chainSpec, pn.ParaID, "genesis", "runtime", "parachainInfo", "parachainId"); err != nil {
+			return nil, fmt.Errorf("error setting parachain info ID: %w", err)
+		}
+	}
 	editedChainSpec, err := json.MarshalIndent(chainSpec, "", "  ")
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling modified parachain chain spec: %w", err)
 	}
 
+	if pn.ModifyGenesis != nil {
+		editedChainSpec, err = pn.ModifyGenesis(pn.Chain.Config(), editedChainSpec)
+		if err != nil {
+			return nil, fmt.Errorf("error modifying parachain chain spec: %w", err)
+		}
+	}
+
 	return editedChainSpec, nil
 }
 
@@ -264,6 +282,12 @@ func (pn *ParachainNode) StopContainer(ctx context.Context) error {
 	return pn.containerLifecycle.StopContainer(ctx)
 }
 
+// ContainerID returns the ID of the node's docker container.
+// It is empty until the container is created.
+func (pn *ParachainNode) ContainerID() string {
+	return pn.containerLifecycle.ContainerID()
+}
+
 // StartContainer starts the container after it is built by CreateNodeContainer.
 func (pn *ParachainNode) StartContainer(ctx context.Context) error {
 	if err := pn.containerLifecycle.StartContainer(ctx); err != nil {
@@ -291,6 +315,7 @@ func (pn *ParachainNode) StartContainer(ctx context.Context) error {
 	}
 
 	pn.api = api
+	pn.blocks = newBlockFinder(pn.logger(), api)
 	return nil
 }
 
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/99designs/keyring"
@@ -24,6 +25,7 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
@@ -63,14 +65,7 @@ type PolkadotParachainSpec struct {
 }
 
 // ParachainConfig is a shared type that allows callers of this module to configure a parachain.
-type ParachainConfig struct {
-	ChainID         string
-	Bin             string
-	Image           ibc.DockerImage
-	NumNodes        int
-	Flags           []string
-	RelayChainFlags []string
-}
+type ParachainConfig = ibc.ParachainConfig
 
 // IndexedName is a slice of the substrate dev key names used for key derivation.
 var IndexedName = []string{"alice", "bob", "charlie", "dave", "ferdie"}
@@ -94,6 +89,20 @@ func (c *PolkadotChain) Config() ibc.ChainConfig {
 	return c.cfg
 }
 
+// ContainerIDs returns the docker container IDs of all relay chain and parachain nodes.
+func (c *PolkadotChain) ContainerIDs() []string {
+	var ids []string
+	for _, n := range c.RelayChainNodes {
+		ids = append(ids, n.ContainerID())
+	}
+	for _, nodes := range c.ParachainNodes {
+		for _, n := range nodes {
+			ids = append(ids, n.ContainerID())
+		}
+	}
+	return ids
+}
+
 func (c *PolkadotChain) NewRelayChainNode(
 	ctx context.Context,
 	i int,
@@ -204,8 +213,10 @@ func (c *PolkadotChain) NewParachainNode(
 		Image:           parachainConfig.Image,
 		Bin:             parachainConfig.Bin,
 		ChainID:         parachainConfig.ChainID,
+		ParaID:          parachainConfig.ParaID,
 		Flags:           parachainConfig.Flags,
 		RelayChainFlags: parachainConfig.RelayChainFlags,
+		ModifyGenesis:   parachainConfig.ModifyGenesis,
 	}
 
 	pn.containerLifecycle = dockerutil.NewContainerLifecycle(c.log, dockerClient, pn.Name())
@@ -376,9 +387,12 @@ func (c *PolkadotChain) modifyRelayChainGenesis(ctx context.Context, chainSpec i
 
 	for _, parachainNodes := range c.ParachainNodes {
 		firstParachainNode := parachainNodes[0]
-		parachainID, err := firstParachainNode.ParachainID(ctx)
-		if err != nil {
-			return fmt.Errorf("error getting parachain ID: %w", err)
+		parachainID := firstParachainNode.ParaID
+		if parachainID == 0 {
+			var err error
+			if parachainID, err = firstParachainNode.ParachainID(ctx); err != nil {
+				return fmt.Errorf("error getting parachain ID: %w", err)
+			}
 		}
 		genesisState, err := firstParachainNode.ExportGenesisState(ctx)
 		if err != nil {
@@ -389,12 +403,12 @@ func (c *PolkadotChain) modifyRelayChainGenesis(ctx context.Context, chainSpec i
 			return fmt.Errorf("error exporting genesis wasm: %w", err)
 		}
 
-		composableParachain := []interface{}{parachainID, PolkadotParachainSpec{
+		parachain := []interface{}{parachainID, PolkadotParachainSpec{
 			GenesisHead:    genesisState,
 			ValidationCode: genesisWasm,
 			Parachain:      true,
 		}}
-		parachains = append(parachains, composableParachain)
+		parachains = append(parachains, parachain)
 	}
 
 	if err := dyno.Set(chainSpec, parachains, runtimeGenesisPath("paras", "paras")...); err != nil {
@@ -600,8 +614,27 @@ func (c *PolkadotChain) Height(ctx context.Context) (uint64, error) {
 
 // ExportState exports the chain state at specific height.
 // Implements Chain interface.
+// The state is the storage of the parachain, or of the relay chain if there is no parachain,
+// as a JSON object of hex encoded storage keys to values.
 func (c *PolkadotChain) ExportState(ctx context.Context, height int64) (string, error) {
-	panic("[ExportState] not implemented yet")
+	api := c.blockFinder().api
+	hash, err := api.RPC.Chain.GetBlockHash(uint64(height))
+	if err != nil {
+		return "", fmt.Errorf("get block hash: %w", err)
+	}
+	var pairs [][2]string
+	if err := api.Client.Call(&pairs, "state_getPairs", "0x", hash.Hex()); err != nil {
+		return "", fmt.Errorf("get storage at height %d: %w", height, err)
+	}
+	state := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		state[p[0]] = p[1]
+	}
+	b, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
 }
 
 // HomeDir is the home directory of a node running in a docker container. Therefore, this maps to
@@ -804,21 +837,33 @@ type AccountInfo struct {
 // GetGasFeesInNativeDenom gets the fees in native denom for an amount of spent gas.
 // Implements Chain interface.
 func (c *PolkadotChain) GetGasFeesInNativeDenom(gasPaid int64) int64 {
-	panic("[GetGasFeesInNativeDenom] not implemented yet")
+	gasPrice, _ := strconv.ParseFloat(strings.Replace(c.cfg.GasPrices, c.cfg.Denom, "", 1), 64)
+	fees := float64(gasPaid) * gasPrice
+	return int64(fees)
 }
 
 // Acknowledgements returns all acknowledgements in a block at height.
 // Implements Chain interface.
 func (c *PolkadotChain) Acknowledgements(ctx context.Context, height uint64) ([]ibc.PacketAcknowledgement, error) {
-	panic("[Acknowledgements] not implemented yet")
+	block, err := c.blockFinder().FindBlock(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("find acknowledgements at height %d: %w", height, err)
+	}
+	return packetAcknowledgements(block)
 }
 
 // Timeouts returns all timeouts in a block at height.
 // Implements Chain interface.
 func (c *PolkadotChain) Timeouts(ctx context.Context, height uint64) ([]ibc.PacketTimeout, error) {
-	panic("[Timeouts] not implemented yet")
+	block, err := c.blockFinder().FindBlock(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("find timeouts at height %d: %w", height, err)
+	}
+	return packetTimeouts(block)
 }
 
+var _ testutil.PacketSourceChain = (*PolkadotChain)(nil)
+
 // GetKeyringPair returns the keyring pair from the keyring using keyName
 func (c *PolkadotChain) GetKeyringPair(keyName string) (signature.KeyringPair, error) {
 	kp := signature.KeyringPair{}
@@ -835,9 +880,38 @@ func (c *PolkadotChain) GetKeyringPair(keyName string) (signature.KeyringPair, e
 	return kp, nil
 }
 
-// FindTxs implements blockdb.BlockSaver (Not implemented yet for polkadot, but we don't want to exit)
+// FindTxs implements blockdb.TxFinder.
+// The transactions are the extrinsics of the first parachain if any, or else of the relay chain, as for Height.
 func (c *PolkadotChain) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx, error) {
-	return []blockdb.Tx{}, nil
+	return c.blockFinder().FindTxs(ctx, height)
+}
+
+// FindBlock implements blockdb.BlockFinder, for the same chain as FindTxs.
+func (c *PolkadotChain) FindBlock(ctx context.Context, height uint64) (blockdb.Block, error) {
+	return c.blockFinder().FindBlock(ctx, height)
+}
+
+// Subchains implements blockdb.SubchainFinder.
+// If the chain has parachains, the blocks of the relay chain and of the parachains other than the first
+// are saved separately, named relay and after the parachain's chain ID.
+func (c *PolkadotChain) Subchains() map[string]blockdb.TxFinder {
+	if len(c.ParachainNodes) == 0 || len(c.ParachainNodes[0]) == 0 {
+		return nil
+	}
+	subchains := map[string]blockdb.TxFinder{"relay": c.RelayChainNodes[0].blocks}
+	for _, nodes := range c.ParachainNodes[1:] {
+		if len(nodes) > 0 {
+			subchains[nodes[0].ChainID] = nodes[0].blocks
+		}
+	}
+	return subchains
+}
+
+func (c *PolkadotChain) blockFinder() *blockFinder {
+	if len(c.ParachainNodes) > 0 && len(c.ParachainNodes[0]) > 0 {
+		return c.ParachainNodes[0][0].blocks
+	}
+	return c.RelayChainNodes[0].blocks
 }
 
 // GetIbcBalance returns the Coins type of ibc coins in account
@@ -42,6 +42,7 @@ type RelayChainNode struct {
 	EcdsaPrivateKey   secp256k1.PrivateKey
 
 	api         *gsrpc.SubstrateAPI
+	blocks      *blockFinder
 	hostWsPort  string
 	hostRpcPort string
 }
@@ -231,6 +232,12 @@ func (p *RelayChainNode) StopContainer(ctx context.Context) error {
 	return p.containerLifecycle.StopContainer(ctx)
 }
 
+// ContainerID returns the ID of the node's docker container.
+// It is empty until the container is created.
+func (p *RelayChainNode) ContainerID() string {
+	return p.containerLifecycle.ContainerID()
+}
+
 // StartContainer starts the container after it is built by CreateNodeContainer.
 func (p *RelayChainNode) StartContainer(ctx context.Context) error {
 	if err := p.containerLifecycle.StartContainer(ctx); err != nil {
@@ -260,6 +267,7 @@ func (p *RelayChainNode) StartContainer(ctx context.Context) error {
 
 	p.logger().Info("Done", zap.String("container", p.Name()))
 	p.api = api
+	p.blocks = newBlockFinder(p.logger(), api)
 
 	return nil
 }
@@ -7,8 +7,6 @@ import (
 	"strings"
 	"sync"
 
-	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
-	"github.com/strangelove-ventures/interchaintest/v7/chain/penumbra"
 	"github.com/strangelove-ventures/interchaintest/v7/chain/polkadot"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"go.uber.org/zap"
@@ -128,34 +126,11 @@ func buildChain(log *zap.Logger, testName string, cfg ibc.ChainConfig, numValida
 		nf = *numFullNodes
 	}
 
-	switch cfg.Type {
-	case "cosmos":
-		return cosmos.NewCosmosChain(testName, cfg, nv, nf, log), nil
-	case "penumbra":
-		return penumbra.NewPenumbraChain(log, testName, cfg, nv, nf), nil
-	case "polkadot":
-		// TODO Clean this up. RelayChain config should only reference cfg.Images[0] and parachains should iterate through the remaining
-		// Maybe just pass everything in like NewCosmosChain and NewPenumbraChain, let NewPolkadotChain figure it out
-		// Or parachains and ICS consumer chains maybe should be their own chain
-		switch {
-		case strings.Contains(cfg.Name, "composable"):
-			parachains := []polkadot.ParachainConfig{{
-				//Bin:             "composable",
-				Bin:     "parachain-node",
-				ChainID: "dev-2000",
-				//ChainID:         "dali-dev",
-				Image:           cfg.Images[1],
-				NumNodes:        nf,
-				Flags:           []string{"--execution=wasm", "--wasmtime-instantiation-strategy=recreate-instance-copy-on-write"},
-				RelayChainFlags: []string{"--execution=wasm"},
-			}}
-			return polkadot.NewPolkadotChain(log, testName, cfg, nv, parachains), nil
-		default:
-			return nil, fmt.Errorf("unexpected error, unknown polkadot parachain: %s", cfg.Name)
-		}
-	default:
-		return nil, fmt.Errorf("unexpected error, unknown chain type: %s for chain: %s", cfg.Type, cfg.Name)
+	t, err := lookupChainType(cfg)
+	if err != nil {
+		return nil, err
 	}
+	return t.newChain(log, testName, cfg, nv, nf)
 }
 
 func (f *BuiltinChainFactory) Name() string {
@@ -174,3 +149,28 @@ func (f *BuiltinChainFactory) Name() string {
 	}
 	return strings.Join(parts, "+")
 }
+
+// polkadotParachains returns the parachains of a polkadot chain.
+// Parachains without a node count run as many nodes as the chain has full nodes.
+// Composable chains without configured parachains default to the composable parachain in cfg.Images[1].
+func polkadotParachains(cfg ibc.ChainConfig, numFullNodes int) ([]polkadot.ParachainConfig, error) {
+	parachains := append([]polkadot.ParachainConfig(nil), cfg.Parachains...)
+	if len(parachains) == 0 {
+		if !strings.Contains(cfg.Name, "composable") || len(cfg.Images) < 2 {
+			return nil, fmt.Errorf("no parachains configured for polkadot chain: %s", cfg.Name)
+		}
+		parachains = []polkadot.ParachainConfig{{
+			Bin:             "parachain-node",
+			ChainID:         "dev-2000",
+			Image:           cfg.Images[1],
+			Flags:           []string{"--execution=wasm", "--wasmtime-instantiation-strategy=recreate-instance-copy-on-write"},
+			RelayChainFlags: []string{"--execution=wasm"},
+		}}
+	}
+	for i := range parachains {
+		if parachains[i].NumNodes == 0 {
+			parachains[i].NumNodes = numFullNodes
+		}
+	}
+	return parachains, nil
+}
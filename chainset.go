@@ -11,6 +11,7 @@ import (
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -26,9 +27,10 @@ type chainSet struct {
 	chains map[ibc.Chain]struct{}
 
 	// The following fields are set during TrackBlocks, and used in Close.
-	trackerEg  *errgroup.Group
-	db         *sql.DB
-	collectors []*blockdb.Collector
+	trackerEg    *errgroup.Group
+	stopTracking context.CancelFunc
+	db           *sql.DB
+	results      *resultRecorder // Closes db instead of Close, if set.
 }
 
 func newChainSet(log *zap.Logger, chains []ibc.Chain) *chainSet {
@@ -122,8 +124,10 @@ func (cs *chainSet) Start(ctx context.Context, testName string, additionalGenesi
 // This method is a nop if dbPath is blank.
 // The gitSha is used to pin a git commit to a test invocation. Thus, when a user is looking at historical
 // data they are able to determine which version of the code produced the results.
+// If the test associated with rep is tracked by its reporter, the results of the test and its subtests,
+// and the relayer commands they run, are saved in the database too.
 // Expected to be called after Start.
-func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha string) error {
+func (cs *chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha string, rep *testreporter.RelayerExecReporter) error {
 	if len(dbPath) == 0 {
 		// nop
 		return nil
@@ -149,10 +153,17 @@ func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha str
 		return fmt.Errorf("create test case in sqlite database: %w", err)
 	}
 
+	if rep != nil {
+		results := &resultRecorder{log: cs.log, db: db, testName: rep.TestName(), rec: testCase.ResultRecorder()}
+		if rep.Listen(results.record) {
+			cs.results = results
+		}
+	}
+
 	// TODO (nix - 6/1/22) Need logger instead of fmt.Fprint
 	cs.trackerEg = new(errgroup.Group)
-	cs.collectors = make([]*blockdb.Collector, len(cs.chains))
-	i := 0
+	// The collectors run until Close.
+	ctx, cs.stopTracking = context.WithCancel(ctx)
 	for c := range cs.chains {
 		c := c
 		id := c.Config().ChainID
@@ -161,42 +172,92 @@ func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha str
 			fmt.Fprintf(os.Stderr, `Chain %s is not configured to save blocks; must implement "FindTxs(ctx context.Context, height uint64) ([][]byte, error)"`+"\n", id)
 			return nil
 		}
-		j := i // Avoid closure on loop variable.
-		cs.trackerEg.Go(func() error {
-			chaindb, err := testCase.AddChain(ctx, id, c.Config().Type)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to add chain %s to database: %v", id, err)
-				return nil
+		cs.collectBlocks(ctx, testCase, id, c.Config().Type, finder)
+		if sf, ok := c.(blockdb.SubchainFinder); ok {
+			for name, finder := range sf.Subchains() {
+				cs.collectBlocks(ctx, testCase, id+"/"+name, c.Config().Type, finder)
 			}
-			log := cs.log.With(zap.String("chain_id", id))
-			collector := blockdb.NewCollector(log, finder, chaindb, 100*time.Millisecond)
-			cs.collectors[j] = collector
-			collector.Collect(ctx)
-			return nil
-		})
-		i++
+		}
 	}
 
 	return nil
 }
 
+// collectBlocks saves the blocks found by finder as the chain id of the test case, until ctx is done.
+func (cs *chainSet) collectBlocks(ctx context.Context, testCase *blockdb.TestCase, id, chainType string, finder blockdb.TxFinder) {
+	cs.trackerEg.Go(func() error {
+		chaindb, err := testCase.AddChain(ctx, id, chainType)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to add chain %s to database: %v", id, err)
+			return nil
+		}
+		log := cs.log.With(zap.String("chain_id", id))
+		blockdb.NewCollector(log, finder, chaindb, 100*time.Millisecond).Collect(ctx)
+		return nil
+	})
+}
+
 // Close frees any resources associated with the chainSet.
 //
 // Currently, it only frees resources from TrackBlocks.
 // Close is safe to call even if TrackBlocks was not called.
 func (cs *chainSet) Close() error {
-	for _, c := range cs.collectors {
-		if c != nil {
-			c.Stop()
-		}
+	if cs.stopTracking != nil {
+		cs.stopTracking()
 	}
 
 	var err error
 	if cs.trackerEg != nil {
 		multierr.AppendInto(&err, cs.trackerEg.Wait())
 	}
-	if cs.db != nil {
+	if cs.results != nil {
+		multierr.AppendInto(&err, cs.results.close())
+	} else if cs.db != nil {
 		multierr.AppendInto(&err, cs.db.Close())
 	}
 	return err
 }
+
+// resultRecorder saves the results reported for a test, and for its subtests, in the block database.
+// The test is reported finished after its interchain is typically closed,
+// so the resultRecorder closes the database once both happened.
+type resultRecorder struct {
+	log      *zap.Logger
+	db       *sql.DB
+	testName string
+	rec      *blockdb.ResultRecorder
+
+	mu       sync.Mutex
+	finished bool
+	closing  bool
+}
+
+func (r *resultRecorder) record(m testreporter.Message) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.rec.Record(context.Background(), m); err != nil {
+		r.log.Warn("Failed to save test result in database", zap.Error(err))
+	}
+
+	if m, ok := m.(testreporter.FinishTestMessage); ok && m.Name == r.testName {
+		r.finished = true
+		if r.closing {
+			if err := r.db.Close(); err != nil {
+				r.log.Warn("Failed to close database", zap.Error(err))
+			}
+		}
+	}
+}
+
+// close closes the database, or leaves it to be closed when the test finishes.
+func (r *resultRecorder) close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closing = true
+	if r.finished {
+		return r.db.Close()
+	}
+	return nil
+}
@@ -139,56 +139,12 @@ func (s *ChainSpec) applyConfigOverrides(cfg ibc.ChainConfig) (*ibc.ChainConfig,
 	cfg.UsingNewGenesisCommand = s.UsingNewGenesisCommand
 
 	// Set the version depending on the chain type.
-	switch cfg.Type {
-	case "cosmos":
-		if s.Version != "" && len(cfg.Images) > 0 {
-			cfg.Images[0].Version = s.Version
-		}
-	case "penumbra":
-		versionSplit := strings.Split(s.Version, ",")
-		if len(versionSplit) != 2 {
-			return nil, errors.New("penumbra version should be comma separated penumbra_version,tendermint_version")
-		}
-		cfg.Images[0].Version = versionSplit[1]
-		cfg.Images[1].Version = versionSplit[0]
-	case "polkadot":
-		// Only set if ChainSpec's Version is set, if not, Version from Images must be set.
-		if s.Version != "" {
-			versionSplit := strings.Split(s.Version, ",")
-			relayChainImageSplit := strings.Split(versionSplit[0], ":")
-			var relayChainVersion string
-			if len(relayChainImageSplit) > 1 {
-				if relayChainImageSplit[0] != "seunlanlege/centauri-polkadot" &&
-					relayChainImageSplit[0] != "polkadot" {
-					return nil, fmt.Errorf("only polkadot is supported as the relay chain node. got: %s", relayChainImageSplit[0])
-				}
-				relayChainVersion = relayChainImageSplit[1]
-			} else {
-				relayChainVersion = relayChainImageSplit[0]
-			}
-			cfg.Images[0].Version = relayChainVersion
-			switch {
-			case strings.Contains(s.Name, "composable"):
-				if len(versionSplit) != 2 {
-					return nil, fmt.Errorf("unexpected composable version: %s. should be comma separated polkadot:version,composable:version", s.Version)
-				}
-				imageSplit := strings.Split(versionSplit[1], ":")
-				if len(imageSplit) != 2 {
-					return nil, fmt.Errorf("parachain versions should be in the format parachain_name:parachain_version, got: %s", versionSplit[1])
-				}
-				if !strings.Contains(cfg.Images[1].Repository, imageSplit[0]) {
-					return nil, fmt.Errorf("unexpected parachain: %s", imageSplit[0])
-				}
-				cfg.Images[1].Version = imageSplit[1]
-			default:
-				return nil, fmt.Errorf("unexpected parachain: %s", s.Name)
-			}
-		} else {
-			// Ensure there are at least two images and check the 2nd version is populated
-			if len(s.ChainConfig.Images) < 2 || s.ChainConfig.Images[1].Version == "" {
-				return nil, fmt.Errorf("ChainCongfig.Images must be >1 and ChainConfig.Images[1].Version must not be empty")
-			}
-		}
+	t, err := lookupChainType(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.applyVersion(s, &cfg); err != nil {
+		return nil, err
 	}
 
 	return &cfg, nil
@@ -206,3 +162,75 @@ func (s *ChainSpec) suffix() string {
 
 // suffixCounter is a package-level counter for safely generating unique suffixes per execution environment.
 var suffixCounter int32
+
+// applyImageVersion implements ChainVersionFunc for chains of a single image, such as cosmos chains.
+func applyImageVersion(s *ChainSpec, cfg *ibc.ChainConfig) error {
+	if s.Version != "" && len(cfg.Images) > 0 {
+		cfg.Images[0].Version = s.Version
+	}
+	return nil
+}
+
+// applyPenumbraVersion implements ChainVersionFunc for penumbra chains,
+// whose version is comma separated penumbra_version,tendermint_version.
+func applyPenumbraVersion(s *ChainSpec, cfg *ibc.ChainConfig) error {
+	versionSplit := strings.Split(s.Version, ",")
+	if len(versionSplit) != 2 {
+		return errors.New("penumbra version should be comma separated penumbra_version,tendermint_version")
+	}
+	cfg.Images[0].Version = versionSplit[1]
+	cfg.Images[1].Version = versionSplit[0]
+	return nil
+}
+
+// applyPolkadotVersion implements ChainVersionFunc for polkadot chains,
+// whose version is the relay chain version followed by comma separated parachain versions.
+func applyPolkadotVersion(s *ChainSpec, cfg *ibc.ChainConfig) error {
+	// Only set if ChainSpec's Version is set, if not, Version from Images must be set.
+	if s.Version != "" {
+		versionSplit := strings.Split(s.Version, ",")
+		relayChainImageSplit := strings.Split(versionSplit[0], ":")
+		var relayChainVersion string
+		if len(relayChainImageSplit) > 1 {
+			if relayChainImageSplit[0] != "seunlanlege/centauri-polkadot" &&
+				relayChainImageSplit[0] != "polkadot" {
+				return fmt.Errorf("only polkadot is supported as the relay chain node. got: %s", relayChainImageSplit[0])
+			}
+			relayChainVersion = relayChainImageSplit[1]
+		} else {
+			relayChainVersion = relayChainImageSplit[0]
+		}
+		cfg.Images[0].Version = relayChainVersion
+		switch {
+		case len(cfg.Parachains) > 0:
+			// Parachain versions follow the relay chain version, in the order of the parachains.
+			if len(versionSplit)-1 > len(cfg.Parachains) {
+				return fmt.Errorf("unexpected polkadot version: %s. got %d parachain versions for %d parachains", s.Version, len(versionSplit)-1, len(cfg.Parachains))
+			}
+			for i, v := range versionSplit[1:] {
+				imageSplit := strings.Split(v, ":")
+				cfg.Parachains[i].Image.Version = imageSplit[len(imageSplit)-1]
+			}
+		case strings.Contains(s.Name, "composable"):
+			if len(versionSplit) != 2 {
+				return fmt.Errorf("unexpected composable version: %s. should be comma separated polkadot:version,composable:version", s.Version)
+			}
+			imageSplit := strings.Split(versionSplit[1], ":")
+			if len(imageSplit) != 2 {
+				return fmt.Errorf("parachain versions should be in the format parachain_name:parachain_version, got: %s", versionSplit[1])
+			}
+			if !strings.Contains(cfg.Images[1].Repository, imageSplit[0]) {
+				return fmt.Errorf("unexpected parachain: %s", imageSplit[0])
+			}
+			cfg.Images[1].Version = imageSplit[1]
+		default:
+			return fmt.Errorf("unexpected parachain: %s", s.Name)
+		}
+	} else if len(cfg.Parachains) == 0 {
+		// Ensure there are at least two images and check the 2nd version is populated
+		if len(s.ChainConfig.Images) < 2 || s.ChainConfig.Images[1].Version == "" {
+			return fmt.Errorf("ChainCongfig.Images must be >1 and ChainConfig.Images[1].Version must not be empty")
+		}
+	}
+	return nil
+}
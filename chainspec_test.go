@@ -147,6 +147,34 @@ func TestChainSpec_Config(t *testing.T) {
 		})
 	})
 
+	t.Run("polkadot parachains", func(t *testing.T) {
+		s := interchaintest.ChainSpec{
+			Name:    "composable",
+			Version: "polkadot:v0.9.39,parachain-a:v1,v2",
+
+			ChainConfig: ibc.ChainConfig{
+				Parachains: []ibc.ParachainConfig{
+					{ChainID: "dev-2000", Bin: "parachain-a", Image: ibc.DockerImage{Repository: "example.com/parachain-a"}},
+					{ChainID: "dev-2001", Bin: "parachain-b", ParaID: 2001, Image: ibc.DockerImage{Repository: "example.com/parachain-b"}},
+				},
+			},
+		}
+
+		cfg, err := s.Config(zaptest.NewLogger(t))
+		require.NoError(t, err)
+
+		require.Equal(t, "v0.9.39", cfg.Images[0].Version)
+		require.Len(t, cfg.Parachains, 2)
+		require.Equal(t, "v1", cfg.Parachains[0].Image.Version)
+		require.Equal(t, "v2", cfg.Parachains[1].Image.Version)
+		require.Equal(t, 2001, cfg.Parachains[1].ParaID)
+		require.Empty(t, s.Parachains[0].Image.Version, "spec parachains must not be modified")
+
+		s.Version = "polkadot:v0.9.39,v1,v2,v3"
+		_, err = s.Config(zaptest.NewLogger(t))
+		require.ErrorContains(t, err, "got 3 parachain versions for 2 parachains")
+	})
+
 	t.Run("error cases", func(t *testing.T) {
 		t.Run("version required", func(t *testing.T) {
 			s := interchaintest.ChainSpec{
@@ -0,0 +1,114 @@
+package interchaintest
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/penumbra"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/polkadot"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"go.uber.org/zap"
+)
+
+// ChainConstructor returns an uninitialized chain of a registered chain type,
+// from a fully built config and the number of validators and full nodes of the ChainSpec.
+type ChainConstructor func(log *zap.Logger, testName string, cfg ibc.ChainConfig, numValidators, numFullNodes int) (ibc.Chain, error)
+
+// ChainVersionFunc sets the versions of the images in cfg from the Version of spec.
+// It is called whether or not spec.Version is set, so that it may also validate the images.
+type ChainVersionFunc func(spec *ChainSpec, cfg *ibc.ChainConfig) error
+
+// ChainTypeOption customizes a chain type registered with RegisterChainType.
+type ChainTypeOption func(*chainType)
+
+// WithChainVersion sets how the Version of a ChainSpec applies to the images of a chain type.
+// By default, a non-empty Version is the version of the first image.
+func WithChainVersion(fn ChainVersionFunc) ChainTypeOption {
+	return func(t *chainType) {
+		t.applyVersion = fn
+	}
+}
+
+type chainType struct {
+	newChain     ChainConstructor
+	applyVersion ChainVersionFunc
+}
+
+var (
+	chainTypesMu sync.RWMutex
+	chainTypes   = make(map[string]chainType)
+)
+
+// RegisterChainType registers the constructor of chains whose ibc.ChainConfig.Type is typ,
+// so that ChainSpec and BuiltinChainFactory can build chains implemented outside of interchaintest.
+// The cosmos, penumbra and polkadot types are registered by default.
+//
+// RegisterChainType is typically called from an init function.
+// It panics if typ is empty, newChain is nil, or typ is already registered.
+func RegisterChainType(typ string, newChain ChainConstructor, opts ...ChainTypeOption) {
+	if typ == "" {
+		panic("interchaintest: RegisterChainType with empty chain type")
+	}
+	if newChain == nil {
+		panic("interchaintest: RegisterChainType with nil constructor for chain type " + typ)
+	}
+
+	t := chainType{newChain: newChain, applyVersion: applyImageVersion}
+	for _, opt := range opts {
+		opt(&t)
+	}
+
+	chainTypesMu.Lock()
+	defer chainTypesMu.Unlock()
+	if _, dup := chainTypes[typ]; dup {
+		panic("interchaintest: RegisterChainType called twice for chain type " + typ)
+	}
+	chainTypes[typ] = t
+}
+
+// unregisterChainType removes the chain type registered as typ, so that tests can register it again.
+func unregisterChainType(typ string) {
+	chainTypesMu.Lock()
+	defer chainTypesMu.Unlock()
+	delete(chainTypes, typ)
+}
+
+// ChainTypes returns the sorted names of the registered chain types.
+func ChainTypes() []string {
+	chainTypesMu.RLock()
+	defer chainTypesMu.RUnlock()
+	types := make([]string, 0, len(chainTypes))
+	for typ := range chainTypes {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func lookupChainType(cfg ibc.ChainConfig) (chainType, error) {
+	chainTypesMu.RLock()
+	defer chainTypesMu.RUnlock()
+	t, ok := chainTypes[cfg.Type]
+	if !ok {
+		return chainType{}, fmt.Errorf("unexpected error, unknown chain type: %s for chain: %s", cfg.Type, cfg.Name)
+	}
+	return t, nil
+}
+
+func init() {
+	RegisterChainType("cosmos", func(log *zap.Logger, testName string, cfg ibc.ChainConfig, nv, nf int) (ibc.Chain, error) {
+		return cosmos.NewCosmosChain(testName, cfg, nv, nf, log), nil
+	})
+	RegisterChainType("penumbra", func(log *zap.Logger, testName string, cfg ibc.ChainConfig, nv, nf int) (ibc.Chain, error) {
+		return penumbra.NewPenumbraChain(log, testName, cfg, nv, nf), nil
+	}, WithChainVersion(applyPenumbraVersion))
+	RegisterChainType("polkadot", func(log *zap.Logger, testName string, cfg ibc.ChainConfig, nv, nf int) (ibc.Chain, error) {
+		parachains, err := polkadotParachains(cfg, nf)
+		if err != nil {
+			return nil, err
+		}
+		return polkadot.NewPolkadotChain(log, testName, cfg, nv, parachains), nil
+	}, WithChainVersion(applyPolkadotVersion))
+}
@@ -0,0 +1,67 @@
+package interchaintest_test
+
+import (
+	"errors"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestRegisterChainType(t *testing.T) {
+	require.Subset(t, interchaintest.ChainTypes(), []string{"cosmos", "penumbra", "polkadot"})
+
+	errBuilt := errors.New("built")
+	var built ibc.ChainConfig
+	var builtValidators, builtFullNodes int
+	t.Cleanup(func() { interchaintest.UnregisterChainType("test-registered") })
+	interchaintest.RegisterChainType("test-registered", func(_ *zap.Logger, _ string, cfg ibc.ChainConfig, nv, nf int) (ibc.Chain, error) {
+		built, builtValidators, builtFullNodes = cfg, nv, nf
+		return nil, errBuilt
+	}, interchaintest.WithChainVersion(func(spec *interchaintest.ChainSpec, cfg *ibc.ChainConfig) error {
+		cfg.Images[1].Version = spec.Version + "-sidecar"
+		return nil
+	}))
+	require.Contains(t, interchaintest.ChainTypes(), "test-registered")
+
+	require.PanicsWithValue(t, "interchaintest: RegisterChainType called twice for chain type test-registered", func() {
+		interchaintest.RegisterChainType("test-registered", func(*zap.Logger, string, ibc.ChainConfig, int, int) (ibc.Chain, error) {
+			return nil, nil
+		})
+	})
+
+	nv, nf := 3, 0
+	spec := &interchaintest.ChainSpec{
+		ChainName: "mychain",
+		Version:   "v1.0.0",
+		ChainConfig: ibc.ChainConfig{
+			Type:    "test-registered",
+			ChainID: "mychain-1",
+			Images: []ibc.DockerImage{
+				{Repository: "docker.example.com/node"},
+				{Repository: "docker.example.com/sidecar"},
+			},
+			Bin:            "/bin/true",
+			Bech32Prefix:   "foo",
+			Denom:          "bar",
+			GasPrices:      "1bar",
+			TrustingPeriod: "24h",
+		},
+		NumValidators: &nv,
+		NumFullNodes:  &nf,
+	}
+
+	_, err := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{spec}).Chains(t.Name())
+	require.ErrorIs(t, err, errBuilt)
+	require.Equal(t, "mychain-1", built.ChainID)
+	require.Equal(t, "v1.0.0-sidecar", built.Images[1].Version)
+	require.Equal(t, 3, builtValidators)
+	require.Equal(t, 0, builtFullNodes)
+
+	spec.ChainConfig.Type = "test-unregistered"
+	_, err = spec.Config(zaptest.NewLogger(t))
+	require.ErrorContains(t, err, "unknown chain type: test-unregistered")
+}
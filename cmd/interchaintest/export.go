@@ -0,0 +1,63 @@
+package interchaintest
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/version"
+)
+
+// runDebugExport exports a test case of the block database, as the implementation of the debug export subcommand.
+func (f mainFlags) runDebugExport(ctx context.Context) error {
+	switch format := blockdb.ExportFormat(f.ExportFormat); format {
+	case blockdb.ExportJSONL, blockdb.ExportCSV, blockdb.ExportHTML:
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+
+	// Explicitly check for file existence otherwise blockdb.ConnectDB implicitly creates and migrates a sqlite file.
+	if _, err := os.Stat(f.BlockDatabaseFile); err != nil {
+		return err
+	}
+
+	db, err := blockdb.ConnectDB(ctx, f.BlockDatabaseFile)
+	if err != nil {
+		return fmt.Errorf("connect to database %s: %w", f.BlockDatabaseFile, err)
+	}
+	defer db.Close()
+
+	if err = blockdb.Migrate(db, version.GitSha); err != nil {
+		return fmt.Errorf("migrate database %s: %w", f.BlockDatabaseFile, err)
+	}
+
+	querySvc := blockdb.NewQuery(db)
+
+	testCaseID := f.ExportTestCase
+	if testCaseID == 0 {
+		testCases, err := querySvc.RecentTestCases(ctx, 1)
+		if err != nil {
+			return fmt.Errorf("query recent test cases: %w", err)
+		}
+		if len(testCases) == 0 {
+			return fmt.Errorf("no test cases found in database %s", f.BlockDatabaseFile)
+		}
+		testCaseID = testCases[0].ID
+	}
+
+	format := blockdb.ExportFormat(f.ExportFormat)
+	if f.ExportFile == "" {
+		return querySvc.ExportTestCase(ctx, os.Stdout, testCaseID, format)
+	}
+
+	file, err := os.Create(f.ExportFile)
+	if err != nil {
+		return err
+	}
+	if err := querySvc.ExportTestCase(ctx, file, testCaseID, format); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
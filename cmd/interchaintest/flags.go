@@ -19,6 +19,18 @@ type mainFlags struct {
 	MatrixFile        string
 	ReportFile        string
 	BlockDatabaseFile string
+
+	// Flags of the start subcommand.
+	TopologyFile  string
+	ChainSpecFile string
+	StartRelayer  string
+	FundAmount    int64
+	ControlAddr   string
+
+	// Flags of the debug export subcommand.
+	ExportTestCase int64
+	ExportFormat   string
+	ExportFile     string
 }
 
 func (f mainFlags) Logger() (lc LoggerCloser, _ error) {
@@ -8,7 +8,10 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -21,6 +24,7 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v7/internal/version"
 	"github.com/strangelove-ventures/interchaintest/v7/relayer"
 	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
 	"go.uber.org/zap"
 )
 
@@ -36,6 +40,14 @@ func init() {
 `)
 		debugFlagSet.PrintDefaults()
 		fmt.Fprint(out, `
+  debug export  Export a test case's blocks and transactions as JSON Lines, CSV or an HTML report.
+`)
+		exportFlagSet.PrintDefaults()
+		fmt.Fprint(out, `
+  start  Run a local interchain until interrupted.
+`)
+		startFlagSet.PrintDefaults()
+		fmt.Fprint(out, `
   version  Prints git commit that produced executable.
 `)
 	}
@@ -48,7 +60,11 @@ var testMatrix struct {
 	ChainSets [][]*interchaintest.ChainSpec
 }
 
-var debugFlagSet = flag.NewFlagSet("debug", flag.ExitOnError)
+var (
+	debugFlagSet  = flag.NewFlagSet("debug", flag.ExitOnError)
+	exportFlagSet = flag.NewFlagSet("debug export", flag.ExitOnError)
+	startFlagSet  = flag.NewFlagSet("start", flag.ExitOnError)
+)
 
 func TestMain(m *testing.M) {
 	rand.Seed(time.Now().UnixNano())
@@ -59,11 +75,24 @@ func TestMain(m *testing.M) {
 
 	switch subcommand() {
 	case "debug":
+		if debugFlagSet.Arg(0) == "export" {
+			if err := extraFlags.runDebugExport(ctx); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to export: %v\n", err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
 		if err := runDebugTerminalUI(ctx); err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to run debug: %v\n", err)
 			os.Exit(1)
 		}
 		os.Exit(0)
+	case "start":
+		// Building an interchain requires a *testing.T,
+		// so run only TestStart, and let it run until interrupted.
+		_ = flag.Set("test.run", "^TestStart$")
+		_ = flag.Set("test.timeout", "0")
+		os.Exit(m.Run())
 	case "version":
 		fmt.Fprintln(os.Stderr, version.GitSha)
 		os.Exit(0)
@@ -173,10 +202,9 @@ func getRelayerFactory(name string, logger *zap.Logger) (interchaintest.RelayerF
 	switch name {
 	case "rly", "cosmos/relayer":
 		return interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, logger, relayer.StartupFlags("-b", "100")), nil
-	case "hermes":
-		return interchaintest.NewBuiltinRelayerFactory(ibc.Hermes, logger), nil
 	default:
-		return nil, fmt.Errorf("unknown relayer type %q (valid types: rly, hermes)", name)
+		// Any relayer type registered with interchaintest.RegisterRelayerType.
+		return interchaintest.NewRelayerFactory(name, logger)
 	}
 }
 
@@ -249,6 +277,18 @@ func addFlags() {
 	flag.StringVar(&extraFlags.ReportFile, "report-file", "", "Path where test report will be stored. Defaults to $HOME/.interchaintest/reports/$TIMESTAMP.json")
 
 	debugFlagSet.StringVar(&extraFlags.BlockDatabaseFile, "block-db", interchaintest.DefaultBlockDatabaseFilepath(), "Path to database sqlite file that tracks blocks and transactions.")
+
+	exportFlagSet.StringVar(&extraFlags.BlockDatabaseFile, "block-db", interchaintest.DefaultBlockDatabaseFilepath(), "Path to database sqlite file that tracks blocks and transactions.")
+	exportFlagSet.Int64Var(&extraFlags.ExportTestCase, "test-case", 0, "ID of the test case to export, as shown by debug. Defaults to the most recent test case")
+	exportFlagSet.StringVar(&extraFlags.ExportFormat, "format", "jsonl", "Export format: jsonl|csv|html")
+	exportFlagSet.StringVar(&extraFlags.ExportFile, "o", "", "Path of the file to export to. Defaults to stdout")
+
+	startFlagSet.StringVar(&extraFlags.TopologyFile, "topology", "", "Path to YAML or JSON topology file defining the chains, relayers, and links to run")
+	startFlagSet.StringVar(&extraFlags.ChainSpecFile, "chains", "", "Path to YAML or JSON file with a list of chain specs, linked in sequence. Defaults to gaia and osmosis")
+	startFlagSet.StringVar(&extraFlags.StartRelayer, "relayer", "rly", "Relayer linking the chains of -chains: "+strings.Join(interchaintest.RelayerTypes(), "|"))
+	startFlagSet.Int64Var(&extraFlags.FundAmount, "fund-amount", 10_000_000_000, "Amount of native denom to fund a new wallet with on each chain")
+	startFlagSet.StringVar(&extraFlags.ControlAddr, "control-addr", "", "Address to serve the HTTP control API on, e.g. localhost:8080. Disabled if empty")
+	startFlagSet.StringVar(&extraFlags.BlockDatabaseFile, "block-db", "", "Path to database sqlite file that tracks blocks and transactions, for use with debug. Disabled if empty")
 }
 
 func parseFlags() {
@@ -257,6 +297,11 @@ func parseFlags() {
 	case "debug":
 		// Ignore errors because configured with flag.ExitOnError.
 		_ = debugFlagSet.Parse(os.Args[2:])
+		if debugFlagSet.Arg(0) == "export" {
+			_ = exportFlagSet.Parse(debugFlagSet.Args()[1:])
+		}
+	case "start":
+		_ = startFlagSet.Parse(os.Args[2:])
 	}
 }
 
@@ -304,3 +349,90 @@ func runDebugTerminalUI(ctx context.Context) error {
 		SetRoot(model.RootView(), true).
 		Run()
 }
+
+// TestStart runs a long-lived interchain for local development,
+// as the implementation of the start subcommand.
+// The chains and relayers are torn down when the process receives an interrupt or termination signal.
+func TestStart(t *testing.T) {
+	if subcommand() != "start" {
+		t.Skip("only run by the start subcommand")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger, err := extraFlags.Logger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = logger.Close() })
+	fmt.Fprintf(os.Stderr, "View chain and relayer logs at %s\n", logger.FilePath)
+
+	topo, err := extraFlags.startTopology()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, network := interchaintest.DockerSetup(t)
+
+	ic, err := interchaintest.NewInterchainFromTopology(t, logger.Logger, client, network, topo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eRep := testreporter.NewNopReporter().RelayerExecReporter(t)
+	if err := ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		GitSha:            version.GitSha,
+		BlockDatabaseFile: extraFlags.BlockDatabaseFile,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ic.Close() })
+
+	for name, r := range ic.Relayers() {
+		paths := ic.RelayerPaths(r)
+		if len(paths) == 0 {
+			continue
+		}
+		if err := r.StartRelayer(ctx, eRep, paths...); err != nil {
+			t.Fatalf("failed to start relayer %s: %v", name, err)
+		}
+		r := r
+		t.Cleanup(func() {
+			// The signal context is already done during cleanup.
+			_ = r.StopRelayer(context.Background(), eRep)
+		})
+	}
+
+	chains := ic.Chains()
+	wallets := interchaintest.GetAndFundTestUsers(t, ctx, "devnet", extraFlags.FundAmount, chains...)
+
+	// Wait for the funding transactions to be committed.
+	heighters := make([]testutil.ChainHeighter, len(chains))
+	for i, c := range chains {
+		heighters[i] = c
+	}
+	if err := testutil.WaitForBlocks(ctx, 2, heighters...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := printDevnet(os.Stdout, chains, wallets); err != nil {
+		t.Fatal(err)
+	}
+
+	if extraFlags.ControlAddr != "" {
+		srv, err := ic.StartControlServer(eRep, extraFlags.ControlAddr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = srv.Close(context.Background()) })
+		fmt.Fprintf(os.Stdout, "\nControl API: http://%s\n", srv.Addr())
+	}
+	fmt.Fprintln(os.Stderr, "Interchain running; press Ctrl+C to stop")
+
+	<-ctx.Done()
+	fmt.Fprintln(os.Stderr, "Stopping interchain")
+}
@@ -44,3 +44,13 @@ func TestMatrixValid(t *testing.T) {
 		})
 	}
 }
+
+func TestTopologyValid(t *testing.T) {
+	for _, name := range []string{"example_topology.yaml", "example_topology.json"} {
+		t.Run(name, func(t *testing.T) {
+			topo, err := interchaintest.ReadTopologyFile(name)
+			require.NoError(t, err)
+			require.NoError(t, topo.Validate(zaptest.NewLogger(t)))
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package interchaintest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// startTopology returns the topology to run with the start subcommand.
+//
+// If a topology file is set, it is used as is.
+// Otherwise, the chains of the chain spec file are linked in sequence by a single relayer,
+// or gaia and osmosis are linked if neither file is set.
+func (f mainFlags) startTopology() (*interchaintest.Topology, error) {
+	if f.TopologyFile != "" {
+		if f.ChainSpecFile != "" {
+			return nil, errors.New("only one of -topology and -chains may be set")
+		}
+		return interchaintest.ReadTopologyFile(f.TopologyFile)
+	}
+
+	specs := []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.1"},
+		{Name: "osmosis", Version: "v7.2.0"},
+	}
+	if f.ChainSpecFile != "" {
+		var err error
+		specs, err = interchaintest.ReadChainSpecFile(f.ChainSpecFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return linearTopology(specs, f.StartRelayer), nil
+}
+
+// linearTopology returns a topology in which each chain is linked to the next one by a single relayer.
+func linearTopology(specs []*interchaintest.ChainSpec, relayerType string) *interchaintest.Topology {
+	topo := &interchaintest.Topology{
+		Relayers: []interchaintest.TopologyRelayer{{Name: relayerType, Type: relayerType}},
+	}
+
+	names := make([]string, len(specs))
+	for i, s := range specs {
+		topo.Chains = append(topo.Chains, interchaintest.TopologyChain{ChainSpec: s})
+
+		// Chains are referred to by ChainName if set, and otherwise by Name,
+		// which for fully configured chains may only be set in the embedded config.
+		switch {
+		case s.ChainName != "":
+			names[i] = s.ChainName
+		case s.Name != "":
+			names[i] = s.Name
+		default:
+			names[i] = s.ChainConfig.Name
+		}
+	}
+
+	for i := 0; i+1 < len(names); i++ {
+		topo.Links = append(topo.Links, interchaintest.TopologyLink{
+			Chain1:  names[i],
+			Chain2:  names[i+1],
+			Relayer: relayerType,
+			Path:    names[i] + "-" + names[i+1],
+		})
+	}
+	return topo
+}
+
+// hostAPIAddresser is implemented by chains that serve a REST API reachable from the host.
+type hostAPIAddresser interface {
+	GetHostAPIAddress() string
+}
+
+// printDevnet writes the host endpoints of each chain and the funded wallets to w.
+// wallets must be in the same order as chains.
+func printDevnet(w io.Writer, chains []ibc.Chain, wallets []ibc.Wallet) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "CHAIN ID\tRPC\tGRPC\tREST")
+	for _, c := range chains {
+		rest := "-"
+		if a, ok := c.(hostAPIAddresser); ok {
+			rest = a.GetHostAPIAddress()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", c.Config().ChainID, c.GetHostRPCAddress(), c.GetHostGRPCAddress(), rest)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Funded wallets:")
+	for i, wallet := range wallets {
+		cfg := chains[i].Config()
+		fmt.Fprintf(w, "  %s  %s (key %s)\n", cfg.ChainID, wallet.FormattedAddress(), wallet.KeyName())
+		fmt.Fprintf(w, "    mnemonic: %s\n", wallet.Mnemonic())
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package interchaintest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestMainFlags_StartTopology(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		topo, err := mainFlags{StartRelayer: "rly"}.startTopology()
+		require.NoError(t, err)
+		require.NoError(t, topo.Validate(zaptest.NewLogger(t)))
+		require.Len(t, topo.Chains, 2)
+		require.Equal(t, []interchaintest.TopologyLink{
+			{Chain1: "gaia", Chain2: "osmosis", Relayer: "rly", Path: "gaia-osmosis"},
+		}, topo.Links)
+	})
+
+	t.Run("chain spec file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "chains.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`
+- Name: gaia
+  Version: v7.0.1
+- Name: osmosis
+  Version: v11.0.1
+- Name: juno
+  ChainName: juno-a
+  Version: v14.1.0
+`), 0o644))
+
+		topo, err := mainFlags{ChainSpecFile: path, StartRelayer: "hermes"}.startTopology()
+		require.NoError(t, err)
+		require.NoError(t, topo.Validate(zaptest.NewLogger(t)))
+		require.Equal(t, []interchaintest.TopologyLink{
+			{Chain1: "gaia", Chain2: "osmosis", Relayer: "hermes", Path: "gaia-osmosis"},
+			{Chain1: "osmosis", Chain2: "juno-a", Relayer: "hermes", Path: "osmosis-juno-a"},
+		}, topo.Links)
+	})
+
+	t.Run("topology file", func(t *testing.T) {
+		topo, err := mainFlags{TopologyFile: "example_topology.yaml"}.startTopology()
+		require.NoError(t, err)
+		require.Len(t, topo.Chains, 3)
+	})
+
+	t.Run("both files", func(t *testing.T) {
+		_, err := mainFlags{TopologyFile: "example_topology.yaml", ChainSpecFile: "chains.json"}.startTopology()
+		require.Error(t, err)
+	})
+}
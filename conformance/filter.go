@@ -0,0 +1,163 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRelayerChannelFilter exercises the channel filter the relayer applies to a single path.
+//
+// Given 2 chains, Chain A and Chain B, linked by two paths, this test asserts:
+// 1. With an allowlist on the first path, packets are relayed on the allowed channel of that path.
+// 2. Packets are not relayed on the other channel of the first path.
+// 3. Packets are still relayed on the second path, which has no filter.
+func TestRelayerChannelFilter(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rf interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	requireCapabilities(t, rep, rf, relayer.MultiPathFilter)
+
+	client, network := interchaintest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	c0, c1 := chains[0], chains[1]
+	c0ChainID, c1ChainID := c0.Config().ChainID, c1.Config().ChainID
+
+	r := rf.Build(t, client, network)
+
+	const (
+		filteredPath   = "filtered"
+		unfilteredPath = "unfiltered"
+	)
+	ic := interchaintest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path:              filteredPath,
+			CreateChannelOpts: ibc.DefaultChannelOpts(),
+		})
+
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	defer ic.Close()
+
+	// newChannel runs create and returns the ID of the channel it opened on Chain A.
+	newChannel := func(create func() error) string {
+		before, err := r.GetChannels(ctx, eRep, c0ChainID)
+		req.NoError(err)
+		req.NoError(create())
+		after, err := r.GetChannels(ctx, eRep, c0ChainID)
+		req.NoError(err)
+	next:
+		for _, ch := range after {
+			for _, b := range before {
+				if b.ChannelID == ch.ChannelID {
+					continue next
+				}
+			}
+			return ch.ChannelID
+		}
+		req.FailNow("no channel was opened")
+		return ""
+	}
+
+	channels, err := r.GetChannels(ctx, eRep, c0ChainID)
+	req.NoError(err)
+	req.Len(channels, 1)
+	allowed := channels[0].ChannelID
+
+	blocked := newChannel(func() error {
+		return r.CreateChannel(ctx, eRep, filteredPath, ibc.DefaultChannelOpts())
+	})
+	unfiltered := newChannel(func() error {
+		if err := r.GeneratePath(ctx, eRep, c0ChainID, c1ChainID, unfilteredPath); err != nil {
+			return err
+		}
+		return r.LinkPath(ctx, eRep, unfilteredPath, ibc.DefaultChannelOpts(), ibc.DefaultClientOpts())
+	})
+
+	req.NoError(r.UpdatePath(ctx, eRep, filteredPath, ibc.ChannelFilter{
+		Rule:        "allowlist",
+		ChannelList: []string{allowed},
+	}))
+
+	req.NoError(r.StartRelayer(ctx, eRep, filteredPath, unfilteredPath))
+	defer func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	}()
+
+	// Get faucet address on destination chain for ibc transfer.
+	c1FaucetAddrBytes, err := c1.GetAddress(ctx, interchaintest.FaucetAccountKeyName)
+	req.NoError(err)
+	c1FaucetAddr, err := types.Bech32ifyAddressBytes(c1.Config().Bech32Prefix, c1FaucetAddrBytes)
+	req.NoError(err)
+
+	start, err := c0.Height(ctx)
+	req.NoError(err)
+
+	txs := make(map[string]ibc.Tx, 3)
+	for _, channelID := range []string{allowed, blocked, unfiltered} {
+		tx, err := c0.SendIBCTransfer(ctx, channelID, interchaintest.FaucetAccountKeyName, ibc.WalletAmount{
+			Address: c1FaucetAddr,
+			Denom:   c0.Config().Denom,
+			Amount:  testCoinAmount,
+		}, ibc.TransferOptions{})
+		req.NoError(err)
+		req.NoError(tx.Validate())
+		txs[channelID] = tx
+	}
+
+	t.Run("allowed channel", func(t *testing.T) {
+		rep.TrackTest(t)
+		req := require.New(rep.TestifyT(t))
+
+		_, err := testutil.PollForAck(ctx, c0, start, start+pollHeightMax, txs[allowed].Packet)
+		req.NoError(err, "packet on the allowed channel was not relayed")
+	})
+
+	t.Run("unfiltered path", func(t *testing.T) {
+		rep.TrackTest(t)
+		req := require.New(rep.TestifyT(t))
+
+		_, err := testutil.PollForAck(ctx, c0, start, start+pollHeightMax, txs[unfiltered].Packet)
+		req.NoError(err, "packet on the path without a filter was not relayed")
+	})
+
+	t.Run("filtered channel", func(t *testing.T) {
+		rep.TrackTest(t)
+		req := require.New(rep.TestifyT(t))
+
+		// The other packets were relayed by now, so the relayer had every chance to relay this one.
+		end, err := c0.Height(ctx)
+		req.NoError(err)
+		_, err = testutil.PollForAck(ctx, c0, start, end+5, txs[blocked].Packet)
+		req.Error(err, "packet on a channel outside the allowlist was relayed")
+	})
+}
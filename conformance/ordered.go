@@ -0,0 +1,480 @@
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/gogoproto/proto"
+	icacontrollertypes "github.com/cosmos/ibc-go/v7/modules/apps/27-interchain-accounts/controller/types"
+	icatypes "github.com/cosmos/ibc-go/v7/modules/apps/27-interchain-accounts/types"
+	chantypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"github.com/docker/docker/client"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+const (
+	// Number of packets sent in a single transaction when checking in-order delivery.
+	orderedBurstSize = 5
+
+	// How long to wait for a channel to reach an expected state.
+	channelStateTimeout = 2 * time.Minute
+
+	// Port and version of the mock application of the ibc-go simapp, which accepts ChanCloseInit,
+	// and the version of the simapp chains the closing handshake runs on.
+	mockPortID      = "mock"
+	mockVersion     = "mock-version"
+	mockSimdVersion = "v7.0.0"
+)
+
+// TestRelayerOrderedChannels exercises the relayer on ORDERED channels.
+//
+// ICS-20 transfer channels are always unordered, so the test uses interchain accounts channels,
+// which are ordered in ibc-go v7. The first chain must enable the interchain accounts controller,
+// and the second chain the interchain accounts host; otherwise the interchain accounts cases are skipped.
+//
+// Each interchain accounts case opens its own channel, so that the cases do not depend on one another.
+// The applications of ibc-go reject closing their channels, so the explicit closing handshake
+// is exercised on the port of the mock application, on a dedicated pair of ibc-go simapp chains.
+//
+// Given 2 chains, Chain A and Chain B, this test asserts:
+// 1. The relayer completes the handshake of an ordered channel opened by Chain A.
+// 2. A burst of packets sent in one transaction is received on Chain B in sequence order, and acknowledged.
+// 3. A timed out packet closes the channel end on Chain A.
+// 4. The relayer completes the closing handshake on Chain B after Chain A's channel end closes on timeout.
+// 5. The relayer completes the closing handshake on the counterparty after a chain sends ChanCloseInit.
+func TestRelayerOrderedChannels(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rf interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	requireCapabilities(t, rep, rf, relayer.OrderedChannel)
+
+	client, network := interchaintest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	c0, ok0 := chains[0].(*cosmos.CosmosChain)
+	c1, ok1 := chains[1].(*cosmos.CosmosChain)
+	if !ok0 || !ok1 {
+		rep.TrackSkip(t, "ordered channel tests require cosmos chains")
+	}
+
+	// Check for the interchain accounts modules before starting the chains,
+	// as their binaries print the help of the query command, without error, only if the module is registered.
+	var icaSkip string
+	if missing := missingCapabilities(rf, relayer.ICAChannel); len(missing) > 0 {
+		icaSkip = fmt.Sprintf("skipping due to missing relayer capabilities +%s", missing)
+	} else if err := checkChainCommand(ctx, t, client, network, c0, "query", "interchain-accounts", "controller"); err != nil {
+		icaSkip = fmt.Sprintf("%s does not support the interchain accounts controller: %v", c0.Config().ChainID, err)
+	} else if err := checkChainCommand(ctx, t, client, network, c1, "query", "interchain-accounts", "host"); err != nil {
+		icaSkip = fmt.Sprintf("%s does not support the interchain accounts host: %v", c1.Config().ChainID, err)
+	}
+	requireICA := func(t *testing.T) {
+		t.Helper()
+		if icaSkip != "" {
+			rep.TrackSkip(t, "%s", icaSkip)
+		}
+	}
+
+	r := rf.Build(t, client, network)
+
+	const pathName = "p"
+	ic := interchaintest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path:              pathName,
+			CreateChannelOpts: ibc.DefaultChannelOpts(),
+		})
+
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	defer ic.Close()
+
+	recipient := interchaintest.GetAndFundTestUsers(t, ctx, "ordered", userFaucetFund, c1)[0]
+
+	connections, err := r.GetConnections(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+	req.NotEmpty(connections)
+	connectionID := connections[0].ID
+
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	defer func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	}()
+
+	c1Denom := c1.Config().Denom
+
+	// icaChannel is an ordered interchain accounts channel, opened for a single subtest
+	// so that the subtests do not depend on the channel state left by one another.
+	type icaChannel struct {
+		owner                  ibc.Wallet
+		port                   string
+		controllerEnd, hostEnd ibc.ChannelOutput
+		address                string
+	}
+
+	// openICAChannel registers an interchain account for a new owner on Chain A,
+	// waits for the relayer to open its ordered channel, and funds the account on Chain B.
+	openICAChannel := func(t *testing.T) icaChannel {
+		req := require.New(rep.TestifyT(t))
+
+		ch := icaChannel{owner: interchaintest.GetAndFundTestUsers(t, ctx, "ordered", userFaucetFund, c0)[0]}
+		_, err := cosmos.BroadcastTx(ctx, cosmos.NewBroadcaster(t, c0), ch.owner, &icacontrollertypes.MsgRegisterInterchainAccount{
+			Owner:        ch.owner.FormattedAddress(),
+			ConnectionId: connectionID,
+		})
+		req.NoError(err, "failed to register interchain account")
+
+		ch.port, err = icatypes.NewControllerPortID(ch.owner.FormattedAddress())
+		req.NoError(err)
+
+		req.NoError(waitForChannelState(ctx, r, eRep, c0.Config().ChainID, ch.port, "", channelOpen, &ch.controllerEnd),
+			"ordered channel was not opened on %s", c0.Config().ChainID)
+		req.NoError(waitForChannelState(ctx, r, eRep, c1.Config().ChainID, icatypes.HostPortID, ch.controllerEnd.Counterparty.ChannelID, channelOpen, &ch.hostEnd),
+			"ordered channel was not opened on %s", c1.Config().ChainID)
+		req.Contains([]string{"ORDER_ORDERED", "Ordered"}, ch.controllerEnd.Ordering)
+		req.Contains([]string{"ORDER_ORDERED", "Ordered"}, ch.hostEnd.Ordering)
+
+		stdout, _, err := c0.Validators[0].ExecQuery(ctx, "interchain-accounts", "controller", "interchain-account", ch.owner.FormattedAddress(), connectionID)
+		req.NoError(err)
+		var icaRes struct {
+			Address string `json:"address"`
+		}
+		req.NoError(json.Unmarshal(stdout, &icaRes))
+		req.NotEmpty(icaRes.Address)
+		ch.address = icaRes.Address
+
+		req.NoError(c1.SendFunds(ctx, interchaintest.FaucetAccountKeyName, ibc.WalletAmount{
+			Address: ch.address,
+			Denom:   c1Denom,
+			Amount:  testCoinAmount,
+		}))
+		return ch
+	}
+
+	// sendPackets sends one interchain accounts packet per amount on the channel in a single transaction,
+	// each transferring the amount from the interchain account to the recipient.
+	sendPackets := func(t *testing.T, ch icaChannel, relativeTimeout time.Duration, amounts ...int64) error {
+		msgs := make([]types.Msg, len(amounts))
+		for i, amount := range amounts {
+			data, err := icatypes.SerializeCosmosTx(c0.Config().EncodingConfig.Codec, []proto.Message{&banktypes.MsgSend{
+				FromAddress: ch.address,
+				ToAddress:   recipient.FormattedAddress(),
+				Amount:      types.NewCoins(types.NewInt64Coin(c1Denom, amount)),
+			}})
+			if err != nil {
+				return err
+			}
+			msgs[i] = &icacontrollertypes.MsgSendTx{
+				Owner:        ch.owner.FormattedAddress(),
+				ConnectionId: connectionID,
+				PacketData: icatypes.InterchainAccountPacketData{
+					Type: icatypes.EXECUTE_TX,
+					Data: data,
+				},
+				RelativeTimeout: uint64(relativeTimeout),
+			}
+		}
+		_, err := cosmos.BroadcastTx(ctx, cosmos.NewBroadcaster(t, c0), ch.owner, msgs...)
+		return err
+	}
+
+	// timeoutPacket sends a packet on the channel that times out before the relayer has a chance to deliver it.
+	timeoutPacket := func(t *testing.T, ch icaChannel) error {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			return err
+		}
+		if err := sendPackets(t, ch, time.Second, 1); err != nil {
+			return err
+		}
+		if err := testutil.WaitForBlocks(ctx, 5, c1); err != nil {
+			return err
+		}
+		return r.StartRelayer(ctx, eRep, pathName)
+	}
+
+	t.Run("in-order delivery", func(t *testing.T) {
+		rep.TrackTest(t)
+		requireICA(t)
+
+		req := require.New(rep.TestifyT(t))
+		ch := openICAChannel(t)
+
+		c0Start, err := c0.Height(ctx)
+		req.NoError(err)
+		c1Start, err := c1.Height(ctx)
+		req.NoError(err)
+
+		balance, err := c1.GetBalance(ctx, recipient.FormattedAddress(), c1Denom)
+		req.NoError(err)
+
+		amounts := make([]int64, orderedBurstSize)
+		var total int64
+		for i := range amounts {
+			amounts[i] = int64(i + 1)
+			total += amounts[i]
+		}
+		req.NoError(sendPackets(t, ch, 10*time.Minute, amounts...))
+
+		received, err := pollReceivedPackets(ctx, c1, ch.hostEnd.ChannelID, c1Start, orderedBurstSize)
+		req.NoError(err)
+		for i := 1; i < len(received); i++ {
+			req.Equalf(received[i-1].Sequence+1, received[i].Sequence, "packets received out of order: %v", received)
+		}
+
+		c0Max, err := c0.Height(ctx)
+		req.NoError(err)
+		for _, p := range received {
+			_, err := testutil.PollForAck(ctx, c0, c0Start, c0Max+pollHeightMax, p)
+			req.NoErrorf(err, "packet %d was not acknowledged", p.Sequence)
+		}
+
+		after, err := c1.GetBalance(ctx, recipient.FormattedAddress(), c1Denom)
+		req.NoError(err)
+		req.Equal(balance+total, after)
+	})
+
+	t.Run("timeout closes channel", func(t *testing.T) {
+		rep.TrackTest(t)
+		requireICA(t)
+
+		req := require.New(rep.TestifyT(t))
+		ch := openICAChannel(t)
+
+		balance, err := c1.GetBalance(ctx, recipient.FormattedAddress(), c1Denom)
+		req.NoError(err)
+
+		req.NoError(timeoutPacket(t, ch))
+
+		req.NoError(waitForChannelState(ctx, r, eRep, c0.Config().ChainID, ch.port, ch.controllerEnd.ChannelID, channelClosed, nil),
+			"timeout did not close the channel on %s", c0.Config().ChainID)
+
+		after, err := c1.GetBalance(ctx, recipient.FormattedAddress(), c1Denom)
+		req.NoError(err)
+		req.Equal(balance, after, "timed out packet was executed")
+	})
+
+	t.Run("close confirm", func(t *testing.T) {
+		rep.TrackTest(t)
+		requireICA(t)
+		requireCapabilities(t, rep, rf, relayer.ChannelClose)
+
+		req := require.New(rep.TestifyT(t))
+		ch := openICAChannel(t)
+
+		req.NoError(timeoutPacket(t, ch))
+
+		// Chain A's channel end is closed by the timeout rather than by ChanCloseInit,
+		// but the relayer must still complete the handshake with ChanCloseConfirm on Chain B.
+		req.NoError(waitForChannelState(ctx, r, eRep, c1.Config().ChainID, icatypes.HostPortID, ch.hostEnd.ChannelID, channelClosed, nil),
+			"relayer did not close the counterparty channel on %s", c1.Config().ChainID)
+	})
+
+	t.Run("close init", func(t *testing.T) {
+		rep.TrackTest(t)
+		requireCapabilities(t, rep, rf, relayer.ChannelClose)
+
+		testRelayerCloseInit(t, ctx, client, network, rf, rep)
+	})
+}
+
+// testRelayerCloseInit asserts that the relayer completes the closing handshake on Chain B
+// after Chain A sends ChanCloseInit on an ordered channel.
+//
+// The applications of ibc-go reject closing their channels, so the handshake runs on the port of the mock application,
+// which only chains built from the ibc-go simapp bind. The case starts its own pair of simapp chains,
+// so that every relayer is exercised whatever the chains under test.
+func testRelayerCloseInit(t *testing.T, ctx context.Context, client *client.Client, network string, rf interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	req := require.New(rep.TestifyT(t))
+
+	chains, err := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "ibc-go-simd", ChainName: "simd-a", Version: mockSimdVersion},
+		{Name: "ibc-go-simd", ChainName: "simd-b", Version: mockSimdVersion},
+	}).Chains(t.Name())
+	req.NoError(err, "failed to get simapp chains")
+	c0, c1 := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	r := rf.Build(t, client, network)
+
+	const pathName = "mock"
+	ic := interchaintest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path: pathName,
+			CreateChannelOpts: ibc.CreateChannelOptions{
+				SourcePortName: mockPortID,
+				DestPortName:   mockPortID,
+				Order:          ibc.Ordered,
+				Version:        mockVersion,
+			},
+		})
+
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	defer ic.Close()
+
+	owner := interchaintest.GetAndFundTestUsers(t, ctx, "close-init", userFaucetFund, c0)[0]
+
+	var mockEnd ibc.ChannelOutput
+	req.NoError(waitForChannelState(ctx, r, eRep, c0.Config().ChainID, mockPortID, "", channelOpen, &mockEnd),
+		"ordered channel was not opened on %s", c0.Config().ChainID)
+	req.Contains([]string{"ORDER_ORDERED", "Ordered"}, mockEnd.Ordering)
+
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	defer func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	}()
+
+	_, err = cosmos.BroadcastTx(ctx, cosmos.NewBroadcaster(t, c0), owner, &chantypes.MsgChannelCloseInit{
+		PortId:    mockPortID,
+		ChannelId: mockEnd.ChannelID,
+		Signer:    owner.FormattedAddress(),
+	})
+	req.NoError(err, "failed to close channel")
+
+	req.NoError(waitForChannelState(ctx, r, eRep, c0.Config().ChainID, mockPortID, mockEnd.ChannelID, channelClosed, nil),
+		"ChanCloseInit did not close the channel on %s", c0.Config().ChainID)
+	req.NoError(waitForChannelState(ctx, r, eRep, c1.Config().ChainID, mockPortID, mockEnd.Counterparty.ChannelID, channelClosed, nil),
+		"relayer did not close the counterparty channel on %s", c1.Config().ChainID)
+}
+
+var (
+	// Channel states as reported by the different relayers.
+	channelOpen   = []string{"STATE_OPEN", "Open"}
+	channelClosed = []string{"STATE_CLOSED", "Closed"}
+)
+
+// checkChainCommand runs the binary of the chain with args in a one-off container of its image,
+// so that the support of a command can be checked before the chain is started.
+func checkChainCommand(ctx context.Context, t *testing.T, client *client.Client, network string, c ibc.Chain, args ...string) error {
+	cfg := c.Config()
+	if len(cfg.Images) == 0 {
+		return fmt.Errorf("no image for chain %s", cfg.ChainID)
+	}
+	image := cfg.Images[0]
+	res := dockerutil.NewImage(zaptest.NewLogger(t), client, network, t.Name(), image.Repository, image.Version).
+		Run(ctx, append([]string{cfg.Bin}, args...), dockerutil.ContainerOptions{User: image.UidGid})
+	if res.Err != nil {
+		return fmt.Errorf("%s %s: %w (stderr: %s)", cfg.Bin, strings.Join(args, " "), res.Err, bytes.TrimSpace(res.Stderr))
+	}
+	return nil
+}
+
+// waitForChannelState waits until the relayer reports a channel on the port of the chain in one of the states.
+// If channelID is empty, any channel on the port matches.
+// If out is not nil, it is set to the matching channel.
+func waitForChannelState(
+	ctx context.Context,
+	r ibc.Relayer,
+	rep ibc.RelayerExecReporter,
+	chainID, portID, channelID string,
+	states []string,
+	out *ibc.ChannelOutput,
+) error {
+	var last []ibc.ChannelOutput
+	err := testutil.WaitForCondition(channelStateTimeout, 2*time.Second, func() (bool, error) {
+		channels, err := r.GetChannels(ctx, rep, chainID)
+		if err != nil {
+			return false, nil
+		}
+		last = channels
+		for _, ch := range channels {
+			if ch.PortID != portID || (channelID != "" && ch.ChannelID != channelID) {
+				continue
+			}
+			for _, s := range states {
+				if ch.State == s {
+					if out != nil {
+						*out = ch
+					}
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("channel on port %s not in state %v: %w (channels: %+v)", portID, states, err, last)
+	}
+	return nil
+}
+
+// pollReceivedPackets returns the first n packets received by the chain on the channel from startHeight,
+// in the order the chain received them.
+func pollReceivedPackets(ctx context.Context, chain *cosmos.CosmosChain, channelID string, startHeight uint64, n int) ([]ibc.Packet, error) {
+	var received []ibc.Packet
+	for h := startHeight; h < startHeight+pollHeightMax; h++ {
+		for {
+			current, err := chain.Height(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if current >= h {
+				break
+			}
+			if err := testutil.WaitForBlocks(ctx, 1, chain); err != nil {
+				return nil, err
+			}
+		}
+
+		packets, err := chain.ReceivedPackets(ctx, h)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range packets {
+			if p.DestChannel != channelID {
+				continue
+			}
+			received = append(received, p)
+			if len(received) == n {
+				return received, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("received %d of %d packets on %s within %d blocks", len(received), n, channelID, pollHeightMax)
+}
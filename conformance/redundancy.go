@@ -0,0 +1,368 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+)
+
+// Number of transfers sent from each chain when relaying redundantly.
+const redundancyBurstSize = 5
+
+// TestRelayerRedundancy runs one relayer from each factory on the same channel between two chains,
+// as production channels are often served by several relayers at once,
+// which then race to relay the same packets.
+//
+// The first relayer links the path, and the others relay over its clients and connection,
+// so every relayer other than the first must have the relayer.SharedPath capability; otherwise the test is skipped.
+//
+// Given 2 chains, Chain A and Chain B, this test asserts:
+// 1. Bursts of transfers from A -> B and B -> A are each received and acknowledged exactly once.
+// 2. The recipients are credited exactly once for each transfer.
+// 3. The relayers together report at least as many packets relayed as were received, in their metrics.
+// It also logs the fees spent by each relayer on each chain, from the balances of the relayer wallets.
+func TestRelayerRedundancy(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rfs []interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	if len(rfs) < 2 {
+		rep.TrackSkip(t, "skipping redundant relaying with fewer than 2 relayers")
+	}
+	for _, rf := range rfs[1:] {
+		if missing := missingCapabilities(rf, relayer.SharedPath); len(missing) > 0 {
+			rep.TrackSkip(t, "skipping as relayer %s cannot relay on an existing path", rf.Name())
+		}
+	}
+
+	client, network := interchaintest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	c0, c1 := chains[0], chains[1]
+
+	ic := interchaintest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1)
+
+	relayers := make([]ibc.Relayer, len(rfs))
+	pathNames := make([]string, len(rfs))
+	for i, rf := range rfs {
+		r := rf.Build(t, client, network)
+		_, shared := r.(relayer.SharedPathRelayer)
+		req.Truef(shared || i == 0, "relayer %s has the SharedPath capability but does not implement relayer.SharedPathRelayer", rf.Name())
+		relayers[i] = r
+		pathNames[i] = fmt.Sprintf("redundant-%d", i)
+
+		ic.AddRelayer(r, fmt.Sprintf("r%d", i)).
+			AddLink(interchaintest.InterchainLink{
+				Chain1:  c0,
+				Chain2:  c1,
+				Relayer: r,
+				Path:    pathNames[i],
+			})
+	}
+
+	eRep := rep.RelayerExecReporter(t)
+
+	// Paths are created below, as only the first relayer links its path.
+	req.NoError(ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+
+		SkipPathCreation: true,
+	}))
+	defer ic.Close()
+
+	c0ID, c1ID := c0.Config().ChainID, c1.Config().ChainID
+	for i, r := range relayers {
+		req.NoError(r.GeneratePath(ctx, eRep, c0ID, c1ID, pathNames[i]))
+	}
+	req.NoError(relayers[0].LinkPath(ctx, eRep, pathNames[0], ibc.DefaultChannelOpts(), ibc.DefaultClientOpts()))
+
+	channels, err := relayers[0].GetChannels(ctx, eRep, c0ID)
+	req.NoError(err)
+	req.Len(channels, 1)
+	channel := channels[0]
+
+	for i, r := range relayers[1:] {
+		req.NoError(relayer.SharePath(ctx, eRep, relayers[0], c0ID, channel.ChannelID, r, pathNames[i+1]))
+	}
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFaucetFund, c0, c1)
+	// Each transfer of a burst is sent by its own user, so that the transfers can be sent concurrently.
+	c0Senders := make([]ibc.Wallet, redundancyBurstSize)
+	c1Senders := make([]ibc.Wallet, redundancyBurstSize)
+	for i := 0; i < redundancyBurstSize; i++ {
+		senders := interchaintest.GetAndFundTestUsers(t, ctx, fmt.Sprintf("%s-%d", t.Name(), i), userFaucetFund, c0, c1)
+		c0Senders[i], c1Senders[i] = senders[0], senders[1]
+	}
+	req.NoError(testutil.WaitForBlocks(ctx, 2, c0, c1))
+
+	balancesBefore, err := relayerBalances(ctx, relayers, c0, c1)
+	req.NoError(err)
+
+	c0Start, err := c0.Height(ctx)
+	req.NoError(err)
+	c1Start, err := c1.Height(ctx)
+	req.NoError(err)
+
+	for i, r := range relayers {
+		req.NoError(r.StartRelayer(ctx, eRep, pathNames[i]))
+	}
+	defer func() {
+		for _, r := range relayers {
+			if err := r.StopRelayer(ctx, eRep); err != nil {
+				t.Logf("error stopping relayer: %v", err)
+			}
+		}
+	}()
+
+	c0Denom, c1Denom := c0.Config().Denom, c1.Config().Denom
+	c0Recv := transferRecipient(users[0], users[1], c1.Config())
+	c1Recv := transferRecipient(users[1], users[0], c0.Config())
+
+	var (
+		eg           errgroup.Group
+		c0Txs, c1Txs []ibc.Tx
+	)
+	eg.Go(func() (err error) {
+		c0Txs, err = sendTransferBurst(ctx, c0, channel.ChannelID, c0Senders, c0Recv, c0Denom)
+		return err
+	})
+	eg.Go(func() (err error) {
+		c1Txs, err = sendTransferBurst(ctx, c1, channel.Counterparty.ChannelID, c1Senders, c1Recv, c1Denom)
+		return err
+	})
+	req.NoError(eg.Wait())
+
+	for _, tx := range c0Txs {
+		_, err := testutil.PollForAck(ctx, c0, tx.Height, tx.Height+pollHeightMax, tx.Packet)
+		req.NoError(err, "failed to get acknowledgement on %s", c0ID)
+	}
+	for _, tx := range c1Txs {
+		_, err := testutil.PollForAck(ctx, c1, tx.Height, tx.Height+pollHeightMax, tx.Packet)
+		req.NoError(err, "failed to get acknowledgement on %s", c1ID)
+	}
+
+	// Give the other relayers time to attempt redundant relays before counting.
+	req.NoError(testutil.WaitForBlocks(ctx, 5, c0, c1))
+
+	t.Run("exactly once", func(t *testing.T) {
+		rep.TrackTest(t)
+		req := require.New(rep.TestifyT(t))
+
+		requireExactlyOnce(ctx, req, c0, c1, c0Start, c1Start, channel.ChannelID, channel.Counterparty.ChannelID, c0Txs)
+		requireExactlyOnce(ctx, req, c1, c0, c1Start, c0Start, channel.Counterparty.ChannelID, channel.ChannelID, c1Txs)
+
+		c1IBCDenom := transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom(channel.Counterparty.PortID, channel.Counterparty.ChannelID, c0Denom)).IBCDenom()
+		bal, err := c1.GetBalance(ctx, c0Recv, c1IBCDenom)
+		req.NoError(err)
+		req.Equal(redundancyBurstSize*testCoinAmount, bal)
+
+		c0IBCDenom := transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom(channel.PortID, channel.ChannelID, c1Denom)).IBCDenom()
+		bal, err = c0.GetBalance(ctx, c1Recv, c0IBCDenom)
+		req.NoError(err)
+		req.Equal(redundancyBurstSize*testCoinAmount, bal)
+	})
+
+	t.Run("fee spend", func(t *testing.T) {
+		rep.TrackTest(t)
+		req := require.New(rep.TestifyT(t))
+
+		balancesAfter, err := relayerBalances(ctx, relayers, c0, c1)
+		req.NoError(err)
+		for i, rf := range rfs {
+			for _, c := range []ibc.Chain{c0, c1} {
+				cfg := c.Config()
+				spent := balancesBefore[i][cfg.ChainID] - balancesAfter[i][cfg.ChainID]
+				t.Logf("relayer %d (%s) spent %d%s on %s", i, rf.Name(), spent, cfg.Denom, cfg.ChainID)
+			}
+		}
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		rep.TrackTest(t)
+		req := require.New(rep.TestifyT(t))
+
+		// Every packet was received once, so the relayers together must report at least that many,
+		// unless some relayer does not report the packets it relayed.
+		relayed := make(map[string]float64, 2)
+		allReported := true
+		for i, r := range relayers {
+			mr, ok := r.(metricsRelayer)
+			if !ok {
+				allReported = false
+				continue
+			}
+			m, err := mr.Metrics(ctx)
+			req.NoError(err, "failed to scrape metrics of relayer %d (%s)", i, rfs[i].Name())
+
+			for _, c := range []ibc.Chain{c0, c1} {
+				cfg := c.Config()
+				packets, err := m.PacketsRelayed(cfg.ChainID)
+				if err != nil {
+					allReported = false
+					t.Logf("relayer %d (%s): %v", i, rfs[i].Name(), err)
+					continue
+				}
+				relayed[cfg.ChainID] += packets
+				t.Logf("relayer %d (%s) relayed %.0f packets to %s", i, rfs[i].Name(), packets, cfg.ChainID)
+			}
+			if txErrors, err := m.TxErrors(); err == nil {
+				t.Logf("relayer %d (%s) reported %.0f tx errors", i, rfs[i].Name(), txErrors)
+			}
+		}
+		if !allReported {
+			rep.TrackSkip(t, "skipping as not every relayer reports the packets it relayed")
+		}
+		req.GreaterOrEqual(relayed[c1ID], float64(redundancyBurstSize), "relayers reported fewer packets relayed to %s than were received", c1ID)
+		req.GreaterOrEqual(relayed[c0ID], float64(redundancyBurstSize), "relayers reported fewer packets relayed to %s than were received", c0ID)
+	})
+}
+
+// metricsRelayer is implemented by relayers that expose their metrics, such as relayer.DockerRelayer.
+type metricsRelayer interface {
+	Metrics(ctx context.Context) (relayer.RelayerMetrics, error)
+}
+
+// sendTransferBurst concurrently sends a transfer of testCoinAmount on the channel from each of the senders.
+func sendTransferBurst(ctx context.Context, c ibc.Chain, channelID string, senders []ibc.Wallet, recipient, denom string) ([]ibc.Tx, error) {
+	txs := make([]ibc.Tx, len(senders))
+	var eg errgroup.Group
+	for i, sender := range senders {
+		i, sender := i, sender
+		eg.Go(func() error {
+			tx, err := c.SendIBCTransfer(ctx, channelID, sender.KeyName(), ibc.WalletAmount{
+				Address: recipient,
+				Denom:   denom,
+				Amount:  testCoinAmount,
+			}, ibc.TransferOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to send transfer %d from %s: %w", i, c.Config().ChainID, err)
+			}
+			if err := tx.Validate(); err != nil {
+				return err
+			}
+			txs[i] = tx
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
+
+// receivingChain is implemented by chains that report the packets received in a block.
+type receivingChain interface {
+	ReceivedPackets(ctx context.Context, height uint64) ([]ibc.Packet, error)
+}
+
+// acknowledgingChain is implemented by chains that report the packets whose acknowledgement was processed in a block,
+// from the events of successful transactions only.
+type acknowledgingChain interface {
+	AcknowledgedPackets(ctx context.Context, height uint64) ([]ibc.Packet, error)
+}
+
+// requireExactlyOnce asserts that each packet sent by the txs on src was acknowledged exactly once on src,
+// and, if dst reports received packets, received exactly once on dst.
+//
+// Acknowledgements are counted from the acknowledged packets of src if it reports them,
+// as its Acknowledgements may include the failed or no-op acknowledgements of the relayers that lost the race.
+func requireExactlyOnce(ctx context.Context, req *require.Assertions, src, dst ibc.Chain, srcStart, dstStart uint64, srcChannelID, dstChannelID string, txs []ibc.Tx) {
+	acknowledged := func(ctx context.Context, h uint64) ([]ibc.Packet, error) {
+		acks, err := src.Acknowledgements(ctx, h)
+		packets := make([]ibc.Packet, len(acks))
+		for i, ack := range acks {
+			packets[i] = ack.Packet
+		}
+		return packets, err
+	}
+	if ac, ok := src.(acknowledgingChain); ok {
+		acknowledged = ac.AcknowledgedPackets
+	}
+	acks, err := countPackets(ctx, src, srcStart, acknowledged,
+		func(p ibc.Packet) bool { return p.SourceChannel == srcChannelID })
+	req.NoError(err)
+
+	var recvs map[uint64]int
+	if rc, ok := dst.(receivingChain); ok {
+		recvs, err = countPackets(ctx, dst, dstStart, rc.ReceivedPackets,
+			func(p ibc.Packet) bool { return p.DestChannel == dstChannelID })
+		req.NoError(err)
+	}
+
+	srcID := src.Config().ChainID
+	for _, tx := range txs {
+		seq := tx.Packet.Sequence
+		req.Equal(1, acks[seq], "packet %d from %s acknowledged %d times", seq, srcID, acks[seq])
+		if recvs != nil {
+			req.Equal(1, recvs[seq], "packet %d from %s received %d times", seq, srcID, recvs[seq])
+		}
+	}
+	req.Len(acks, len(txs), "unexpected acknowledgements on %s: %v", srcID, acks)
+}
+
+// countPackets counts by sequence the packets matching the filter, as returned by query for each block of the chain
+// from startHeight to the current height.
+func countPackets(
+	ctx context.Context,
+	c ibc.Chain,
+	startHeight uint64,
+	query func(context.Context, uint64) ([]ibc.Packet, error),
+	match func(ibc.Packet) bool,
+) (map[uint64]int, error) {
+	end, err := c.Height(ctx)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[uint64]int)
+	for h := startHeight; h <= end; h++ {
+		packets, err := query(ctx, h)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query packets of %s at height %d: %w", c.Config().ChainID, h, err)
+		}
+		for _, p := range packets {
+			if match(p) {
+				counts[p.Sequence]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// relayerBalances returns the balance of each relayer's wallet on each chain, in the chain's denom, keyed by chain ID.
+func relayerBalances(ctx context.Context, relayers []ibc.Relayer, chains ...ibc.Chain) ([]map[string]int64, error) {
+	balances := make([]map[string]int64, len(relayers))
+	for i, r := range relayers {
+		balances[i] = make(map[string]int64, len(chains))
+		for _, c := range chains {
+			cfg := c.Config()
+			w, ok := r.GetWallet(cfg.ChainID)
+			if !ok {
+				return nil, fmt.Errorf("relayer %d has no wallet on %s", i, cfg.ChainID)
+			}
+			bal, err := c.GetBalance(ctx, w.FormattedAddress(), cfg.Denom)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get balance of relayer %d on %s: %w", i, cfg.ChainID, err)
+			}
+			balances[i][cfg.ChainID] = bal
+		}
+	}
+	return balances, nil
+}
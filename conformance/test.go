@@ -125,6 +125,16 @@ func missingCapabilities(rf interchaintest.RelayerFactory, reqCaps ...relayer.Ca
 	return missing
 }
 
+// transferRecipient returns the address receiving the test transfers sent by user to the counterparty chain.
+// Between Cosmos chains, it is the address of user's own key on the counterparty chain.
+// Other chains derive addresses differently, so transfers go to the counterparty user instead.
+func transferRecipient(user, counterpartyUser ibc.Wallet, counterpartyCfg ibc.ChainConfig) string {
+	if w, ok := user.(*cosmos.CosmosWallet); ok && counterpartyCfg.Type == "cosmos" {
+		return w.FormattedAddressWithPrefix(counterpartyCfg.Bech32Prefix)
+	}
+	return counterpartyUser.FormattedAddress()
+}
+
 func sendIBCTransfersFromBothChainsWithTimeout(
 	ctx context.Context,
 	t *testing.T,
@@ -143,12 +153,12 @@ func sendIBCTransfersFromBothChainsWithTimeout(
 	// will send ibc transfers from user wallet on both chains to their own respective wallet on the other chain
 
 	testCoinSrcToDst := ibc.WalletAmount{
-		Address: srcUser.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(dstChainCfg.Bech32Prefix),
+		Address: transferRecipient(srcUser, dstUser, dstChainCfg),
 		Denom:   srcChainCfg.Denom,
 		Amount:  testCoinAmount,
 	}
 	testCoinDstToSrc := ibc.WalletAmount{
-		Address: dstUser.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(srcChainCfg.Bech32Prefix),
+		Address: transferRecipient(dstUser, srcUser, srcChainCfg),
 		Denom:   dstChainCfg.Denom,
 		Amount:  testCoinAmount,
 	}
@@ -262,8 +272,29 @@ func Test(t *testing.T, ctx context.Context, cfs []interchaintest.ChainFactory,
 
 								TestRelayerFlushing(t, ctx, cf, rf, rep)
 							})
+
+							t.Run("ordered channels", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerOrderedChannels(t, ctx, cf, rf, rep)
+							})
+
+							t.Run("channel filter", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerChannelFilter(t, ctx, cf, rf, rep)
+							})
 						})
 					}
+
+					t.Run("redundant relayers", func(t *testing.T) {
+						rep.TrackTest(t)
+						rep.TrackParallel(t)
+
+						TestRelayerRedundancy(t, ctx, cf, rfs, rep)
+					})
 				})
 			}
 		})
@@ -400,6 +431,7 @@ func testPacketRelaySuccess(
 	srcDenom := srcChainCfg.Denom
 
 	dstChainCfg := dstChain.Config()
+	dstUser := testCase.Users[1]
 
 	// [BEGIN] assert on source to destination transfer
 	for i, srcTx := range testCase.TxCache.Src {
@@ -416,10 +448,10 @@ func testPacketRelaySuccess(
 		srcDenomTrace := transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom(channels[i].Counterparty.PortID, channels[i].Counterparty.ChannelID, srcDenom))
 		dstIbcDenom := srcDenomTrace.IBCDenom()
 
-		srcFinalBalance, err := srcChain.GetBalance(ctx, srcUser.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(srcChainCfg.Bech32Prefix), srcDenom)
+		srcFinalBalance, err := srcChain.GetBalance(ctx, srcUser.FormattedAddress(), srcDenom)
 		req.NoError(err, "failed to get balance from source chain")
 
-		dstFinalBalance, err := dstChain.GetBalance(ctx, srcUser.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(dstChainCfg.Bech32Prefix), dstIbcDenom)
+		dstFinalBalance, err := dstChain.GetBalance(ctx, transferRecipient(srcUser, dstUser, dstChainCfg), dstIbcDenom)
 		req.NoError(err, "failed to get balance from dest chain")
 
 		totalFees := srcChain.GetGasFeesInNativeDenom(srcTx.GasSpent)
@@ -434,7 +466,6 @@ func testPacketRelaySuccess(
 	// [BEGIN] assert on destination to source transfer
 	for i, dstTx := range testCase.TxCache.Dst {
 		t.Logf("Asserting %s to %s transfer", dstChainCfg.ChainID, srcChainCfg.ChainID)
-		dstUser := testCase.Users[1]
 		dstDenom := dstChainCfg.Denom
 		// Assuming these values since the ibc transfers were sent in PreRelayerStart, so balances may have already changed by now
 		srcInitialBalance := int64(0)
@@ -448,10 +479,10 @@ func testPacketRelaySuccess(
 		dstDenomTrace := transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom(channels[i].PortID, channels[i].ChannelID, dstDenom))
 		srcIbcDenom := dstDenomTrace.IBCDenom()
 
-		srcFinalBalance, err := srcChain.GetBalance(ctx, dstUser.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(srcChainCfg.Bech32Prefix), srcIbcDenom)
+		srcFinalBalance, err := srcChain.GetBalance(ctx, transferRecipient(dstUser, srcUser, srcChainCfg), srcIbcDenom)
 		req.NoError(err, "failed to get balance from source chain")
 
-		dstFinalBalance, err := dstChain.GetBalance(ctx, dstUser.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(dstChainCfg.Bech32Prefix), dstDenom)
+		dstFinalBalance, err := dstChain.GetBalance(ctx, dstUser.FormattedAddress(), dstDenom)
 		req.NoError(err, "failed to get balance from dest chain")
 
 		totalFees := dstChain.GetGasFeesInNativeDenom(dstTx.GasSpent)
@@ -501,10 +532,10 @@ func testPacketRelayFail(
 		srcDenomTrace := transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom(channels[i].Counterparty.PortID, channels[i].Counterparty.ChannelID, srcDenom))
 		dstIbcDenom := srcDenomTrace.IBCDenom()
 
-		srcFinalBalance, err := srcChain.GetBalance(ctx, srcUser.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(srcChainCfg.Bech32Prefix), srcDenom)
+		srcFinalBalance, err := srcChain.GetBalance(ctx, srcUser.FormattedAddress(), srcDenom)
 		req.NoError(err, "failed to get balance from source chain")
 
-		dstFinalBalance, err := dstChain.GetBalance(ctx, srcUser.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(dstChainCfg.Bech32Prefix), dstIbcDenom)
+		dstFinalBalance, err := dstChain.GetBalance(ctx, transferRecipient(srcUser, dstUser, dstChainCfg), dstIbcDenom)
 		req.NoError(err, "failed to get balance from destination chain")
 
 		totalFees := srcChain.GetGasFeesInNativeDenom(srcTx.GasSpent)
@@ -528,10 +559,10 @@ func testPacketRelayFail(
 		dstDenomTrace := transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom(channels[i].PortID, channels[i].ChannelID, dstDenom))
 		srcIbcDenom := dstDenomTrace.IBCDenom()
 
-		srcFinalBalance, err := srcChain.GetBalance(ctx, dstUser.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(srcChainCfg.Bech32Prefix), srcIbcDenom)
+		srcFinalBalance, err := srcChain.GetBalance(ctx, transferRecipient(dstUser, srcUser, srcChainCfg), srcIbcDenom)
 		req.NoError(err, "failed to get balance from source chain")
 
-		dstFinalBalance, err := dstChain.GetBalance(ctx, dstUser.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(dstChainCfg.Bech32Prefix), dstDenom)
+		dstFinalBalance, err := dstChain.GetBalance(ctx, dstUser.FormattedAddress(), dstDenom)
 		req.NoError(err, "failed to get balance from destination chain")
 
 		totalFees := dstChain.GetGasFeesInNativeDenom(dstTx.GasSpent)
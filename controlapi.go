@@ -0,0 +1,473 @@
+package interchaintest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"go.uber.org/zap"
+)
+
+// ControlServer is an HTTP server exposing the control API of a built Interchain,
+// so that scripts and test harnesses outside the Go test process can drive the interchain.
+// See Interchain.ControlHandler for the available endpoints.
+type ControlServer struct {
+	srv *http.Server
+	ln  net.Listener
+}
+
+// StartControlServer starts serving the control API of the built interchain on addr, e.g. "localhost:8080".
+// If the port of addr is 0, a free port is chosen; the actual address is reported by the Addr method.
+// The server runs in the background until Close is called.
+func (ic *Interchain) StartControlServer(rep *testreporter.RelayerExecReporter, addr string) (*ControlServer, error) {
+	if !ic.built {
+		return nil, errors.New("Interchain.StartControlServer called before Build")
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	s := &ControlServer{
+		srv: &http.Server{
+			Handler:           ic.ControlHandler(rep),
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		ln: ln,
+	}
+	go func() {
+		if err := s.srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			ic.log.Warn("Control server stopped", zap.Error(err))
+		}
+	}()
+	return s, nil
+}
+
+// Addr returns the host and port the server is listening on.
+func (s *ControlServer) Addr() string {
+	return s.ln.Addr().String()
+}
+
+// Close stops the server, waiting up to the context deadline for in-flight requests to complete.
+func (s *ControlServer) Close(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
+}
+
+// ControlHandler returns an HTTP handler for the control API of the interchain.
+// Request and response bodies are JSON; errors are reported as {"error": "..."} with a non-2xx status.
+//
+//	GET  /chains                           List chains and their nodes.
+//	GET  /chains/{chain_id}/height         Current height.
+//	GET  /chains/{chain_id}/balance        Balance of ?address= in ?denom=.
+//	POST /chains/{chain_id}/exec           Run {"cmd": [...], "env": [...]} on the chain.
+//	POST /chains/{chain_id}/send           Send {"key_name", "address", "denom", "amount"}.
+//	POST /chains/{chain_id}/ibc-transfer   Transfer {"key_name", "channel_id", "address", "denom", "amount", "memo", "timeout_height", "timeout_nanoseconds"}.
+//	GET  /relayers                         List relayers and their paths.
+//	GET  /relayers/{name}/wallets          Relayer wallets on each of its chains.
+//	POST /relayers/{name}/flush            Flush {"path", "channel_id"}.
+//	POST /relayers/{name}/start            Start relaying {"paths": [...]}, or every path of the relayer if empty.
+//	POST /relayers/{name}/stop             Stop relaying.
+func (ic *Interchain) ControlHandler(rep *testreporter.RelayerExecReporter) http.Handler {
+	h := controlHandler{ic: ic, rep: rep}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/chains", h.listChains)
+	mux.HandleFunc("/chains/", h.chain)
+	mux.HandleFunc("/relayers", h.listRelayers)
+	mux.HandleFunc("/relayers/", h.relayer)
+	return mux
+}
+
+type controlHandler struct {
+	ic  *Interchain
+	rep *testreporter.RelayerExecReporter
+}
+
+type controlChain struct {
+	Name        string        `json:"name"`
+	ChainID     string        `json:"chain_id"`
+	Type        string        `json:"type"`
+	Denom       string        `json:"denom"`
+	RPCAddr     string        `json:"rpc_addr"`
+	GRPCAddr    string        `json:"grpc_addr"`
+	HostRPCAddr string        `json:"host_rpc_addr"`
+	HostGRPC    string        `json:"host_grpc_addr"`
+	Nodes       []controlNode `json:"nodes"`
+}
+
+type controlNode struct {
+	Name        string `json:"name"`
+	Validator   bool   `json:"validator"`
+	ContainerID string `json:"container_id"`
+}
+
+type controlRelayer struct {
+	Name  string   `json:"name"`
+	Paths []string `json:"paths"`
+}
+
+type controlWallet struct {
+	ChainID  string `json:"chain_id"`
+	KeyName  string `json:"key_name"`
+	Address  string `json:"address"`
+	Mnemonic string `json:"mnemonic"`
+}
+
+type controlExecRequest struct {
+	Cmd []string `json:"cmd"`
+	Env []string `json:"env"`
+}
+
+type controlExecResponse struct {
+	Stdout string `json:"stdout"`
+	Stderr string `json:"stderr"`
+}
+
+type controlSendRequest struct {
+	KeyName string `json:"key_name"`
+	Address string `json:"address"`
+	Denom   string `json:"denom"`
+	Amount  int64  `json:"amount"`
+}
+
+type controlTransferRequest struct {
+	controlSendRequest
+	ChannelID string `json:"channel_id"`
+	Memo      string `json:"memo"`
+
+	// Optional timeouts; the chain's defaults are used if both are zero.
+	TimeoutHeight      uint64 `json:"timeout_height"`
+	TimeoutNanoseconds uint64 `json:"timeout_nanoseconds"`
+}
+
+type controlTx struct {
+	Height   uint64 `json:"height"`
+	TxHash   string `json:"tx_hash"`
+	GasSpent int64  `json:"gas_spent"`
+
+	Sequence    uint64 `json:"sequence"`
+	SourcePort  string `json:"source_port"`
+	SourceChan  string `json:"source_channel"`
+	DestPort    string `json:"dest_port"`
+	DestChannel string `json:"dest_channel"`
+}
+
+type controlFlushRequest struct {
+	Path      string `json:"path"`
+	ChannelID string `json:"channel_id"`
+}
+
+type controlStartRequest struct {
+	Paths []string `json:"paths"`
+}
+
+func (h controlHandler) listChains(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	chains := h.ic.Chains()
+	out := make([]controlChain, len(chains))
+	for i, c := range chains {
+		cfg := c.Config()
+		out[i] = controlChain{
+			Name:        cfg.Name,
+			ChainID:     cfg.ChainID,
+			Type:        cfg.Type,
+			Denom:       cfg.Denom,
+			RPCAddr:     c.GetRPCAddress(),
+			GRPCAddr:    c.GetGRPCAddress(),
+			HostRPCAddr: c.GetHostRPCAddress(),
+			HostGRPC:    c.GetHostGRPCAddress(),
+			Nodes:       chainNodes(c),
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// chainNodes describes the nodes of the chain.
+// Cosmos chains report each node; other chains report only their containers.
+func chainNodes(c ibc.Chain) []controlNode {
+	nodes := []controlNode{}
+	switch c := c.(type) {
+	case *cosmos.CosmosChain:
+		for _, n := range c.Nodes() {
+			nodes = append(nodes, controlNode{Name: n.Name(), Validator: n.Validator, ContainerID: n.ContainerID()})
+		}
+	case ContainerOwner:
+		for _, id := range c.ContainerIDs() {
+			nodes = append(nodes, controlNode{ContainerID: id})
+		}
+	}
+	return nodes
+}
+
+func (h controlHandler) chain(w http.ResponseWriter, r *http.Request) {
+	chainID, action, ok := splitControlPath(r.URL.Path, "/chains/")
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown path %s", r.URL.Path))
+		return
+	}
+
+	var c ibc.Chain
+	for chain, id := range h.ic.chains {
+		if id == chainID {
+			c = chain
+			break
+		}
+	}
+	if c == nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown chain %s", chainID))
+		return
+	}
+
+	ctx := r.Context()
+	switch action {
+	case "height":
+		if !requireMethod(w, r, http.MethodGet) {
+			return
+		}
+		height, err := c.Height(ctx)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]uint64{"height": height})
+
+	case "balance":
+		if !requireMethod(w, r, http.MethodGet) {
+			return
+		}
+		q := r.URL.Query()
+		address, denom := q.Get("address"), q.Get("denom")
+		if address == "" {
+			writeError(w, http.StatusBadRequest, errors.New("address query parameter is required"))
+			return
+		}
+		if denom == "" {
+			denom = c.Config().Denom
+		}
+		balance, err := c.GetBalance(ctx, address, denom)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"denom": denom, "amount": balance})
+
+	case "exec":
+		var req controlExecRequest
+		if !readRequest(w, r, &req) {
+			return
+		}
+		if len(req.Cmd) == 0 {
+			writeError(w, http.StatusBadRequest, errors.New("cmd is required"))
+			return
+		}
+		stdout, stderr, err := c.Exec(ctx, req.Cmd, req.Env)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{
+				"error":  err.Error(),
+				"stdout": string(stdout),
+				"stderr": string(stderr),
+			})
+			return
+		}
+		writeJSON(w, http.StatusOK, controlExecResponse{Stdout: string(stdout), Stderr: string(stderr)})
+
+	case "send":
+		var req controlSendRequest
+		if !readRequest(w, r, &req) {
+			return
+		}
+		if err := c.SendFunds(ctx, req.KeyName, req.walletAmount(c)); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, struct{}{})
+
+	case "ibc-transfer":
+		var req controlTransferRequest
+		if !readRequest(w, r, &req) {
+			return
+		}
+		opts := ibc.TransferOptions{Memo: req.Memo}
+		if req.TimeoutHeight != 0 || req.TimeoutNanoseconds != 0 {
+			opts.Timeout = &ibc.IBCTimeout{Height: req.TimeoutHeight, NanoSeconds: req.TimeoutNanoseconds}
+		}
+		tx, err := c.SendIBCTransfer(ctx, req.ChannelID, req.KeyName, req.walletAmount(c), opts)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, controlTx{
+			Height:   tx.Height,
+			TxHash:   tx.TxHash,
+			GasSpent: tx.GasSpent,
+
+			Sequence:    tx.Packet.Sequence,
+			SourcePort:  tx.Packet.SourcePort,
+			SourceChan:  tx.Packet.SourceChannel,
+			DestPort:    tx.Packet.DestPort,
+			DestChannel: tx.Packet.DestChannel,
+		})
+
+	default:
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown chain action %q", action))
+	}
+}
+
+// walletAmount returns the requested amount, in the chain's native denom if no denom was given.
+func (req controlSendRequest) walletAmount(c ibc.Chain) ibc.WalletAmount {
+	denom := req.Denom
+	if denom == "" {
+		denom = c.Config().Denom
+	}
+	return ibc.WalletAmount{Address: req.Address, Denom: denom, Amount: req.Amount}
+}
+
+func (h controlHandler) listRelayers(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	out := []controlRelayer{}
+	for name, rly := range h.ic.Relayers() {
+		out = append(out, controlRelayer{Name: name, Paths: h.ic.RelayerPaths(rly)})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h controlHandler) relayer(w http.ResponseWriter, r *http.Request) {
+	name, action, ok := splitControlPath(r.URL.Path, "/relayers/")
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown path %s", r.URL.Path))
+		return
+	}
+
+	rly, ok := h.ic.Relayers()[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown relayer %s", name))
+		return
+	}
+
+	ctx := r.Context()
+	switch action {
+	case "wallets":
+		if !requireMethod(w, r, http.MethodGet) {
+			return
+		}
+		out := []controlWallet{}
+		for _, c := range h.ic.relayerChains()[rly] {
+			chainID := c.Config().ChainID
+			wallet, ok := rly.GetWallet(chainID)
+			if !ok {
+				continue
+			}
+			out = append(out, controlWallet{
+				ChainID:  chainID,
+				KeyName:  wallet.KeyName(),
+				Address:  wallet.FormattedAddress(),
+				Mnemonic: wallet.Mnemonic(),
+			})
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i].ChainID < out[j].ChainID })
+		writeJSON(w, http.StatusOK, out)
+
+	case "flush":
+		var req controlFlushRequest
+		if !readRequest(w, r, &req) {
+			return
+		}
+		if err := rly.Flush(ctx, h.rep, req.Path, req.ChannelID); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, struct{}{})
+
+	case "start":
+		var req controlStartRequest
+		if !readRequest(w, r, &req) {
+			return
+		}
+		paths := req.Paths
+		if len(paths) == 0 {
+			paths = h.ic.RelayerPaths(rly)
+		}
+		// The relayer keeps running after the request completes,
+		// so it must not be bound to the request context.
+		if err := rly.StartRelayer(context.Background(), h.rep, paths...); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, struct{}{})
+
+	case "stop":
+		if !requireMethod(w, r, http.MethodPost) {
+			return
+		}
+		if err := rly.StopRelayer(ctx, h.rep); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, struct{}{})
+
+	default:
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown relayer action %q", action))
+	}
+}
+
+// splitControlPath splits a path of the form prefix + "{name}/{action}".
+func splitControlPath(p, prefix string) (name, action string, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(p, prefix), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// requireMethod reports whether the request uses the given method,
+// writing a Method Not Allowed response if not.
+func requireMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+	w.Header().Set("Allow", method)
+	writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+	return false
+}
+
+// readRequest decodes the JSON body of a POST request into v,
+// writing an error response and returning false on failure.
+// An empty body leaves v unchanged.
+func readRequest(w http.ResponseWriter, r *http.Request, v any) bool {
+	if !requireMethod(w, r, http.MethodPost) {
+		return false
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,172 @@
+package interchaintest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// controlTestChain implements the parts of ibc.Chain used by the control API.
+type controlTestChain struct {
+	ibc.Chain
+
+	cfg  ibc.ChainConfig
+	sent []ibc.WalletAmount
+}
+
+func (c *controlTestChain) Config() ibc.ChainConfig    { return c.cfg }
+func (c *controlTestChain) GetRPCAddress() string      { return "http://" + c.cfg.ChainID + ":26657" }
+func (c *controlTestChain) GetGRPCAddress() string     { return c.cfg.ChainID + ":9090" }
+func (c *controlTestChain) GetHostRPCAddress() string  { return "http://127.0.0.1:26657" }
+func (c *controlTestChain) GetHostGRPCAddress() string { return "127.0.0.1:9090" }
+
+func (c *controlTestChain) Height(context.Context) (uint64, error) { return 42, nil }
+
+func (c *controlTestChain) GetBalance(_ context.Context, address, denom string) (int64, error) {
+	if address != "addr1" {
+		return 0, errors.New("unknown address")
+	}
+	return 100, nil
+}
+
+func (c *controlTestChain) Exec(_ context.Context, cmd []string, _ []string) ([]byte, []byte, error) {
+	return []byte(strings.Join(cmd, " ")), nil, nil
+}
+
+func (c *controlTestChain) SendFunds(_ context.Context, _ string, amount ibc.WalletAmount) error {
+	c.sent = append(c.sent, amount)
+	return nil
+}
+
+func (c *controlTestChain) SendIBCTransfer(_ context.Context, channelID, _ string, amount ibc.WalletAmount, _ ibc.TransferOptions) (ibc.Tx, error) {
+	c.sent = append(c.sent, amount)
+	return ibc.Tx{Height: 7, TxHash: "ABC", Packet: ibc.Packet{Sequence: 1, SourceChannel: channelID}}, nil
+}
+
+// controlTestRelayer implements the parts of ibc.Relayer used by the control API.
+type controlTestRelayer struct {
+	ibc.Relayer
+
+	started []string
+	flushed []string
+	stopped bool
+}
+
+func (r *controlTestRelayer) StartRelayer(_ context.Context, _ ibc.RelayerExecReporter, paths ...string) error {
+	r.started = append(r.started, paths...)
+	return nil
+}
+
+func (r *controlTestRelayer) StopRelayer(context.Context, ibc.RelayerExecReporter) error {
+	r.stopped = true
+	return nil
+}
+
+func (r *controlTestRelayer) Flush(_ context.Context, _ ibc.RelayerExecReporter, path, channelID string) error {
+	r.flushed = append(r.flushed, path+"/"+channelID)
+	return nil
+}
+
+func (r *controlTestRelayer) GetWallet(chainID string) (ibc.Wallet, bool) {
+	return cosmos.NewWallet("relayer-"+chainID, []byte{1, 2, 3}, "mnemonic "+chainID, ibc.ChainConfig{Bech32Prefix: "cosmos"}), true
+}
+
+func TestControlHandler(t *testing.T) {
+	a := &controlTestChain{cfg: ibc.ChainConfig{Name: "a", ChainID: "a-1", Denom: "ua"}}
+	b := &controlTestChain{cfg: ibc.ChainConfig{Name: "b", ChainID: "b-1", Denom: "ub"}}
+	r := new(controlTestRelayer)
+
+	ic := NewInterchain().AddChain(a).AddChain(b).AddRelayer(r, "r").AddLink(InterchainLink{
+		Chain1: a, Chain2: b, Relayer: r, Path: "ab",
+	})
+
+	srv := httptest.NewServer(ic.ControlHandler(testreporter.NewNopReporter().RelayerExecReporter(t)))
+	defer srv.Close()
+
+	do := func(method, path, body string, wantStatus int, out any) {
+		t.Helper()
+		req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, wantStatus, res.StatusCode)
+		if out != nil {
+			require.NoError(t, json.NewDecoder(res.Body).Decode(out))
+		}
+	}
+
+	var chains []controlChain
+	do(http.MethodGet, "/chains", "", http.StatusOK, &chains)
+	require.Len(t, chains, 2)
+	require.Equal(t, "a-1", chains[0].ChainID)
+	require.Equal(t, "http://a-1:26657", chains[0].RPCAddr)
+	require.Equal(t, "b-1", chains[1].ChainID)
+
+	var height map[string]uint64
+	do(http.MethodGet, "/chains/a-1/height", "", http.StatusOK, &height)
+	require.Equal(t, uint64(42), height["height"])
+
+	var balance struct {
+		Denom  string
+		Amount int64
+	}
+	do(http.MethodGet, "/chains/a-1/balance?address=addr1", "", http.StatusOK, &balance)
+	require.Equal(t, "ua", balance.Denom)
+	require.Equal(t, int64(100), balance.Amount)
+	do(http.MethodGet, "/chains/a-1/balance?address=other", "", http.StatusInternalServerError, nil)
+	do(http.MethodGet, "/chains/a-1/balance", "", http.StatusBadRequest, nil)
+
+	var exec controlExecResponse
+	do(http.MethodPost, "/chains/a-1/exec", `{"cmd": ["echo", "hi"]}`, http.StatusOK, &exec)
+	require.Equal(t, "echo hi", exec.Stdout)
+	do(http.MethodPost, "/chains/a-1/exec", `{}`, http.StatusBadRequest, nil)
+	do(http.MethodGet, "/chains/a-1/exec", "", http.StatusMethodNotAllowed, nil)
+
+	do(http.MethodPost, "/chains/a-1/send", `{"key_name": "faucet", "address": "addr2", "amount": 5}`, http.StatusOK, nil)
+	var tx controlTx
+	do(http.MethodPost, "/chains/a-1/ibc-transfer", `{"key_name": "faucet", "channel_id": "channel-0", "address": "addr3", "denom": "x", "amount": 6}`, http.StatusOK, &tx)
+	require.Equal(t, "ABC", tx.TxHash)
+	require.Equal(t, "channel-0", tx.SourceChan)
+	require.Equal(t, []ibc.WalletAmount{
+		{Address: "addr2", Denom: "ua", Amount: 5},
+		{Address: "addr3", Denom: "x", Amount: 6},
+	}, a.sent)
+
+	do(http.MethodGet, "/chains/c-1/height", "", http.StatusNotFound, nil)
+	do(http.MethodGet, "/chains/a-1/bogus", "", http.StatusNotFound, nil)
+	do(http.MethodPost, "/chains/a-1/send", `{`, http.StatusBadRequest, nil)
+
+	var relayers []controlRelayer
+	do(http.MethodGet, "/relayers", "", http.StatusOK, &relayers)
+	require.Equal(t, []controlRelayer{{Name: "r", Paths: []string{"ab"}}}, relayers)
+
+	var wallets []controlWallet
+	do(http.MethodGet, "/relayers/r/wallets", "", http.StatusOK, &wallets)
+	require.Len(t, wallets, 2)
+	require.Equal(t, "a-1", wallets[0].ChainID)
+	require.Equal(t, "mnemonic a-1", wallets[0].Mnemonic)
+
+	do(http.MethodPost, "/relayers/r/start", "", http.StatusOK, nil)
+	require.Equal(t, []string{"ab"}, r.started)
+	do(http.MethodPost, "/relayers/r/flush", `{"path": "ab", "channel_id": "channel-0"}`, http.StatusOK, nil)
+	require.Equal(t, []string{"ab/channel-0"}, r.flushed)
+	do(http.MethodPost, "/relayers/r/stop", "", http.StatusOK, nil)
+	require.True(t, r.stopped)
+
+	do(http.MethodPost, "/relayers/x/stop", "", http.StatusNotFound, nil)
+}
+
+func TestStartControlServer_RequiresBuild(t *testing.T) {
+	_, err := NewInterchain().StartControlServer(testreporter.NewNopReporter().RelayerExecReporter(t), "127.0.0.1:0")
+	require.Error(t, err)
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/relayer"
 	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
-	"github.com/strangelove-ventures/interchaintest/v7/testutil"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 )
@@ -91,67 +90,36 @@ func CosmosChainUpgradeIBCTest(t *testing.T, chainName, initialVersion, upgradeC
 	})
 
 	const userFunds = int64(10_000_000_000)
-	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFunds, chain)
-	chainUser := users[0]
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFunds, chain, counterpartyChain)
+	chainUser, counterpartyUser := users[0], users[1]
 
 	// test IBC conformance before chain upgrade
 	conformance.TestChainPair(t, ctx, client, network, chain, counterpartyChain, rf, rep, r, path)
 
-	height, err := chain.Height(ctx)
-	require.NoError(t, err, "error fetching height before submit upgrade proposal")
-
-	haltHeight := height + haltHeightDelta
-
-	proposal := cosmos.SoftwareUpgradeProposal{
-		Deposit:     "500000000" + chain.Config().Denom, // greater than min deposit
-		Title:       "Chain Upgrade 1",
-		Name:        upgradeName,
-		Description: "First chain software upgrade",
-		Height:      haltHeight,
-	}
-
-	upgradeTx, err := chain.UpgradeProposal(ctx, chainUser.KeyName(), proposal)
-	require.NoError(t, err, "error submitting software upgrade proposal tx")
-
-	err = chain.VoteOnProposalAllValidators(ctx, upgradeTx.ProposalID, cosmos.ProposalVoteYes)
-	require.NoError(t, err, "failed to submit votes")
-
-	_, err = cosmos.PollForProposalStatus(ctx, chain, height, height+haltHeightDelta, upgradeTx.ProposalID, cosmos.ProposalStatusPassed)
-	require.NoError(t, err, "proposal status did not change to passed in expected number of blocks")
-
-	height, err = chain.Height(ctx)
-	require.NoError(t, err, "error fetching height before upgrade")
-
-	timeoutCtx, timeoutCtxCancel := context.WithTimeout(ctx, time.Second*45)
-	defer timeoutCtxCancel()
-
-	// this should timeout due to chain halt at upgrade height.
-	_ = testutil.WaitForBlocks(timeoutCtx, int(haltHeight-height)+1, chain)
-
-	height, err = chain.Height(ctx)
-	require.NoError(t, err, "error fetching height after chain should have halted")
-
-	// make sure that chain is halted
-	require.Equal(t, haltHeight, height, "height is not equal to halt height")
-
-	// bring down nodes to prepare for upgrade
-	err = chain.StopAllNodes(ctx)
-	require.NoError(t, err, "error stopping node(s)")
-
-	// upgrade version on all nodes
-	chain.UpgradeVersion(ctx, client, upgradeContainerRepo, upgradeVersion)
-
-	// start all nodes back up.
-	// validators reach consensus on first block after upgrade height
-	// and chain block production resumes.
-	err = chain.StartAllNodes(ctx)
-	require.NoError(t, err, "error starting upgraded node(s)")
-
-	timeoutCtx, timeoutCtxCancel = context.WithTimeout(ctx, time.Second*45)
-	defer timeoutCtxCancel()
+	channel, err := ibc.GetTransferChannel(ctx, r, rep.RelayerExecReporter(t), chain.Config().ChainID, counterpartyChain.Config().ChainID)
+	require.NoError(t, err)
 
-	err = testutil.WaitForBlocks(timeoutCtx, int(blocksAfterUpgrade), chain)
-	require.NoError(t, err, "chain did not produce blocks after upgrade")
+	// the conformance tests leave the relayer running, so it relays the IBC check transfers across the upgrade.
+	height, err := chain.PerformUpgrade(ctx, cosmos.UpgradePlan{
+		Name:    upgradeName,
+		KeyName: chainUser.KeyName(),
+		Deposit: "500000000" + chain.Config().Denom, // greater than min deposit
+		Title:   "Chain Upgrade 1",
+		Image:   ibc.DockerImage{Repository: upgradeContainerRepo, Version: upgradeVersion},
+		Timeout: 45 * time.Second,
+
+		HaltHeightDelta:    haltHeightDelta,
+		BlocksAfterUpgrade: blocksAfterUpgrade,
+
+		IBCCheck: &cosmos.UpgradeIBCCheck{
+			ChannelID: channel.ChannelID,
+			KeyName:   chainUser.KeyName(),
+			Recipient: counterpartyUser.FormattedAddress(),
+			Amount:    1_000_000,
+		},
+	})
+	require.NoError(t, err, "error performing upgrade")
+	t.Logf("chain upgraded, at height %d", height)
 
 	// test IBC conformance after chain upgrade on same path
 	conformance.TestChainPair(t, ctx, client, network, chain, counterpartyChain, rf, rep, r, path)
@@ -11,7 +11,6 @@ import (
 	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
 	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
-	"github.com/strangelove-ventures/interchaintest/v7/testutil"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 )
@@ -71,66 +70,19 @@ func CosmosChainUpgradeTest(t *testing.T, chainName, initialVersion, upgradeCont
 	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFunds, chain)
 	chainUser := users[0]
 
-	height, err := chain.Height(ctx)
-	require.NoError(t, err, "error fetching height before submit upgrade proposal")
+	height, err := chain.PerformUpgrade(ctx, cosmos.UpgradePlan{
+		Name:    upgradeName,
+		KeyName: chainUser.KeyName(),
+		Deposit: "500000000" + chain.Config().Denom, // greater than min deposit
+		Title:   "Chain Upgrade 1",
+		Image:   ibc.DockerImage{Repository: upgradeContainerRepo, Version: upgradeVersion},
+		Timeout: 45 * time.Second,
 
-	haltHeight := height + haltHeightDelta
-
-	proposal := cosmos.SoftwareUpgradeProposal{
-		Deposit:     "500000000" + chain.Config().Denom, // greater than min deposit
-		Title:       "Chain Upgrade 1",
-		Name:        upgradeName,
-		Description: "First chain software upgrade",
-		Height:      haltHeight,
-	}
-
-	upgradeTx, err := chain.UpgradeProposal(ctx, chainUser.KeyName(), proposal)
-	require.NoError(t, err, "error submitting software upgrade proposal tx")
-
-	err = chain.VoteOnProposalAllValidators(ctx, upgradeTx.ProposalID, cosmos.ProposalVoteYes)
-	require.NoError(t, err, "failed to submit votes")
-
-	_, err = cosmos.PollForProposalStatus(ctx, chain, height, height+haltHeightDelta, upgradeTx.ProposalID, cosmos.ProposalStatusPassed)
-	require.NoError(t, err, "proposal status did not change to passed in expected number of blocks")
-
-	timeoutCtx, timeoutCtxCancel := context.WithTimeout(ctx, time.Second*45)
-	defer timeoutCtxCancel()
-
-	height, err = chain.Height(ctx)
-	require.NoError(t, err, "error fetching height before upgrade")
-
-	// this should timeout due to chain halt at upgrade height.
-	_ = testutil.WaitForBlocks(timeoutCtx, int(haltHeight-height)+1, chain)
-
-	height, err = chain.Height(ctx)
-	require.NoError(t, err, "error fetching height after chain should have halted")
-
-	// make sure that chain is halted
-	require.Equal(t, haltHeight, height, "height is not equal to halt height")
-
-	// bring down nodes to prepare for upgrade
-	err = chain.StopAllNodes(ctx)
-	require.NoError(t, err, "error stopping node(s)")
-
-	// upgrade version on all nodes
-	chain.UpgradeVersion(ctx, client, upgradeContainerRepo, upgradeVersion)
-
-	// start all nodes back up.
-	// validators reach consensus on first block after upgrade height
-	// and chain block production resumes.
-	err = chain.StartAllNodes(ctx)
-	require.NoError(t, err, "error starting upgraded node(s)")
-
-	timeoutCtx, timeoutCtxCancel = context.WithTimeout(ctx, time.Second*45)
-	defer timeoutCtxCancel()
-
-	err = testutil.WaitForBlocks(timeoutCtx, int(blocksAfterUpgrade), chain)
-	require.NoError(t, err, "chain did not produce blocks after upgrade")
-
-	height, err = chain.Height(ctx)
-	require.NoError(t, err, "error fetching height after upgrade")
-
-	require.GreaterOrEqual(t, height, haltHeight+blocksAfterUpgrade, "height did not increment enough after upgrade")
+		HaltHeightDelta:    haltHeightDelta,
+		BlocksAfterUpgrade: blocksAfterUpgrade,
+	})
+	require.NoError(t, err, "error performing upgrade")
+	t.Logf("chain upgraded, at height %d", height)
 }
 
 func modifyGenesisShortProposals(votingPeriod string, maxDepositPeriod string) func(ibc.ChainConfig, []byte) ([]byte, error) {
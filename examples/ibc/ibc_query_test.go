@@ -0,0 +1,97 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestIBCQuery links two chains, then finds the transfer channel between them
+// by querying the chains instead of the relayer.
+func TestIBCQuery(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{
+			GasPrices: "0.0uatom",
+		}},
+		{Name: "osmosis", Version: "v7.2.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, osmosis := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const path = "gaia-osmo"
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia).
+		AddChain(osmosis).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia,
+			Chain2:  osmosis,
+			Relayer: r,
+			Path:    path,
+		})
+
+	eRep := testreporter.NewNopReporter().RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  false,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	gaiaQuerier, ok := gaia.(ibc.IBCQuerier)
+	require.True(t, ok, "gaia does not implement ibc.IBCQuerier")
+
+	fromChain, err := ibc.GetTransferChannelFromChain(ctx, gaiaQuerier, gaia.Config().ChainID, osmosis.Config().ChainID)
+	require.NoError(t, err)
+
+	fromRelayer, err := ibc.GetTransferChannel(ctx, r, eRep, gaia.Config().ChainID, osmosis.Config().ChainID)
+	require.NoError(t, err)
+	require.Equal(t, fromRelayer.ChannelID, fromChain.ChannelID)
+	require.Equal(t, fromRelayer.Counterparty.ChannelID, fromChain.Counterparty.ChannelID)
+
+	// The connection of the channel leads to the client tracking osmosis.
+	connections, err := gaiaQuerier.QueryConnections(ctx)
+	require.NoError(t, err)
+	var clientID string
+	for _, conn := range connections {
+		if conn.ID == fromChain.ConnectionHops[0] {
+			clientID = conn.ClientID
+		}
+	}
+	require.NotEmpty(t, clientID)
+
+	cs, err := gaiaQuerier.QueryClientState(ctx, clientID)
+	require.NoError(t, err)
+	require.Equal(t, osmosis.Config().ChainID, cs.ChainID)
+	require.False(t, cs.Frozen)
+
+	// A zero height queries the consensus state at the latest height of the client.
+	consensus, err := gaiaQuerier.QueryConsensusState(ctx, clientID, clienttypes.Height{})
+	require.NoError(t, err)
+	require.Equal(t, cs.LatestHeight, consensus.Height)
+	require.NotEmpty(t, consensus.Root)
+}
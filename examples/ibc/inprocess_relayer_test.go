@@ -0,0 +1,109 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/conformance"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer/inprocess"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestInProcessRelayer links two chains with the in-process relayer,
+// relays a transfer one message at a time, then runs the conformance tests against it.
+func TestInProcessRelayer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{
+			GasPrices: "0.0uatom",
+		}},
+		{Name: "osmosis", Version: "v7.2.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, osmosis := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+
+	rf := inprocess.NewRelayerFactory(zaptest.NewLogger(t))
+	r := rf.Build(t, client, network).(*inprocess.Relayer)
+
+	const path = "gaia-osmo"
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia).
+		AddChain(osmosis).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia,
+			Chain2:  osmosis,
+			Relayer: r,
+			Path:    path,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  false,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, gaia, osmosis)
+	gaiaUser, osmosisUser := users[0], users[1]
+
+	gaiaChannels, err := r.GetChannels(ctx, eRep, gaia.Config().ChainID)
+	require.NoError(t, err)
+	require.Len(t, gaiaChannels, 1)
+	gaiaChannel := gaiaChannels[0]
+
+	const amount = int64(1_000_000)
+	tx, err := gaia.SendIBCTransfer(ctx, gaiaChannel.ChannelID, gaiaUser.KeyName(), ibc.WalletAmount{
+		Address: osmosisUser.FormattedAddress(),
+		Denom:   gaia.Config().Denom,
+		Amount:  amount,
+	}, ibc.TransferOptions{})
+	require.NoError(t, err)
+	require.NoError(t, tx.Validate())
+
+	// Relay the MsgRecvPacket to osmosis, then the MsgAcknowledgement back to gaia.
+	n, err := r.RelayPackets(ctx, eRep, path, gaia.Config().ChainID, gaiaChannel.ChannelID)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	n, err = r.RelayAcknowledgements(ctx, eRep, path, gaia.Config().ChainID, gaiaChannel.ChannelID)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	ibcDenom := transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom(
+		gaiaChannel.Counterparty.PortID, gaiaChannel.Counterparty.ChannelID, gaia.Config().Denom,
+	)).IBCDenom()
+	bal, err := osmosis.GetBalance(ctx, osmosisUser.FormattedAddress(), ibcDenom)
+	require.NoError(t, err)
+	require.Equal(t, amount, bal)
+
+	// Nothing is left to relay.
+	n, err = r.RelayPackets(ctx, eRep, path, gaia.Config().ChainID, gaiaChannel.ChannelID)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	conformance.TestChainPair(t, ctx, client, network, gaia, osmosis, rf, rep, r, path)
+}
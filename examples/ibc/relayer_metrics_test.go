@@ -0,0 +1,122 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestRelayerMetrics relays a transfer with each Docker relayer,
+// then checks the packets relayed in the metrics scraped from the relayer.
+func TestRelayerMetrics(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	for _, impl := range []ibc.RelayerImplementation{ibc.CosmosRly, ibc.Hermes} {
+		impl := impl
+		rf := interchaintest.NewBuiltinRelayerFactory(impl, zaptest.NewLogger(t))
+		t.Run(rf.Name(), func(t *testing.T) {
+			t.Parallel()
+			testRelayerMetrics(t, rf)
+		})
+	}
+}
+
+func testRelayerMetrics(t *testing.T, rf interchaintest.RelayerFactory) {
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{
+			GasPrices: "0.0uatom",
+		}},
+		{Name: "osmosis", Version: "v7.2.0"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia, osmosis := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := rf.Build(t, client, network)
+
+	const path = "gaia-osmo"
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia).
+		AddChain(osmosis).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia,
+			Chain2:  osmosis,
+			Relayer: r,
+			Path:    path,
+		})
+
+	eRep := testreporter.NewNopReporter().RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  false,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, gaia, osmosis)
+	gaiaUser, osmosisUser := users[0], users[1]
+
+	channels, err := r.GetChannels(ctx, eRep, gaia.Config().ChainID)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, path))
+	t.Cleanup(func() {
+		_ = r.StopRelayer(ctx, eRep)
+	})
+
+	tx, err := gaia.SendIBCTransfer(ctx, channels[0].ChannelID, gaiaUser.KeyName(), ibc.WalletAmount{
+		Address: osmosisUser.FormattedAddress(),
+		Denom:   gaia.Config().Denom,
+		Amount:  1_000_000,
+	}, ibc.TransferOptions{})
+	require.NoError(t, err)
+	require.NoError(t, tx.Validate())
+
+	_, err = testutil.PollForAck(ctx, gaia, tx.Height, tx.Height+30, tx.Packet)
+	require.NoError(t, err)
+
+	mr, ok := r.(interface {
+		Metrics(ctx context.Context) (relayer.RelayerMetrics, error)
+	})
+	require.True(t, ok, "relayer does not expose metrics")
+
+	// Relayers update their metrics asynchronously, so poll until the packet is counted.
+	var m relayer.RelayerMetrics
+	require.NoError(t, testutil.WaitForCondition(time.Minute, 2*time.Second, func() (bool, error) {
+		m, err = mr.Metrics(ctx)
+		if err != nil {
+			return false, err
+		}
+		packets, err := m.PacketsRelayed(osmosis.Config().ChainID)
+		return packets >= 1, err
+	}))
+
+	txErrors, err := m.TxErrors()
+	require.NoError(t, err)
+	balance, err := m.WalletBalance(gaia.Config().ChainID, gaia.Config().Denom)
+	require.NoError(t, err)
+	t.Logf("%s reported %.0f tx errors and a wallet balance of %.0f%s on %s", rf.Name(), txErrors, balance, gaia.Config().Denom, gaia.Config().ChainID)
+}
@@ -0,0 +1,122 @@
+package penumbra_test
+
+import (
+	"context"
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/conformance"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestPenumbraIBC links a Penumbra chain to a Cosmos chain, transfers tokens from Penumbra,
+// checks the balances on both chains, then runs the conformance tests against the pair.
+func TestPenumbraIBC(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+	nv := 2
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:    "penumbra",
+			Version: "045-metis,v0.34.23",
+			ChainConfig: ibc.ChainConfig{
+				ChainID: "penumbra-1",
+			},
+			NumValidators: &nv,
+		},
+		{Name: "gaia", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{
+			GasPrices: "0.0uatom",
+		}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	penumbra, gaia := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+
+	rf := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t))
+	r := rf.Build(t, client, network)
+
+	const path = "penumbra-gaia"
+	ic := interchaintest.NewInterchain().
+		AddChain(penumbra).
+		AddChain(gaia).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  penumbra,
+			Chain2:  gaia,
+			Relayer: r,
+			Path:    path,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  false,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const fundAmount = int64(10_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", fundAmount, penumbra, gaia)
+	penumbraUser, gaiaUser := users[0], users[1]
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, penumbra, gaia))
+
+	penumbraBal, err := penumbra.GetBalance(ctx, penumbraUser.FormattedAddress(), penumbra.Config().Denom)
+	require.NoError(t, err)
+	require.Equal(t, fundAmount, penumbraBal)
+
+	// The relayer wallet was funded at genesis, and its balance can be queried like any other key's.
+	relayerWallet, ok := r.GetWallet(penumbra.Config().ChainID)
+	require.True(t, ok)
+	relayerBal, err := penumbra.GetBalance(ctx, relayerWallet.FormattedAddress(), penumbra.Config().Denom)
+	require.NoError(t, err)
+	require.Positive(t, relayerBal)
+
+	channels, err := r.GetChannels(ctx, eRep, penumbra.Config().ChainID)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	channel := channels[0]
+
+	const amount = int64(1_000_000)
+	tx, err := penumbra.SendIBCTransfer(ctx, channel.ChannelID, penumbraUser.KeyName(), ibc.WalletAmount{
+		Address: gaiaUser.FormattedAddress(),
+		Denom:   penumbra.Config().Denom,
+		Amount:  amount,
+	}, ibc.TransferOptions{})
+	require.NoError(t, err)
+	require.NoError(t, tx.Validate())
+
+	require.NoError(t, r.Flush(ctx, eRep, path, channel.ChannelID))
+
+	penumbraBal, err = penumbra.GetBalance(ctx, penumbraUser.FormattedAddress(), penumbra.Config().Denom)
+	require.NoError(t, err)
+	require.LessOrEqual(t, penumbraBal, fundAmount-amount)
+
+	ibcDenom := transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom(
+		channel.Counterparty.PortID, channel.Counterparty.ChannelID, penumbra.Config().Denom,
+	)).IBCDenom()
+	gaiaBal, err := gaia.GetBalance(ctx, gaiaUser.FormattedAddress(), ibcDenom)
+	require.NoError(t, err)
+	require.Equal(t, amount, gaiaBal)
+
+	conformance.TestChainPair(t, ctx, client, network, penumbra, gaia, rf, rep, r, path)
+}
@@ -0,0 +1,13 @@
+package interchaintest
+
+import "github.com/strangelove-ventures/interchaintest/v7/ibc"
+
+// Unexported functions used by the tests of package interchaintest_test.
+var (
+	UnregisterChainType   = unregisterChainType
+	UnregisterRelayerType = unregisterRelayerType
+)
+
+// ClientOpts and ChannelOpts return the options of the link, with defaults for the omitted fields.
+func (l TopologyLink) ClientOpts() ibc.CreateClientOptions   { return l.clientOpts() }
+func (l TopologyLink) ChannelOpts() ibc.CreateChannelOptions { return l.channelOpts() }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 
+	chantypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
 	host "github.com/cosmos/ibc-go/v7/modules/core/24-host"
 	"go.uber.org/multierr"
 )
@@ -28,6 +29,20 @@ type Packet struct {
 	TimeoutTimestamp Nanoseconds
 }
 
+// PacketFromChannel converts a packet of ibc-go messages, such as MsgAcknowledgement, into a Packet.
+func PacketFromChannel(p chantypes.Packet) Packet {
+	return Packet{
+		Sequence:         p.Sequence,
+		SourcePort:       p.SourcePort,
+		SourceChannel:    p.SourceChannel,
+		DestPort:         p.DestinationPort,
+		DestChannel:      p.DestinationChannel,
+		Data:             p.Data,
+		TimeoutHeight:    p.TimeoutHeight.String(),
+		TimeoutTimestamp: Nanoseconds(p.TimeoutTimestamp),
+	}
+}
+
 // Validate returns an error if the packet is not well-formed.
 func (packet Packet) Validate() error {
 	var merr error
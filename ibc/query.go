@@ -0,0 +1,87 @@
+package ibc
+
+import (
+	"context"
+	"time"
+
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+)
+
+// IBCQuerier is optionally implemented by chains that can query their own IBC state,
+// so that tests can find clients, connections, channels and packets without depending on the output of a relayer.
+type IBCQuerier interface {
+	// QueryClients returns the states of all light clients on the chain.
+	QueryClients(ctx context.Context) ([]ClientStateOutput, error)
+
+	// QueryClientState returns the state of the light client with clientID.
+	QueryClientState(ctx context.Context, clientID string) (ClientStateOutput, error)
+
+	// QueryConsensusState returns the consensus state of the light client with clientID at height,
+	// or at its latest height if height is zero.
+	QueryConsensusState(ctx context.Context, clientID string, height clienttypes.Height) (ConsensusStateOutput, error)
+
+	// QueryConnections returns all connections on the chain.
+	QueryConnections(ctx context.Context) (ConnectionOutputs, error)
+
+	// QueryChannels returns all channels on the chain.
+	QueryChannels(ctx context.Context) ([]ChannelOutput, error)
+
+	// QueryPacketCommitments returns the sequences of the packets sent on a channel
+	// whose commitments have not been cleared by an acknowledgement or timeout.
+	QueryPacketCommitments(ctx context.Context, portID, channelID string) ([]uint64, error)
+
+	// QueryPacketReceipt reports whether the packet with sequence was received on a channel.
+	QueryPacketReceipt(ctx context.Context, portID, channelID string, sequence uint64) (bool, error)
+
+	// QueryUnreceivedPackets returns the sequences, of those committed by the counterparty channel,
+	// of the packets not yet received on a channel.
+	QueryUnreceivedPackets(ctx context.Context, portID, channelID string, sequences []uint64) ([]uint64, error)
+
+	// QueryUnreceivedAcks returns the sequences, of those acknowledged by the counterparty channel,
+	// of the packets sent on a channel whose acknowledgements were not yet received.
+	QueryUnreceivedAcks(ctx context.Context, portID, channelID string, sequences []uint64) ([]uint64, error)
+}
+
+// ClientStateOutput represents the state of an IBC light client queried from the chain that hosts it.
+// For client states the chain's codec cannot decode, only ClientID, ClientType and Status are set.
+type ClientStateOutput struct {
+	ClientID   string
+	ClientType string // E.g. 07-tendermint.
+	Status     string // Active, Expired, Frozen or Unknown.
+
+	// ChainID and TrustingPeriod are only set for clients that track them, such as tendermint clients.
+	ChainID        string
+	TrustingPeriod time.Duration
+	LatestHeight   clienttypes.Height
+	Frozen         bool
+}
+
+// ConsensusStateOutput represents a consensus state of an IBC light client.
+type ConsensusStateOutput struct {
+	Height    clienttypes.Height
+	Timestamp time.Time
+	Root      []byte
+
+	// Only set for tendermint clients.
+	NextValidatorsHash []byte
+}
+
+// GetTransferChannelFromChain is like GetTransferChannel,
+// but queries the IBC state of the source chain instead of a relayer.
+func GetTransferChannelFromChain(ctx context.Context, q IBCQuerier, srcChainID, dstChainID string) (*ChannelOutput, error) {
+	return findTransferChannel(srcChainID, dstChainID,
+		func() (ClientOutputs, error) {
+			states, err := q.QueryClients(ctx)
+			if err != nil {
+				return nil, err
+			}
+			clients := make(ClientOutputs, len(states))
+			for i, s := range states {
+				clients[i] = &ClientOutput{ClientID: s.ClientID, ClientState: ClientState{ChainID: s.ChainID}}
+			}
+			return clients, nil
+		},
+		func() (ConnectionOutputs, error) { return q.QueryConnections(ctx) },
+		func() ([]ChannelOutput, error) { return q.QueryChannels(ctx) },
+	)
+}
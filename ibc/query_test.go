@@ -0,0 +1,59 @@
+package ibc
+
+import (
+	"context"
+	"testing"
+
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	"github.com/stretchr/testify/require"
+)
+
+type mockIBCQuerier struct {
+	IBCQuerier // Unused queries panic.
+
+	clients     []ClientStateOutput
+	connections ConnectionOutputs
+	channels    []ChannelOutput
+}
+
+func (q mockIBCQuerier) QueryClients(context.Context) ([]ClientStateOutput, error) {
+	return q.clients, nil
+}
+
+func (q mockIBCQuerier) QueryConnections(context.Context) (ConnectionOutputs, error) {
+	return q.connections, nil
+}
+
+func (q mockIBCQuerier) QueryChannels(context.Context) ([]ChannelOutput, error) {
+	return q.channels, nil
+}
+
+func TestGetTransferChannelFromChain(t *testing.T) {
+	ctx := context.Background()
+	q := mockIBCQuerier{
+		clients: []ClientStateOutput{
+			{ClientID: "07-tendermint-0", ChainID: "osmosis-1", LatestHeight: clienttypes.NewHeight(1, 10)},
+			{ClientID: "07-tendermint-1", ChainID: "juno-1"},
+		},
+		connections: ConnectionOutputs{
+			{ID: "connection-0", ClientID: "07-tendermint-0"},
+			{ID: "connection-1", ClientID: "07-tendermint-1"},
+		},
+		channels: []ChannelOutput{
+			{PortID: "icahost", ChannelID: "channel-0", ConnectionHops: []string{"connection-1"}},
+			{PortID: "transfer", ChannelID: "channel-1", ConnectionHops: []string{"connection-1"}},
+			{PortID: "transfer", ChannelID: "channel-2", ConnectionHops: []string{"connection-0"}},
+		},
+	}
+
+	ch, err := GetTransferChannelFromChain(ctx, q, "gaia-1", "juno-1")
+	require.NoError(t, err)
+	require.Equal(t, "channel-1", ch.ChannelID)
+
+	ch, err = GetTransferChannelFromChain(ctx, q, "gaia-1", "osmosis-1")
+	require.NoError(t, err)
+	require.Equal(t, "channel-2", ch.ChannelID)
+
+	_, err = GetTransferChannelFromChain(ctx, q, "gaia-1", "stargaze-1")
+	require.EqualError(t, err, "unable to find client on gaia-1 tracking stargaze-1")
+}
@@ -2,7 +2,9 @@ package ibc
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	chantypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
@@ -102,7 +104,21 @@ type Relayer interface {
 // GetTransferChannel will return the transfer channel assuming only one client,
 // one connection, and one channel with "transfer" port exists between two chains.
 func GetTransferChannel(ctx context.Context, r Relayer, rep RelayerExecReporter, srcChainID, dstChainID string) (*ChannelOutput, error) {
-	srcClients, err := r.GetClients(ctx, rep, srcChainID)
+	return findTransferChannel(srcChainID, dstChainID,
+		func() (ClientOutputs, error) { return r.GetClients(ctx, rep, srcChainID) },
+		func() (ConnectionOutputs, error) { return r.GetConnections(ctx, rep, srcChainID) },
+		func() ([]ChannelOutput, error) { return r.GetChannels(ctx, rep, srcChainID) },
+	)
+}
+
+// findTransferChannel finds the transfer channel on the source chain, from its clients, connections and channels.
+func findTransferChannel(
+	srcChainID, dstChainID string,
+	getClients func() (ClientOutputs, error),
+	getConnections func() (ConnectionOutputs, error),
+	getChannels func() ([]ChannelOutput, error),
+) (*ChannelOutput, error) {
+	srcClients, err := getClients()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get clients on source chain: %w", err)
 	}
@@ -126,7 +142,7 @@ func GetTransferChannel(ctx context.Context, r Relayer, rep RelayerExecReporter,
 		return nil, fmt.Errorf("unable to find client on %s tracking %s", srcChainID, dstChainID)
 	}
 
-	srcConnections, err := r.GetConnections(ctx, rep, srcChainID)
+	srcConnections, err := getConnections()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get connections on source chain: %w", err)
 	}
@@ -149,7 +165,7 @@ func GetTransferChannel(ctx context.Context, r Relayer, rep RelayerExecReporter,
 		return nil, fmt.Errorf("unable to find connection on %s for client %s", srcChainID, srcClientID)
 	}
 
-	srcChannels, err := r.GetChannels(ctx, rep, srcChainID)
+	srcChannels, err := getChannels()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get channels on source chain: %w", err)
 	}
@@ -160,6 +176,7 @@ func GetTransferChannel(ctx context.Context, r Relayer, rep RelayerExecReporter,
 
 	var srcChan *ChannelOutput
 	for _, channel := range srcChannels {
+		channel := channel
 		if len(channel.ConnectionHops) == 1 && channel.ConnectionHops[0] == srcConnectionID && channel.PortID == "transfer" {
 			if srcChan != nil {
 				return nil, fmt.Errorf("found multiple transfer channels on %s for connection %s", srcChainID, srcConnectionID)
@@ -253,6 +270,30 @@ func (o Order) String() string {
 	}
 }
 
+// UnmarshalJSON accepts either the string representation of the Order, e.g. "ordered",
+// or its integer value, so that channel options can be written by hand in configuration files.
+func (o *Order) UnmarshalJSON(bz []byte) error {
+	var s string
+	if err := json.Unmarshal(bz, &s); err != nil {
+		var n int
+		if err := json.Unmarshal(bz, &n); err != nil {
+			return fmt.Errorf("channel order must be a string or an integer: %s", bz)
+		}
+		*o = Order(n)
+		return nil
+	}
+
+	switch strings.ToLower(s) {
+	case "ordered", "order_ordered":
+		*o = Ordered
+	case "unordered", "order_unordered":
+		*o = Unordered
+	default:
+		return fmt.Errorf("invalid channel order %q (valid orders: ordered, unordered)", s)
+	}
+	return nil
+}
+
 // Validate checks that the Order type is a valid value.
 func (o Order) Validate() error {
 	if o == Ordered || o == Unordered {
@@ -1,6 +1,7 @@
 package ibc
 
 import (
+	"encoding/json"
 	"testing"
 
 	chantypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
@@ -34,3 +35,17 @@ func TestChannelOptsConfigured(t *testing.T) {
 	}
 	require.Error(t, opts.Validate())
 }
+
+func TestOrderUnmarshalJSON(t *testing.T) {
+	var opts CreateChannelOptions
+	require.NoError(t, json.Unmarshal([]byte(`{"Order": "ordered"}`), &opts))
+	require.Equal(t, Ordered, opts.Order)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"Order": "UNORDERED"}`), &opts))
+	require.Equal(t, Unordered, opts.Order)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"Order": 1}`), &opts))
+	require.Equal(t, Ordered, opts.Order)
+
+	require.Error(t, json.Unmarshal([]byte(`{"Order": "sideways"}`), &opts))
+}
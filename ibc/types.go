@@ -42,6 +42,30 @@ type ChainConfig struct {
 	EncodingConfig *testutil.TestEncodingConfig
 	// Required when the chain uses the new sub commands for genesis (https://github.com/cosmos/cosmos-sdk/pull/14149)
 	UsingNewGenesisCommand bool `yaml:"using-new-genesis-command"`
+	// Parachains to run alongside the relay chain, used for polkadot chains only.
+	// The relay chain uses Images[0].
+	Parachains []ParachainConfig `yaml:"parachains"`
+}
+
+// ParachainConfig describes a parachain of a polkadot chain.
+type ParachainConfig struct {
+	// Chain ID passed to the parachain binary as --chain, e.g. dev-2000.
+	ChainID string `yaml:"chain-id"`
+	// Docker image of the parachain nodes.
+	Image DockerImage `yaml:"image"`
+	// Binary to execute for the parachain node daemon.
+	Bin string `yaml:"bin"`
+	// Parachain ID registered on the relay chain.
+	// If zero, the ID of the parachain's default chain spec is used.
+	ParaID int `yaml:"para-id"`
+	// Number of parachain nodes. If zero, the number of full nodes of the chain spec is used.
+	NumNodes int `yaml:"num-nodes"`
+	// Flags for the parachain node.
+	Flags []string `yaml:"flags"`
+	// Flags for the embedded relay chain node, passed after --.
+	RelayChainFlags []string `yaml:"relay-chain-flags"`
+	// When provided, the parachain chain spec will be altered before it is shared.
+	ModifyGenesis func(ChainConfig, []byte) ([]byte, error)
 }
 
 func (c ChainConfig) Clone() ChainConfig {
@@ -49,6 +73,9 @@ func (c ChainConfig) Clone() ChainConfig {
 	images := make([]DockerImage, len(c.Images))
 	copy(images, c.Images)
 	x.Images = images
+	if c.Parachains != nil {
+		x.Parachains = append([]ParachainConfig(nil), c.Parachains...)
+	}
 	return x
 }
 
@@ -134,6 +161,10 @@ func (c ChainConfig) MergeChainSpecConfig(other ChainConfig) ChainConfig {
 		c.EncodingConfig = other.EncodingConfig
 	}
 
+	if len(other.Parachains) > 0 {
+		c.Parachains = append([]ParachainConfig(nil), other.Parachains...)
+	}
+
 	return c
 }
 
@@ -3,9 +3,11 @@ package interchaintest
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -37,6 +39,12 @@ type Interchain struct {
 
 	// Set during Build and cleaned up in the Close method.
 	cs *chainSet
+
+	// Network faults injected into the interchain's containers, set during Build.
+	nf *networkFaults
+
+	// Saves and restores chain volumes for Snapshot and RestoreInterchain, set during Build.
+	archiver *dockerutil.VolumeArchiver
 }
 
 type interchainLink struct {
@@ -226,6 +234,9 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 	}
 	ic.cs = newChainSet(ic.log, chains)
 
+	ic.nf = newNetworkFaults(ic.log, opts)
+	ic.archiver = dockerutil.NewVolumeArchiver(ic.log, opts.Client, opts.TestName)
+
 	// Initialize the chains (pull docker images, etc.).
 	if err := ic.cs.Initialize(ctx, opts.TestName, opts.Client, opts.NetworkID); err != nil {
 		return fmt.Errorf("failed to initialize chains: %w", err)
@@ -246,7 +257,7 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		return fmt.Errorf("failed to start chains: %w", err)
 	}
 
-	if err := ic.cs.TrackBlocks(ctx, opts.TestName, opts.BlockDatabaseFile, opts.GitSha); err != nil {
+	if err := ic.cs.TrackBlocks(ctx, opts.TestName, opts.BlockDatabaseFile, opts.GitSha, rep); err != nil {
 		return fmt.Errorf("failed to track blocks: %w", err)
 	}
 
@@ -328,6 +339,39 @@ func (ic *Interchain) WithLog(log *zap.Logger) *Interchain {
 	return ic
 }
 
+// Chains returns the chains added to the interchain, ordered by chain ID.
+func (ic *Interchain) Chains() []ibc.Chain {
+	chains := make([]ibc.Chain, 0, len(ic.chains))
+	for c := range ic.chains {
+		chains = append(chains, c)
+	}
+	sort.Slice(chains, func(i, j int) bool {
+		return ic.chains[chains[i]] < ic.chains[chains[j]]
+	})
+	return chains
+}
+
+// Relayers returns the relayers added to the interchain, keyed by their instance name.
+func (ic *Interchain) Relayers() map[string]ibc.Relayer {
+	relayers := make(map[string]ibc.Relayer, len(ic.relayers))
+	for r, name := range ic.relayers {
+		relayers[name] = r
+	}
+	return relayers
+}
+
+// RelayerPaths returns the sorted names of the paths linked through the given relayer.
+func (ic *Interchain) RelayerPaths(r ibc.Relayer) []string {
+	var paths []string
+	for rp := range ic.links {
+		if rp.Relayer == r {
+			paths = append(paths, rp.Path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
 // Close cleans up any resources created during Build,
 // and returns any relevant errors.
 func (ic *Interchain) Close() error {
@@ -404,10 +448,7 @@ func (ic *Interchain) configureRelayerKeys(ctx context.Context, rep *testreporte
 
 	for r, chains := range ic.relayerChains() {
 		for _, c := range chains {
-			rpcAddr, grpcAddr := c.GetRPCAddress(), c.GetGRPCAddress()
-			if !r.UseDockerNetwork() {
-				rpcAddr, grpcAddr = c.GetHostRPCAddress(), c.GetHostGRPCAddress()
-			}
+			rpcAddr, grpcAddr := relayerChainAddrs(r, c)
 
 			chainName := ic.chains[c]
 			if err := r.AddChainConfiguration(ctx,
@@ -431,6 +472,14 @@ func (ic *Interchain) configureRelayerKeys(ctx context.Context, rep *testreporte
 	return nil
 }
 
+// relayerChainAddrs returns the RPC and gRPC addresses the relayer uses to reach the chain.
+func relayerChainAddrs(r ibc.Relayer, c ibc.Chain) (rpcAddr, grpcAddr string) {
+	if !r.UseDockerNetwork() {
+		return c.GetHostRPCAddress(), c.GetHostGRPCAddress()
+	}
+	return c.GetRPCAddress(), c.GetGRPCAddress()
+}
+
 // relayerChain is a tuple of a Relayer and a Chain.
 type relayerChain struct {
 	R ibc.Relayer
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
@@ -450,6 +451,24 @@ func TestInterchain_AddNil(t *testing.T) {
 	})
 }
 
+func TestInterchain_NetworkFaultsRequireBuild(t *testing.T) {
+	var (
+		_ interchaintest.ContainerOwner = (*cosmos.CosmosChain)(nil)
+		_ interchaintest.ContainerOwner = (*cosmos.ChainNode)(nil)
+		_ interchaintest.ContainerOwner = (*rly.CosmosRelayer)(nil)
+	)
+
+	ic := interchaintest.NewInterchain()
+	ctx := context.Background()
+	var c cosmos.CosmosChain
+
+	require.Error(t, ic.Partition(ctx, &c, &c))
+	require.Error(t, ic.Heal(ctx, &c, &c))
+	require.Error(t, ic.AddLatency(ctx, &c, time.Second))
+	require.Error(t, ic.DropPackets(ctx, &c, 10))
+	require.Error(t, ic.ClearNetworkFaults(ctx))
+}
+
 func assertTransactionIsValid(t *testing.T, resp sdk.TxResponse) {
 	require.NotNil(t, resp)
 	require.NotEqual(t, 0, resp.GasUsed)
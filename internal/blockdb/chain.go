@@ -3,8 +3,10 @@ package blockdb
 import (
 	"context"
 	"database/sql"
+	"encoding/hex"
 	"fmt"
 	"hash/fnv"
+	"time"
 
 	"golang.org/x/sync/singleflight"
 )
@@ -16,6 +18,12 @@ type Chain struct {
 	single singleflight.Group
 }
 
+// Phases of the block_event table.
+const (
+	blockEventBegin = "begin_block"
+	blockEventEnd   = "end_block"
+)
+
 type transactions []Tx
 
 func (txs transactions) Hash() []byte {
@@ -32,19 +40,42 @@ func (txs transactions) Hash() []byte {
 func (chain *Chain) SaveBlock(ctx context.Context, height uint64, txs []Tx) error {
 	k := fmt.Sprintf("%d-%x", height, transactions(txs).Hash())
 	_, err, _ := chain.single.Do(k, func() (any, error) {
-		return nil, chain.saveBlock(ctx, height, txs)
+		return nil, chain.saveBlock(ctx, Block{Height: height, Txs: txs}, false)
+	})
+	return err
+}
+
+// SaveFullBlock tracks a block with its header metadata, commit signatures, begin and end block events,
+// and transactions. Like SaveBlock, this method is idempotent.
+func (chain *Chain) SaveFullBlock(ctx context.Context, block Block) error {
+	k := fmt.Sprintf("%d-%x-%s-full", block.Height, transactions(block.Txs).Hash(), block.AppHash)
+	_, err, _ := chain.single.Do(k, func() (any, error) {
+		return nil, chain.saveBlock(ctx, block, true)
 	})
 	return err
 }
 
-func (chain *Chain) saveBlock(ctx context.Context, height uint64, txs transactions) error {
+func (chain *Chain) saveBlock(ctx context.Context, block Block, full bool) error {
 	dbTx, err := chain.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = dbTx.Rollback() }()
 
-	res, err := dbTx.ExecContext(ctx, `INSERT OR REPLACE INTO block(height, fk_chain_id, created_at) VALUES (?, ?, ?)`, height, chain.id, nowRFC3339())
+	// Blocks saved without their header have NULL header columns.
+	var blockTime, proposer, appHash, validatorsHash sql.NullString
+	if full {
+		// Block times need sub-second precision to tell blocks apart.
+		blockTime = sql.NullString{String: block.Time.UTC().Format(time.RFC3339Nano), Valid: true}
+		proposer = sql.NullString{String: block.Proposer, Valid: true}
+		appHash = sql.NullString{String: block.AppHash, Valid: true}
+		validatorsHash = sql.NullString{String: block.ValidatorsHash, Valid: true}
+	}
+
+	res, err := dbTx.ExecContext(ctx, `INSERT OR REPLACE INTO block(
+    height, fk_chain_id, created_at, block_time, proposer, app_hash, validators_hash
+) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		block.Height, chain.id, nowRFC3339(), blockTime, proposer, appHash, validatorsHash)
 	if err != nil {
 		return fmt.Errorf("insert into block: %w", err)
 	}
@@ -53,7 +84,43 @@ func (chain *Chain) saveBlock(ctx context.Context, height uint64, txs transactio
 	if err != nil {
 		return err
 	}
-	for _, tx := range txs {
+
+	for _, sig := range block.Signatures {
+		_, err := dbTx.ExecContext(ctx, `INSERT INTO block_signature(validator_address, flag, timestamp, signature, fk_block_id) VALUES (?, ?, ?, ?, ?)`,
+			sig.ValidatorAddress, sig.Flag, sig.Timestamp.UTC().Format(time.RFC3339Nano), hex.EncodeToString(sig.Signature), blockID)
+		if err != nil {
+			return fmt.Errorf("insert into block_signature: %w", err)
+		}
+	}
+
+	for _, phase := range []struct {
+		name   string
+		events []Event
+	}{
+		{blockEventBegin, block.BeginBlockEvents},
+		{blockEventEnd, block.EndBlockEvents},
+	} {
+		for _, e := range phase.events {
+			eventRes, err := dbTx.ExecContext(ctx, `INSERT INTO block_event(type, phase, fk_block_id) VALUES (?, ?, ?)`, e.Type, phase.name, blockID)
+			if err != nil {
+				return fmt.Errorf("insert into block_event: %w", err)
+			}
+
+			eventID, err := eventRes.LastInsertId()
+			if err != nil {
+				return err
+			}
+
+			for _, attr := range e.Attributes {
+				_, err := dbTx.ExecContext(ctx, `INSERT INTO block_event_attr(key, value, fk_event_id) VALUES (?, ?, ?)`, attr.Key, attr.Value, eventID)
+				if err != nil {
+					return fmt.Errorf("insert into block_event_attr: %w", err)
+				}
+			}
+		}
+	}
+
+	for _, tx := range block.Txs {
 		txRes, err := dbTx.ExecContext(ctx, `INSERT INTO tx(data, fk_block_id) VALUES (?, ?)`, string(tx.Data), blockID)
 		if err != nil {
 			return fmt.Errorf("insert into tx: %w", err)
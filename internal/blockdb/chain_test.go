@@ -118,6 +118,71 @@ ORDER BY tendermint_event_attr.id`)
 		}
 	})
 
+	t.Run("full block", func(t *testing.T) {
+		db := migratedDB()
+		defer db.Close()
+
+		chain := validChain(t, db)
+
+		blockTime := time.Date(2023, 5, 1, 12, 0, 0, 500, time.UTC)
+		block := Block{
+			Height:         7,
+			Time:           blockTime,
+			Proposer:       "AABB",
+			AppHash:        "CCDD",
+			ValidatorsHash: "EEFF",
+			Signatures: []CommitSig{
+				{ValidatorAddress: "AABB", Flag: SignatureCommit, Timestamp: blockTime, Signature: []byte{1, 2}},
+				{ValidatorAddress: "1122", Flag: SignatureAbsent},
+			},
+			BeginBlockEvents: []Event{{Type: "mint", Attributes: []EventAttribute{{Key: "amount", Value: "10"}}}},
+			EndBlockEvents:   []Event{{Type: "complete_unbonding"}},
+			Txs:              []Tx{tx2},
+		}
+		require.NoError(t, chain.SaveFullBlock(ctx, block))
+		// Saving again replaces the block.
+		require.NoError(t, chain.SaveFullBlock(ctx, block))
+
+		var gotTime, gotProposer, gotAppHash, gotValidatorsHash string
+		row := db.QueryRow(`SELECT block_time, proposer, app_hash, validators_hash FROM block WHERE height = 7`)
+		require.NoError(t, row.Scan(&gotTime, &gotProposer, &gotAppHash, &gotValidatorsHash))
+		require.Equal(t, "2023-05-01T12:00:00.0000005Z", gotTime)
+		require.Equal(t, "AABB", gotProposer)
+		require.Equal(t, "CCDD", gotAppHash)
+		require.Equal(t, "EEFF", gotValidatorsHash)
+
+		rows, err := db.Query(`SELECT validator_address, flag, signature FROM block_signature ORDER BY id`)
+		require.NoError(t, err)
+		defer rows.Close()
+		var gotSigs [][3]string
+		for rows.Next() {
+			var sig [3]string
+			require.NoError(t, rows.Scan(&sig[0], &sig[1], &sig[2]))
+			gotSigs = append(gotSigs, sig)
+		}
+		require.Equal(t, [][3]string{{"AABB", "commit", "0102"}, {"1122", "absent", ""}}, gotSigs)
+
+		rows, err = db.Query(`SELECT block_event.type, phase, COALESCE(key, ''), COALESCE(value, '') FROM block_event
+LEFT JOIN block_event_attr ON block_event_attr.fk_event_id = block_event.id
+ORDER BY block_event.id`)
+		require.NoError(t, err)
+		defer rows.Close()
+		var gotEvents [][4]string
+		for rows.Next() {
+			var e [4]string
+			require.NoError(t, rows.Scan(&e[0], &e[1], &e[2], &e[3]))
+			gotEvents = append(gotEvents, e)
+		}
+		require.Equal(t, [][4]string{
+			{"mint", "begin_block", "amount", "10"},
+			{"complete_unbonding", "end_block", "", ""},
+		}, gotEvents)
+
+		var count int
+		require.NoError(t, db.QueryRow(`SELECT count(*) FROM tx`).Scan(&count))
+		require.Equal(t, 1, count)
+	})
+
 	t.Run("idempotent", func(t *testing.T) {
 		db := migratedDB()
 		defer db.Close()
@@ -34,14 +34,60 @@ type EventAttribute struct {
 	Key, Value string
 }
 
+// Commit signature flags, as stored in the block_signature table.
+const (
+	SignatureAbsent = "absent" // No vote was received from the validator.
+	SignatureCommit = "commit" // The validator voted for the block.
+	SignatureNil    = "nil"    // The validator voted for nil.
+)
+
+// CommitSig is a validator's signature of the commit for a block.
+type CommitSig struct {
+	ValidatorAddress string // Hex encoded.
+	Flag             string // One of SignatureAbsent, SignatureCommit or SignatureNil.
+	Timestamp        time.Time
+	Signature        []byte
+}
+
+// Block is a block's header metadata, commit signatures and events, along with its transactions.
+// Hashes and addresses are hex encoded.
+type Block struct {
+	Height         uint64
+	Time           time.Time
+	Proposer       string
+	AppHash        string
+	ValidatorsHash string
+
+	// Signatures of the commit for this block, i.e. not the block's last commit.
+	Signatures []CommitSig
+
+	BeginBlockEvents []Event
+	EndBlockEvents   []Event
+
+	Txs []Tx
+}
+
 // TxFinder finds transactions given block at height.
 type TxFinder interface {
 	FindTxs(ctx context.Context, height uint64) ([]Tx, error)
 }
 
-// BlockSaver saves transactions for block at height.
+// BlockFinder finds the whole block at height, including blocks without transactions.
+// The Collector prefers FindBlock over FindTxs for a TxFinder that also implements BlockFinder.
+type BlockFinder interface {
+	FindBlock(ctx context.Context, height uint64) (Block, error)
+}
+
+// SubchainFinder is implemented by chains made of several blockchains, e.g. a Polkadot relay chain and its parachains.
+// The blocks of each subchain are saved as a separate chain, with the subchain name appended to the chain ID.
+type SubchainFinder interface {
+	Subchains() map[string]TxFinder
+}
+
+// BlockSaver saves transactions for block at height, or whole blocks found by a BlockFinder.
 type BlockSaver interface {
 	SaveBlock(ctx context.Context, height uint64, txs []Tx) error
+	SaveFullBlock(ctx context.Context, block Block) error
 }
 
 // Collector saves block transactions at regular intervals.
@@ -103,6 +149,17 @@ func (p *Collector) Stop() {
 }
 
 func (p *Collector) saveTxsForHeight(ctx context.Context, height uint64) error {
+	if finder, ok := p.finder.(BlockFinder); ok {
+		block, err := finder.FindBlock(ctx, height)
+		if err != nil {
+			return fmt.Errorf("find block: %w", err)
+		}
+		if err := p.saver.SaveFullBlock(ctx, block); err != nil {
+			return fmt.Errorf("save block: %w", err)
+		}
+		return nil
+	}
+
 	txs, err := p.finder.FindTxs(ctx, height)
 	if err != nil {
 		return fmt.Errorf("find txs: %w", err)
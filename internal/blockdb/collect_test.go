@@ -21,12 +21,26 @@ func (f mockTxFinder) FindTxs(ctx context.Context, height uint64) ([]Tx, error)
 	return f(ctx, height)
 }
 
+type mockBlockFinder func(ctx context.Context, height uint64) (Block, error)
+
+func (f mockBlockFinder) FindTxs(ctx context.Context, height uint64) ([]Tx, error) {
+	panic("FindTxs called on a BlockFinder")
+}
+
+func (f mockBlockFinder) FindBlock(ctx context.Context, height uint64) (Block, error) {
+	return f(ctx, height)
+}
+
 type mockBlockSaver func(ctx context.Context, height uint64, txs []Tx) error
 
 func (f mockBlockSaver) SaveBlock(ctx context.Context, height uint64, txs []Tx) error {
 	return f(ctx, height, txs)
 }
 
+func (f mockBlockSaver) SaveFullBlock(ctx context.Context, block Block) error {
+	return f(ctx, block.Height, block.Txs)
+}
+
 func TestCollector_Collect(t *testing.T) {
 	nopLog := zap.NewNop()
 
@@ -87,6 +101,35 @@ func TestCollector_Collect(t *testing.T) {
 		require.Equal(t, "3", string(savedTxs[2][0].Data))
 	})
 
+	t.Run("block finder", func(t *testing.T) {
+		finder := mockBlockFinder(func(ctx context.Context, height uint64) (Block, error) {
+			return Block{Height: height, Txs: []Tx{{Data: []byte(strconv.FormatUint(height, 10))}}}, nil
+		})
+
+		ch := make(chan []Tx)
+		saver := mockBlockSaver(func(ctx context.Context, height uint64, txs []Tx) error {
+			select {
+			case ch <- txs:
+			case <-ctx.Done():
+			}
+			return nil
+		})
+
+		collector := NewCollector(nopLog, finder, saver, time.Nanosecond)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			collector.Collect(context.Background())
+		}()
+
+		require.Equal(t, "1", string((<-ch)[0].Data))
+		require.Equal(t, "2", string((<-ch)[0].Data))
+
+		// Wait for Collect to return, so its goroutine does not outlive the test.
+		collector.Stop()
+		<-done
+	})
+
 	t.Run("find error", func(t *testing.T) {
 		ch := make(chan int)
 		finder := mockTxFinder(func(ctx context.Context, height uint64) ([]Tx, error) {
@@ -0,0 +1,418 @@
+package blockdb
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ExportFormat is a file format for exporting a test case.
+type ExportFormat string
+
+const (
+	// ExportJSONL exports one JSON object per line and record; see ExportRecord.
+	ExportJSONL ExportFormat = "jsonl"
+	// ExportCSV exports one CSV row per record; see ExportRecord for the columns.
+	ExportCSV ExportFormat = "csv"
+	// ExportHTML exports a standalone HTML report, with a timeline of the test case's packets.
+	ExportHTML ExportFormat = "html"
+)
+
+// ExportRecord is a record of an exported test case: the test case itself, one of its chains,
+// or a block, tx or event of a chain. Type tells which, and only the fields relevant to it are set.
+type ExportRecord struct {
+	Type string `json:"type"` // One of test_case, chain, block, tx or event.
+
+	// Test case.
+	Name   string `json:"name,omitempty"`
+	GitSha string `json:"git_sha,omitempty"`
+
+	// Chain, and the chain of a block, tx or event.
+	ChainID   string `json:"chain_id,omitempty"`
+	ChainType string `json:"chain_type,omitempty"`
+
+	// Block, and the block of a tx or event.
+	Height int64 `json:"height,omitempty"`
+	// Creation time of the test case or block, in RFC3339 format.
+	CreatedAt string `json:"created_at,omitempty"`
+
+	// Tx, and the tx of an event. TxIndex is the tx position within its block.
+	TxIndex *int            `json:"tx_index,omitempty"`
+	Tx      json.RawMessage `json:"tx,omitempty"`
+
+	// Event. EventIndex is the event position within its tx.
+	EventIndex *int              `json:"event_index,omitempty"`
+	EventType  string            `json:"event_type,omitempty"`
+	Attributes []ExportAttribute `json:"attributes,omitempty"`
+}
+
+// ExportAttribute is an event attribute of an ExportRecord.
+type ExportAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ExportTestCase writes the chains, blocks, txs and events of a test case to w in the format.
+func (q *Query) ExportTestCase(ctx context.Context, w io.Writer, testCaseID int64, format ExportFormat) error {
+	tc, err := q.exportTestCase(ctx, testCaseID)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case ExportJSONL:
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		for _, rec := range tc.records() {
+			if err := enc.Encode(rec); err != nil {
+				return err
+			}
+		}
+		return nil
+	case ExportCSV:
+		return writeExportCSV(w, tc.records())
+	case ExportHTML:
+		packets, err := q.PacketFlows(ctx, testCaseID)
+		if err != nil {
+			return fmt.Errorf("query packet flows: %w", err)
+		}
+		return writeExportHTML(w, tc, packets)
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+type exportedTestCase struct {
+	Name      string
+	GitSha    string
+	CreatedAt string
+	Chains    []*exportedChain
+}
+
+type exportedChain struct {
+	ChainID   string
+	ChainType string
+	Blocks    []*exportedBlock
+}
+
+type exportedBlock struct {
+	Height    int64
+	CreatedAt string
+	Txs       []*exportedTx
+}
+
+type exportedTx struct {
+	Data   []byte
+	Events []Event
+}
+
+// exportTestCase reads the test case from the database.
+func (q *Query) exportTestCase(ctx context.Context, testCaseID int64) (*exportedTestCase, error) {
+	tc := new(exportedTestCase)
+	row := q.db.QueryRowContext(ctx, `SELECT name, git_sha, created_at FROM test_case WHERE id = ?`, testCaseID)
+	if err := row.Scan(&tc.Name, &tc.GitSha, &tc.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("test case %d not found", testCaseID)
+		}
+		return nil, err
+	}
+
+	rows, err := q.db.QueryContext(ctx, `SELECT id, chain_id, chain_type FROM chain WHERE fk_test_id = ? ORDER BY chain_id ASC`, testCaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var chainPKeys []int64
+	for rows.Next() {
+		var (
+			pkey  int64
+			chain exportedChain
+		)
+		if err := rows.Scan(&pkey, &chain.ChainID, &chain.ChainType); err != nil {
+			return nil, err
+		}
+		chainPKeys = append(chainPKeys, pkey)
+		tc.Chains = append(tc.Chains, &chain)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, chain := range tc.Chains {
+		if err := q.exportBlocks(ctx, chainPKeys[i], chain); err != nil {
+			return nil, fmt.Errorf("export blocks of %s: %w", chain.ChainID, err)
+		}
+	}
+	return tc, nil
+}
+
+// exportBlocks reads the blocks of the chain, with their txs and events.
+func (q *Query) exportBlocks(ctx context.Context, chainPkey int64, chain *exportedChain) error {
+	rows, err := q.db.QueryContext(ctx, `SELECT block.height, block.created_at, tx.id, tx.data FROM block
+    LEFT JOIN tx ON tx.fk_block_id = block.id
+    WHERE block.fk_chain_id = ?
+    ORDER BY block.height ASC, tx.id ASC`, chainPkey)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	txs := make(map[int64]*exportedTx)
+	for rows.Next() {
+		var (
+			height    int64
+			createdAt string
+			txID      sql.NullInt64
+			data      []byte
+		)
+		if err := rows.Scan(&height, &createdAt, &txID, &data); err != nil {
+			return err
+		}
+		if n := len(chain.Blocks); n == 0 || chain.Blocks[n-1].Height != height {
+			chain.Blocks = append(chain.Blocks, &exportedBlock{Height: height, CreatedAt: createdAt})
+		}
+		if !txID.Valid {
+			continue
+		}
+		block := chain.Blocks[len(chain.Blocks)-1]
+		tx := &exportedTx{Data: data}
+		block.Txs = append(block.Txs, tx)
+		txs[txID.Int64] = tx
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = q.db.QueryContext(ctx, `SELECT tendermint_event.fk_tx_id, tendermint_event.id, tendermint_event.type, attr.key, attr.value
+    FROM tendermint_event
+    INNER JOIN tx ON tendermint_event.fk_tx_id = tx.id
+    INNER JOIN block ON tx.fk_block_id = block.id
+    LEFT JOIN tendermint_event_attr attr ON attr.fk_event_id = tendermint_event.id
+    WHERE block.fk_chain_id = ?
+    ORDER BY tendermint_event.id ASC, attr.id ASC`, chainPkey)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var lastEventID int64
+	for rows.Next() {
+		var (
+			txID, eventID int64
+			typ           string
+			key, value    sql.NullString
+		)
+		if err := rows.Scan(&txID, &eventID, &typ, &key, &value); err != nil {
+			return err
+		}
+		tx := txs[txID]
+		if eventID != lastEventID {
+			tx.Events = append(tx.Events, Event{Type: typ})
+			lastEventID = eventID
+		}
+		if key.Valid {
+			event := &tx.Events[len(tx.Events)-1]
+			event.Attributes = append(event.Attributes, EventAttribute{Key: key.String, Value: value.String})
+		}
+	}
+	return rows.Err()
+}
+
+// records flattens the test case into export records, in order.
+func (tc *exportedTestCase) records() []ExportRecord {
+	recs := []ExportRecord{{Type: "test_case", Name: tc.Name, GitSha: tc.GitSha, CreatedAt: tc.CreatedAt}}
+	for _, chain := range tc.Chains {
+		recs = append(recs, ExportRecord{Type: "chain", ChainID: chain.ChainID, ChainType: chain.ChainType})
+		for _, block := range chain.Blocks {
+			blockRec := ExportRecord{Type: "block", ChainID: chain.ChainID, Height: block.Height, CreatedAt: block.CreatedAt}
+			recs = append(recs, blockRec)
+			for i, tx := range block.Txs {
+				i := i
+				txRec := blockRec
+				txRec.Type = "tx"
+				txRec.TxIndex = &i
+				txRec.Tx = exportTxData(tx.Data)
+				recs = append(recs, txRec)
+				for j, event := range tx.Events {
+					j := j
+					eventRec := txRec
+					eventRec.Type = "event"
+					eventRec.Tx = nil
+					eventRec.EventIndex = &j
+					eventRec.EventType = event.Type
+					eventRec.Attributes = make([]ExportAttribute, len(event.Attributes))
+					for k, attr := range event.Attributes {
+						eventRec.Attributes[k] = ExportAttribute{Key: attr.Key, Value: attr.Value}
+					}
+					recs = append(recs, eventRec)
+				}
+			}
+		}
+	}
+	return recs
+}
+
+// exportTxData returns tx data as is if it is JSON, as for Tendermint transactions, or otherwise as a JSON string.
+func exportTxData(data []byte) json.RawMessage {
+	if json.Valid(data) {
+		return data
+	}
+	b, err := json.Marshal(string(data))
+	if err != nil {
+		// Marshaling a string cannot fail.
+		panic(err)
+	}
+	return b
+}
+
+var exportCSVHeader = []string{
+	"type", "name", "git_sha", "chain_id", "chain_type", "height", "created_at",
+	"tx_index", "tx", "event_index", "event_type", "attributes",
+}
+
+// writeExportCSV writes the records with the exportCSVHeader columns.
+// Event attributes are a JSON array of key and value objects.
+func writeExportCSV(w io.Writer, recs []ExportRecord) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportCSVHeader); err != nil {
+		return err
+	}
+	optInt := func(i *int) string {
+		if i == nil {
+			return ""
+		}
+		return strconv.Itoa(*i)
+	}
+	for _, rec := range recs {
+		var height, attrs string
+		if rec.Height != 0 {
+			height = strconv.FormatInt(rec.Height, 10)
+		}
+		if rec.Attributes != nil {
+			b, err := json.Marshal(rec.Attributes)
+			if err != nil {
+				return err
+			}
+			attrs = string(b)
+		}
+		if err := cw.Write([]string{
+			rec.Type, rec.Name, rec.GitSha, rec.ChainID, rec.ChainType, height, rec.CreatedAt,
+			optInt(rec.TxIndex), string(rec.Tx), optInt(rec.EventIndex), rec.EventType, attrs,
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+//go:embed export.html.tmpl
+var exportHTML string
+
+var exportHTMLTemplate = template.Must(template.New("export").Funcs(template.FuncMap{
+	"height": func(h sql.NullInt64) string {
+		if !h.Valid {
+			return ""
+		}
+		return strconv.FormatInt(h.Int64, 10)
+	},
+	"txJSON": txJSON,
+}).Parse(exportHTML))
+
+// exportedPacket is a packet in the HTML report's timeline.
+type exportedPacket struct {
+	PacketFlowResult
+	// Position and width of the packet's bar in the timeline, as a percentage of the test case's duration.
+	Offset, Width float64
+}
+
+func writeExportHTML(w io.Writer, tc *exportedTestCase, flows []PacketFlowResult) error {
+	// Blocks are saved when collected, so their creation time places the heights of each chain in time.
+	blockTimes := make(map[string]map[int64]time.Time)
+	var start, end time.Time
+	for _, chain := range tc.Chains {
+		times := make(map[int64]time.Time, len(chain.Blocks))
+		for _, block := range chain.Blocks {
+			t, err := time.Parse(time.RFC3339, block.CreatedAt)
+			if err != nil {
+				return fmt.Errorf("parse created at of %s block %d: %w", chain.ChainID, block.Height, err)
+			}
+			times[block.Height] = t
+			if start.IsZero() || t.Before(start) {
+				start = t
+			}
+			if t.After(end) {
+				end = t
+			}
+		}
+		blockTimes[chain.ChainID] = times
+	}
+	// Avoid dividing by zero when all blocks were saved within the same second.
+	span := end.Sub(start) + time.Second
+	percent := func(t time.Time) float64 {
+		return 100 * float64(t.Sub(start)) / float64(span)
+	}
+
+	packets := make([]exportedPacket, len(flows))
+	for i, flow := range flows {
+		p := exportedPacket{PacketFlowResult: flow}
+
+		// The packet spans from its first to its last known event, or to the end if it never completed.
+		var first, last time.Time
+		for _, ev := range []struct {
+			chainID string
+			height  sql.NullInt64
+		}{
+			{flow.SrcChainID, flow.SendHeight},
+			{flow.DstChainID, flow.RecvHeight},
+			{flow.DstChainID, flow.WriteAckHeight},
+			{flow.SrcChainID, flow.AckHeight},
+			{flow.SrcChainID, flow.TimeoutHeight},
+		} {
+			t, ok := blockTimes[ev.chainID][ev.height.Int64]
+			if !ev.height.Valid || !ok {
+				continue
+			}
+			if first.IsZero() || t.Before(first) {
+				first = t
+			}
+			if t.After(last) {
+				last = t
+			}
+		}
+		if !flow.Complete() {
+			last = end.Add(time.Second)
+		}
+		if !first.IsZero() {
+			p.Offset = percent(first)
+			// Keep a minimum width for the bar to be visible.
+			p.Width = percent(last.Add(time.Second)) - p.Offset
+			if p.Width > 100-p.Offset {
+				p.Width = 100 - p.Offset
+			}
+		}
+		packets[i] = p
+	}
+
+	return exportHTMLTemplate.Execute(w, struct {
+		TestCase *exportedTestCase
+		Packets  []exportedPacket
+	}{tc, packets})
+}
+
+// txJSON pretty prints tx data for the HTML report, if it is JSON.
+func txJSON(data []byte) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return string(data)
+	}
+	return buf.String()
+}
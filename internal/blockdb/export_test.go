@@ -0,0 +1,153 @@
+package blockdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func exportFixture(t *testing.T) *Query {
+	t.Helper()
+
+	ctx := context.Background()
+	db := migratedDB()
+	t.Cleanup(func() { _ = db.Close() })
+
+	tc, err := CreateTestCase(ctx, db, "TestExport", "abc123")
+	require.NoError(t, err)
+	chainA, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	chainB, err := tc.AddChain(ctx, "chain-b", "cosmos")
+	require.NoError(t, err)
+
+	packetEvent := func(typ, seq string) Event {
+		return Event{Type: typ, Attributes: []EventAttribute{
+			{Key: "packet_sequence", Value: seq},
+			{Key: "packet_src_port", Value: "transfer"},
+			{Key: "packet_src_channel", Value: "channel-0"},
+			{Key: "packet_dst_port", Value: "transfer"},
+			{Key: "packet_dst_channel", Value: "channel-1"},
+		}}
+	}
+	require.NoError(t, chainA.SaveBlock(ctx, 1, nil))
+	require.NoError(t, chainA.SaveBlock(ctx, 2, []Tx{
+		{Data: []byte(`{"tx":1}`), Events: []Event{packetEvent("send_packet", "1"), {Type: "empty"}}},
+		{Data: []byte(`not json`), Events: []Event{packetEvent("send_packet", "2")}},
+	}))
+	require.NoError(t, chainB.SaveBlock(ctx, 7, []Tx{
+		{Data: []byte(`{"tx":2}`), Events: []Event{packetEvent("recv_packet", "1")}},
+	}))
+	require.NoError(t, chainA.SaveBlock(ctx, 3, []Tx{
+		{Data: []byte(`{"tx":3}`), Events: []Event{packetEvent("acknowledge_packet", "1")}},
+	}))
+
+	return NewQuery(db)
+}
+
+func TestQuery_ExportTestCase(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("jsonl", func(t *testing.T) {
+		q := exportFixture(t)
+
+		var buf bytes.Buffer
+		require.NoError(t, q.ExportTestCase(ctx, &buf, 1, ExportJSONL))
+
+		var recs []ExportRecord
+		dec := json.NewDecoder(&buf)
+		for dec.More() {
+			var rec ExportRecord
+			require.NoError(t, dec.Decode(&rec))
+			recs = append(recs, rec)
+		}
+
+		var types []string
+		for _, rec := range recs {
+			types = append(types, rec.Type)
+		}
+		require.Equal(t, []string{
+			"test_case",
+			"chain", "block", "block", "tx", "event", "event", "tx", "event", "block", "tx", "event",
+			"chain", "block", "tx", "event",
+		}, types)
+
+		require.Equal(t, "TestExport", recs[0].Name)
+		require.Equal(t, "abc123", recs[0].GitSha)
+		require.NotEmpty(t, recs[0].CreatedAt)
+
+		require.Equal(t, "chain-a", recs[1].ChainID)
+		require.Equal(t, "cosmos", recs[1].ChainType)
+
+		tx := recs[4]
+		require.Equal(t, "chain-a", tx.ChainID)
+		require.EqualValues(t, 2, tx.Height)
+		require.Zero(t, *tx.TxIndex)
+		require.JSONEq(t, `{"tx":1}`, string(tx.Tx))
+
+		event := recs[5]
+		require.Equal(t, "send_packet", event.EventType)
+		require.EqualValues(t, 2, event.Height)
+		require.Zero(t, *event.TxIndex)
+		require.Zero(t, *event.EventIndex)
+		require.Empty(t, event.Tx)
+		require.Equal(t, ExportAttribute{Key: "packet_sequence", Value: "1"}, event.Attributes[0])
+		require.Len(t, event.Attributes, 5)
+
+		require.Equal(t, "empty", recs[6].EventType)
+		require.Equal(t, 1, *recs[6].EventIndex)
+		require.Empty(t, recs[6].Attributes)
+
+		// Tx data that is not JSON is a string.
+		require.Equal(t, 1, *recs[7].TxIndex)
+		require.Equal(t, `"not json"`, string(recs[7].Tx))
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		q := exportFixture(t)
+
+		var buf bytes.Buffer
+		require.NoError(t, q.ExportTestCase(ctx, &buf, 1, ExportCSV))
+
+		rows, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 17)
+		require.Equal(t, exportCSVHeader, rows[0])
+		require.Equal(t, []string{"tx", "", "", "chain-a", "", "2", rows[3][6], "0", `{"tx":1}`, "", "", ""}, rows[5])
+
+		event := rows[6]
+		require.Equal(t, "event", event[0])
+		require.Equal(t, "0", event[9])
+		require.Equal(t, "send_packet", event[10])
+		require.Contains(t, event[11], `{"key":"packet_sequence","value":"1"}`)
+	})
+
+	t.Run("html", func(t *testing.T) {
+		q := exportFixture(t)
+
+		var buf bytes.Buffer
+		require.NoError(t, q.ExportTestCase(ctx, &buf, 1, ExportHTML))
+
+		html := buf.String()
+		require.Contains(t, html, "<h1>TestExport</h1>")
+		require.Contains(t, html, "acknowledged")
+		// Packet 2 was never received.
+		require.Equal(t, 1, strings.Count(html, `<tr class="incomplete">`))
+		require.Contains(t, html, `class="bar" style="left: `)
+		require.NotContains(t, html, "ZgotmplZ")
+		require.Contains(t, html, "&#34;tx&#34;: 1")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		q := exportFixture(t)
+
+		require.ErrorContains(t, q.ExportTestCase(ctx, new(bytes.Buffer), 2, ExportJSONL), "test case 2 not found")
+		require.ErrorContains(t, q.ExportTestCase(ctx, new(bytes.Buffer), 1, "xml"), "unknown export format")
+	})
+}
@@ -21,6 +21,10 @@ import (
 //	│                    │          │                    │         │                    │          │                    │
 //	└────────────────────┘          └────────────────────┘         └────────────────────┘          └────────────────────┘
 //
+// A block also has many commit signatures and many begin and end block events, each with many attributes.
+// A test case also has many test results, one for the test and each of its subtests, and many relayer execs,
+// one per relayer command run by the test.
+//
 // The gitSha ensures we can trace back to the version of the codebase that produced the schema.
 // Warning: Typical best practice wraps each migration step into its own transaction. For simplicity given
 // this is an embedded database, we omit transactions.
@@ -49,7 +53,6 @@ func Migrate(db *sql.DB, gitSha string) error {
 		return fmt.Errorf("pragma journal_mode: %w", err)
 	}
 
-	// TODO(nix 05-27-2022): Appropriate indexes?
 	_, err = db.Exec(`PRAGMA foreign_keys = ON`)
 	if err != nil {
 		return fmt.Errorf("pragma foreign_keys: %w", err)
@@ -148,6 +151,100 @@ ON CONFLICT(git_sha) DO UPDATE SET git_sha=git_sha`, nowRFC3339(), gitSha)
 		return fmt.Errorf("create table tendermint_event: %w", err)
 	}
 
+	// Block header metadata is NULL for blocks saved from their transactions only.
+	for _, col := range []string{"block_time", "proposer", "app_hash", "validators_hash"} {
+		_, err = tx.Exec(fmt.Sprintf(`ALTER TABLE block ADD COLUMN %s TEXT`, col))
+		if errIgnoreDuplicateColumn(err, col) != nil {
+			return fmt.Errorf("alter table block add %s: %w", col, err)
+		}
+	}
+
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS block_signature (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    validator_address TEXT NOT NULL,
+    flag TEXT NOT NULL CHECK ( flag IN ('absent', 'commit', 'nil') ),
+    timestamp TEXT NOT NULL,
+    signature TEXT NOT NULL, -- hex encoded
+    fk_block_id INTEGER,
+    FOREIGN KEY(fk_block_id) REFERENCES block(id) ON DELETE CASCADE
+)`)
+	if err != nil {
+		return fmt.Errorf("create table block_signature: %w", err)
+	}
+
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS block_event (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    type TEXT NOT NULL CHECK (length(type) > 0),
+    phase TEXT NOT NULL CHECK ( phase IN ('begin_block', 'end_block') ),
+    fk_block_id INTEGER,
+    FOREIGN KEY(fk_block_id) REFERENCES block(id) ON DELETE CASCADE
+)`)
+	if err != nil {
+		return fmt.Errorf("create table block_event: %w", err)
+	}
+
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS block_event_attr (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    key TEXT NOT NULL CHECK (length(key) > 0),
+    value TEXT NOT NULL,
+    fk_event_id INTEGER,
+    FOREIGN KEY(fk_event_id) REFERENCES block_event(id) ON DELETE CASCADE
+)`)
+	if err != nil {
+		return fmt.Errorf("create table block_event_attr: %w", err)
+	}
+
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS test_result (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL CHECK ( length(name) > 0 ),
+    outcome TEXT NOT NULL CHECK ( outcome IN ('pass', 'fail', 'skip') ),
+    started_at TEXT NOT NULL CHECK (length(started_at) > 0),
+    finished_at TEXT NOT NULL CHECK (length(finished_at) > 0),
+    duration_ms INTEGER NOT NULL,
+    fk_test_id INTEGER,
+    FOREIGN KEY(fk_test_id) REFERENCES test_case(id) ON DELETE CASCADE,
+    UNIQUE(name,fk_test_id)
+)`)
+	if err != nil {
+		return fmt.Errorf("create table test_result: %w", err)
+	}
+
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS relayer_exec (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    test_name TEXT NOT NULL CHECK ( length(test_name) > 0 ),
+    container_name TEXT NOT NULL,
+    command TEXT NOT NULL, -- JSON array of the command's arguments
+    stdout TEXT NOT NULL,
+    stderr TEXT NOT NULL,
+    exit_code INTEGER NOT NULL,
+    error TEXT NOT NULL,
+    started_at TEXT NOT NULL CHECK (length(started_at) > 0),
+    finished_at TEXT NOT NULL CHECK (length(finished_at) > 0),
+    fk_test_id INTEGER,
+    FOREIGN KEY(fk_test_id) REFERENCES test_case(id) ON DELETE CASCADE
+)`)
+	if err != nil {
+		return fmt.Errorf("create table relayer_exec: %w", err)
+	}
+
+	// Indexes for joining a test case's blocks and txs, and for searching txs by event.
+	for _, index := range []string{
+		`CREATE INDEX IF NOT EXISTS idx_block_fk_chain_id ON block(fk_chain_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_tx_fk_block_id ON tx(fk_block_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_tendermint_event_fk_tx_id ON tendermint_event(fk_tx_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_tendermint_event_type ON tendermint_event(type)`,
+		`CREATE INDEX IF NOT EXISTS idx_tendermint_event_attr_fk_event_id ON tendermint_event_attr(fk_event_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_tendermint_event_attr_key_value ON tendermint_event_attr(key, value)`,
+		`CREATE INDEX IF NOT EXISTS idx_tendermint_event_attr_value ON tendermint_event_attr(value)`,
+		`CREATE INDEX IF NOT EXISTS idx_block_signature_fk_block_id ON block_signature(fk_block_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_block_event_fk_block_id ON block_event(fk_block_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_block_event_attr_fk_event_id ON block_event_attr(fk_event_id)`,
+	} {
+		if _, err := tx.Exec(index); err != nil {
+			return fmt.Errorf("create index: %w", err)
+		}
+	}
+
 	// Creating views should be last migration step.
 	if err := upsertViews(tx); err != nil {
 		// Error already wrapped.
@@ -243,6 +340,40 @@ FROM v_tx_flattened, json_each(v_tx_flattened.tx, "$.body.messages")
 		return fmt.Errorf("create v_cosmos_messages view: %w", err)
 	}
 
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_substrate_extrinsics`)
+	if err != nil {
+		return fmt.Errorf("drop old v_substrate_extrinsics view: %w", err)
+	}
+	_, err = tx.Exec(`CREATE VIEW v_substrate_extrinsics AS
+SELECT
+  test_case_id
+  , test_case_name
+  , chain_kid
+  , chain_id
+  , block_id
+  , block_height
+  , tx_id
+  , ROW_NUMBER() OVER (PARTITION BY block_id ORDER BY tx_id) - 1 as extrinsic_n -- extrinsic position within the block
+  , json_extract(tx, "$.pallet") as pallet
+  , json_extract(tx, "$.call") as call
+  , json_extract(tx, "$.signer") as signer
+  , CASE
+      WHEN EXISTS(SELECT 1 FROM tendermint_event WHERE fk_tx_id = tx_id AND type = 'System.ExtrinsicFailed') THEN 'failed'
+      WHEN EXISTS(SELECT 1 FROM tendermint_event WHERE fk_tx_id = tx_id AND type = 'System.ExtrinsicSuccess') THEN 'success'
+    END as outcome
+  , (SELECT GROUP_CONCAT(type, ', ') FROM (
+      SELECT type FROM tendermint_event
+      WHERE fk_tx_id = tx_id AND type NOT IN ('System.ExtrinsicSuccess', 'System.ExtrinsicFailed')
+      ORDER BY id
+    )) as events
+  , tx as raw
+FROM v_tx_flattened
+WHERE chain_type = 'polkadot' AND json_valid(tx)
+`)
+	if err != nil {
+		return fmt.Errorf("create v_substrate_extrinsics view: %w", err)
+	}
+
 	_, err = tx.Exec(`DROP VIEW IF EXISTS v_tx_agg`)
 	if err != nil {
 		return fmt.Errorf("drop old v_tx_agg view: %w", err)
@@ -269,6 +400,59 @@ FROM v_tx_flattened, json_each(v_tx_flattened.tx, "$.body.messages")
 		return fmt.Errorf("create v_tx_agg view: %w", err)
 	}
 
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_test_result`)
+	if err != nil {
+		return fmt.Errorf("drop old v_test_result view: %w", err)
+	}
+
+	_, err = tx.Exec(`CREATE VIEW v_test_result AS
+    SELECT
+       test_case.id AS test_case_id
+     , test_case.created_at AS test_case_created_at
+     , test_case.name AS test_case_name
+     , test_case.git_sha AS test_case_git_sha
+     , test_result.name AS name
+     , test_result.outcome AS outcome
+     , test_result.started_at AS started_at
+     , test_result.finished_at AS finished_at
+     , test_result.duration_ms AS duration_ms
+    FROM test_result
+    JOIN test_case ON test_result.fk_test_id = test_case.id
+`)
+	if err != nil {
+		return fmt.Errorf("create v_test_result view: %w", err)
+	}
+
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_packet_event`)
+	if err != nil {
+		return fmt.Errorf("drop old v_packet_event view: %w", err)
+	}
+
+	// One row per IBC packet event, with the packet's identifying attributes as columns.
+	_, err = tx.Exec(`CREATE VIEW v_packet_event AS
+    SELECT
+       v_tx_flattened.test_case_id AS test_case_id
+     , v_tx_flattened.chain_kid AS chain_kid
+     , v_tx_flattened.chain_id AS chain_id
+     , v_tx_flattened.block_height AS block_height
+     , v_tx_flattened.tx_id AS tx_id
+     , tendermint_event.id AS event_id
+     , tendermint_event.type AS type
+     , CAST(MAX(CASE WHEN attr.key = 'packet_sequence' THEN attr.value END) AS INTEGER) AS sequence
+     , MAX(CASE WHEN attr.key = 'packet_src_port' THEN attr.value END) AS src_port
+     , MAX(CASE WHEN attr.key = 'packet_src_channel' THEN attr.value END) AS src_channel
+     , MAX(CASE WHEN attr.key = 'packet_dst_port' THEN attr.value END) AS dst_port
+     , MAX(CASE WHEN attr.key = 'packet_dst_channel' THEN attr.value END) AS dst_channel
+    FROM tendermint_event
+    JOIN tendermint_event_attr attr ON attr.fk_event_id = tendermint_event.id
+    JOIN v_tx_flattened ON tendermint_event.fk_tx_id = v_tx_flattened.tx_id
+    WHERE tendermint_event.type IN ('send_packet', 'recv_packet', 'write_acknowledgement', 'acknowledge_packet', 'timeout_packet')
+    GROUP BY tendermint_event.id
+`)
+	if err != nil {
+		return fmt.Errorf("create v_packet_event view: %w", err)
+	}
+
 	return nil
 }
 
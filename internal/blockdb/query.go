@@ -3,7 +3,10 @@ package blockdb
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -169,7 +172,59 @@ func (q *Query) CosmosMessages(ctx context.Context, chainPkey int64) ([]CosmosMe
 	return results, nil
 }
 
+// SubstrateExtrinsicResult is an extrinsic of a Substrate chain, i.e. a Polkadot relay chain or parachain.
+type SubstrateExtrinsicResult struct {
+	Height int64
+	Index  int // Position within the block.
+
+	// The pallet and call are null for extrinsics that could not be decoded.
+	Pallet sql.NullString
+	Call   sql.NullString
+	Signer sql.NullString // Null for unsigned extrinsics, such as inherents.
+
+	Outcome sql.NullString // Either success or failed, from the extrinsic's System events.
+	Events  sql.NullString // Comma separated runtime events emitted by the extrinsic, other than its outcome.
+}
+
+// SubstrateExtrinsics returns a summary of the extrinsics of a Substrate chain, with their runtime events.
+// chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
+func (q *Query) SubstrateExtrinsics(ctx context.Context, chainPkey int64) ([]SubstrateExtrinsicResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT
+        block_height
+        , extrinsic_n
+        , pallet
+        , call
+        , signer
+        , outcome
+        , events
+    FROM v_substrate_extrinsics
+    WHERE chain_kid = ?
+    ORDER BY block_height ASC, extrinsic_n ASC`, chainPkey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var results []SubstrateExtrinsicResult
+	for rows.Next() {
+		var res SubstrateExtrinsicResult
+		if err = rows.Scan(
+			&res.Height,
+			&res.Index,
+			&res.Pallet,
+			&res.Call,
+			&res.Signer,
+			&res.Outcome,
+			&res.Events,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
 type TxResult struct {
+	ID     int64 // tx primary key
 	Height int64
 	Tx     []byte
 }
@@ -177,7 +232,7 @@ type TxResult struct {
 // Transactions returns TxResults only for blocks with transactions present.
 // chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
 func (q *Query) Transactions(ctx context.Context, chainPkey int64) ([]TxResult, error) {
-	rows, err := q.db.QueryContext(ctx, `SELECT block.height, tx.data FROM tx 
+	rows, err := q.db.QueryContext(ctx, `SELECT tx.id, block.height, tx.data FROM tx 
     INNER JOIN block on tx.fk_block_id = block.id
     INNER JOIN chain on block.fk_chain_id = chain.id
     WHERE chain.id = ?
@@ -190,11 +245,475 @@ func (q *Query) Transactions(ctx context.Context, chainPkey int64) ([]TxResult,
 	var results []TxResult
 	for rows.Next() {
 		var res TxResult
-		if err := rows.Scan(&res.Height, &res.Tx); err != nil {
+		if err := rows.Scan(&res.ID, &res.Height, &res.Tx); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+// BlockResult is a block's header metadata, with a count of its commit signatures.
+// The header fields are empty for blocks saved from their transactions only.
+type BlockResult struct {
+	Height         int64
+	Time           time.Time // Always set to user's local time zone.
+	Proposer       string
+	AppHash        string
+	ValidatorsHash string
+	Signatures     int // Number of validators who signed the block's commit.
+	Absent         int // Number of validators whose signature is absent from the block's commit.
+	TxTotal        int
+}
+
+// Blocks returns all saved blocks of a chain, including blocks without transactions.
+// chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
+func (q *Query) Blocks(ctx context.Context, chainPkey int64) ([]BlockResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT
+    block.height
+  , block.block_time
+  , COALESCE(block.proposer, '')
+  , COALESCE(block.app_hash, '')
+  , COALESCE(block.validators_hash, '')
+  , (SELECT COUNT(*) FROM block_signature WHERE fk_block_id = block.id AND flag = 'commit')
+  , (SELECT COUNT(*) FROM block_signature WHERE fk_block_id = block.id AND flag = 'absent')
+  , (SELECT COUNT(*) FROM tx WHERE fk_block_id = block.id)
+FROM block
+WHERE block.fk_chain_id = ?
+ORDER BY block.height ASC`, chainPkey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []BlockResult
+	for rows.Next() {
+		var (
+			res       BlockResult
+			blockTime sql.NullString
+		)
+		if err := rows.Scan(&res.Height, &blockTime, &res.Proposer, &res.AppHash, &res.ValidatorsHash,
+			&res.Signatures, &res.Absent, &res.TxTotal); err != nil {
+			return nil, err
+		}
+		if blockTime.Valid {
+			res.Time, err = timeToLocal(blockTime.String)
+			if err != nil {
+				return nil, err
+			}
+		}
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+// TxSearchResult is a transaction found by SearchTxs.
+type TxSearchResult struct {
+	ChainPKey int64  // chain primary key
+	ChainID   string // E.g. osmosis-1001
+	Height    int64
+	TxID      int64 // tx primary key, see TxResult.ID
+	Tx        []byte
+}
+
+// SearchTxs returns the transactions of a test case, across all its chains, with an event matching term.
+// The term is either:
+//   - an attribute key and value as key=value, e.g. packet_sequence=5
+//   - an event type, e.g. send_packet
+//   - an attribute value, e.g. a sender address, or a message type URL such as /ibc.core.channel.v1.MsgRecvPacket
+//     which Cosmos chains report as the action of the message event.
+//
+// Results are ordered by chain ID, height and position within the block.
+func (q *Query) SearchTxs(ctx context.Context, testCaseID int64, term string, limit int) ([]TxSearchResult, error) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return nil, nil
+	}
+
+	var (
+		match string
+		args  []any
+	)
+	if key, value, ok := strings.Cut(term, "="); ok {
+		match = `SELECT event.fk_tx_id FROM tendermint_event_attr attr
+        JOIN tendermint_event event ON attr.fk_event_id = event.id
+        WHERE attr.key = ? AND attr.value = ?`
+		args = []any{strings.TrimSpace(key), strings.TrimSpace(value)}
+	} else {
+		match = `SELECT fk_tx_id FROM tendermint_event WHERE type = ?
+        UNION
+        SELECT event.fk_tx_id FROM tendermint_event_attr attr
+        JOIN tendermint_event event ON attr.fk_event_id = event.id
+        WHERE attr.value = ?`
+		args = []any{term, term}
+	}
+	args = append([]any{testCaseID}, append(args, limit)...)
+
+	rows, err := q.db.QueryContext(ctx, `SELECT chain.id, chain.chain_id, block.height, tx.id, tx.data FROM tx
+    INNER JOIN block ON tx.fk_block_id = block.id
+    INNER JOIN chain ON block.fk_chain_id = chain.id
+    WHERE chain.fk_test_id = ? AND tx.id IN (`+match+`)
+    ORDER BY chain.chain_id ASC, block.height ASC, tx.id ASC LIMIT ?`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []TxSearchResult
+	for rows.Next() {
+		var res TxSearchResult
+		if err := rows.Scan(&res.ChainPKey, &res.ChainID, &res.Height, &res.TxID, &res.Tx); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// TestOutcomeResult is the result of a test, or subtest, run by a single test case.
+type TestOutcomeResult struct {
+	TestCaseID   int64
+	TestCaseName string
+	GitSha       string // Git commit that ran the test.
+	Name         string // The test's full name, e.g. TestConformance/gaia/rly.
+	Outcome      string // One of OutcomePass, OutcomeFail or OutcomeSkip.
+	// Always set to user's local time zone.
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Duration   time.Duration
+}
+
+// TestFailures returns the failed tests that finished at or after since, most recent first.
+func (q *Query) TestFailures(ctx context.Context, since time.Time, limit int) ([]TestOutcomeResult, error) {
+	return q.testOutcomes(ctx, `WHERE outcome = ? AND finished_at >= ?
+    ORDER BY finished_at DESC, test_case_id DESC, name ASC LIMIT ?`, OutcomeFail, timeRFC3339(since), limit)
+}
+
+// TestResults returns the results of the tests run by a test case, in the order they finished.
+func (q *Query) TestResults(ctx context.Context, testCaseID int64) ([]TestOutcomeResult, error) {
+	return q.testOutcomes(ctx, `WHERE test_case_id = ? ORDER BY finished_at ASC, name ASC`, testCaseID)
+}
+
+func (q *Query) testOutcomes(ctx context.Context, where string, args ...any) ([]TestOutcomeResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT
+        test_case_id, test_case_name, test_case_git_sha, name, outcome, started_at, finished_at, duration_ms
+    FROM v_test_result `+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []TestOutcomeResult
+	for rows.Next() {
+		var (
+			res                   TestOutcomeResult
+			startedAt, finishedAt string
+			durationMs            int64
+		)
+		if err := rows.Scan(
+			&res.TestCaseID,
+			&res.TestCaseName,
+			&res.GitSha,
+			&res.Name,
+			&res.Outcome,
+			&startedAt,
+			&finishedAt,
+			&durationMs,
+		); err != nil {
 			return nil, err
 		}
+		if res.StartedAt, err = timeToLocal(startedAt); err != nil {
+			return nil, fmt.Errorf("parse startedAt: %w", err)
+		}
+		if res.FinishedAt, err = timeToLocal(finishedAt); err != nil {
+			return nil, fmt.Errorf("parse finishedAt: %w", err)
+		}
+		res.Duration = time.Duration(durationMs) * time.Millisecond
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// TestHistoryResult summarizes the outcomes of a test across test cases.
+type TestHistoryResult struct {
+	Name     string
+	Runs     int // Number of test cases that ran the test, including skips.
+	Failures int
+	Skips    int
+	// Time of the most recent failure, zero if the test never failed.
+	// Always set to user's local time zone.
+	LastFailedAt time.Time
+}
+
+// Flaky reports whether the test both failed and passed.
+func (r TestHistoryResult) Flaky() bool {
+	return r.Failures > 0 && r.Failures < r.Runs-r.Skips
+}
+
+// TestHistory summarizes the outcomes of each test that finished at or after since,
+// the most failing tests first, to spot flaky tests across runs.
+func (q *Query) TestHistory(ctx context.Context, since time.Time) ([]TestHistoryResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT
+        name
+        , COUNT(*) AS runs
+        , SUM(outcome = ?) AS failures
+        , SUM(outcome = ?) AS skips
+        , COALESCE(MAX(CASE WHEN outcome = ? THEN finished_at END), '') AS last_failed_at
+    FROM v_test_result
+    WHERE finished_at >= ?
+    GROUP BY name
+    ORDER BY failures DESC, name ASC`, OutcomeFail, OutcomeSkip, OutcomeFail, timeRFC3339(since))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []TestHistoryResult
+	for rows.Next() {
+		var (
+			res          TestHistoryResult
+			lastFailedAt string
+		)
+		if err := rows.Scan(&res.Name, &res.Runs, &res.Failures, &res.Skips, &lastFailedAt); err != nil {
+			return nil, err
+		}
+		if lastFailedAt != "" {
+			if res.LastFailedAt, err = timeToLocal(lastFailedAt); err != nil {
+				return nil, fmt.Errorf("parse lastFailedAt: %w", err)
+			}
+		}
 		results = append(results, res)
 	}
+	return results, nil
+}
+
+// RelayerExecResult is a relayer command run by a test case.
+type RelayerExecResult struct {
+	TestName      string // The test, or subtest, that ran the command.
+	ContainerName string
+	Command       []string
+	Stdout        string
+	Stderr        string
+	ExitCode      int
+	Error         string
+	// Always set to user's local time zone.
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// RelayerExecs returns the relayer commands run by a test case, in the order they were reported.
+func (q *Query) RelayerExecs(ctx context.Context, testCaseID int64) ([]RelayerExecResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT
+        test_name, container_name, command, stdout, stderr, exit_code, error, started_at, finished_at
+    FROM relayer_exec
+    WHERE fk_test_id = ?
+    ORDER BY id ASC`, testCaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
+	var results []RelayerExecResult
+	for rows.Next() {
+		var (
+			res                        RelayerExecResult
+			cmd, startedAt, finishedAt string
+		)
+		if err := rows.Scan(
+			&res.TestName,
+			&res.ContainerName,
+			&cmd,
+			&res.Stdout,
+			&res.Stderr,
+			&res.ExitCode,
+			&res.Error,
+			&startedAt,
+			&finishedAt,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(cmd), &res.Command); err != nil {
+			return nil, fmt.Errorf("unmarshal command: %w", err)
+		}
+		if res.StartedAt, err = timeToLocal(startedAt); err != nil {
+			return nil, fmt.Errorf("parse startedAt: %w", err)
+		}
+		if res.FinishedAt, err = timeToLocal(finishedAt); err != nil {
+			return nil, fmt.Errorf("parse finishedAt: %w", err)
+		}
+		results = append(results, res)
+	}
 	return results, nil
 }
+
+// PacketFlowResult is the lifecycle of an IBC packet across the chains of a test case.
+// Heights are null if the chain did not emit the corresponding event, or its block was not saved.
+type PacketFlowResult struct {
+	Sequence uint64
+
+	SrcChainID string // Empty if the packet was never sent, nor acknowledged or timed out, by a saved chain.
+	SrcPort    string
+	SrcChannel string
+
+	DstChainID string // Empty if the packet was never received by a saved chain.
+	DstPort    string
+	DstChannel string
+
+	SendHeight     sql.NullInt64
+	RecvHeight     sql.NullInt64
+	WriteAckHeight sql.NullInt64
+	AckHeight      sql.NullInt64
+	TimeoutHeight  sql.NullInt64
+}
+
+// Complete reports whether the packet was acknowledged or timed out on the sending chain.
+func (r PacketFlowResult) Complete() bool {
+	return r.AckHeight.Valid || r.TimeoutHeight.Valid
+}
+
+// Status is the furthest step the packet reached in its lifecycle.
+func (r PacketFlowResult) Status() string {
+	switch {
+	case r.AckHeight.Valid:
+		return "acknowledged"
+	case r.TimeoutHeight.Valid:
+		return "timed out"
+	case r.WriteAckHeight.Valid:
+		return "ack pending"
+	case r.RecvHeight.Valid:
+		return "received"
+	case r.SendHeight.Valid:
+		return "sent"
+	default:
+		return "unknown"
+	}
+}
+
+type packetEvent struct {
+	chainID  string
+	height   int64
+	typ      string
+	sequence uint64
+	srcPort, srcChannel,
+	dstPort, dstChannel string
+}
+
+// PacketFlows returns the lifecycle of each packet sent on any chain of a test case, joining its
+// send_packet, recv_packet, write_acknowledgement, acknowledge_packet and timeout_packet events by
+// sequence, ports and channels. Results are ordered by source chain, port, channel and sequence.
+func (q *Query) PacketFlows(ctx context.Context, testCaseID int64) ([]PacketFlowResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT
+        chain_id, block_height, type, sequence, src_port, src_channel, dst_port, dst_channel
+    FROM v_packet_event
+    WHERE test_case_id = ? AND sequence IS NOT NULL
+    ORDER BY block_height ASC, event_id ASC`, testCaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []packetEvent
+	for rows.Next() {
+		var (
+			e                                        packetEvent
+			srcPort, srcChannel, dstPort, dstChannel sql.NullString
+		)
+		if err := rows.Scan(&e.chainID, &e.height, &e.typ, &e.sequence, &srcPort, &srcChannel, &dstPort, &dstChannel); err != nil {
+			return nil, err
+		}
+		e.srcPort, e.srcChannel, e.dstPort, e.dstChannel = srcPort.String, srcChannel.String, dstPort.String, dstChannel.String
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return joinPacketEvents(events), nil
+}
+
+// joinPacketEvents joins packet events into flows.
+// Packet identifiers are only unique per chain, so events emitted by the sending chain are joined first,
+// then those emitted by the receiving chain join the flow of a matching packet sent by another chain.
+func joinPacketEvents(events []packetEvent) []PacketFlowResult {
+	type packetKey struct {
+		sequence                                 uint64
+		srcPort, srcChannel, dstPort, dstChannel string
+	}
+	var flows []*PacketFlowResult
+	byKey := make(map[packetKey][]*PacketFlowResult)
+	flow := func(e packetEvent, srcChainID, dstChainID string) *PacketFlowResult {
+		key := packetKey{e.sequence, e.srcPort, e.srcChannel, e.dstPort, e.dstChannel}
+		candidates := byKey[key]
+		for _, f := range candidates {
+			if (srcChainID != "" && f.SrcChainID == srcChainID) || (dstChainID != "" && f.DstChainID == dstChainID) {
+				return f
+			}
+		}
+		if dstChainID != "" {
+			// First event of the receiving chain, for a packet sent by another chain.
+			for _, f := range candidates {
+				if f.DstChainID == "" && f.SrcChainID != dstChainID {
+					f.DstChainID = dstChainID
+					return f
+				}
+			}
+		}
+		f := &PacketFlowResult{
+			Sequence:   e.sequence,
+			SrcChainID: srcChainID,
+			SrcPort:    e.srcPort,
+			SrcChannel: e.srcChannel,
+			DstChainID: dstChainID,
+			DstPort:    e.dstPort,
+			DstChannel: e.dstChannel,
+		}
+		flows = append(flows, f)
+		byKey[key] = append(byKey[key], f)
+		return f
+	}
+	setHeight := func(h *sql.NullInt64, height int64) {
+		if !h.Valid {
+			*h = sql.NullInt64{Int64: height, Valid: true}
+		}
+	}
+
+	for _, e := range events {
+		switch e.typ {
+		case "send_packet":
+			setHeight(&flow(e, e.chainID, "").SendHeight, e.height)
+		case "acknowledge_packet":
+			setHeight(&flow(e, e.chainID, "").AckHeight, e.height)
+		case "timeout_packet":
+			setHeight(&flow(e, e.chainID, "").TimeoutHeight, e.height)
+		}
+	}
+	for _, e := range events {
+		switch e.typ {
+		case "recv_packet":
+			setHeight(&flow(e, "", e.chainID).RecvHeight, e.height)
+		case "write_acknowledgement":
+			setHeight(&flow(e, "", e.chainID).WriteAckHeight, e.height)
+		}
+	}
+
+	sort.SliceStable(flows, func(i, j int) bool {
+		a, b := flows[i], flows[j]
+		if a.SrcChainID != b.SrcChainID {
+			return a.SrcChainID < b.SrcChainID
+		}
+		if a.SrcPort != b.SrcPort {
+			return a.SrcPort < b.SrcPort
+		}
+		if a.SrcChannel != b.SrcChannel {
+			return a.SrcChannel < b.SrcChannel
+		}
+		return a.Sequence < b.Sequence
+	})
+	results := make([]PacketFlowResult, len(flows))
+	for i, f := range flows {
+		results[i] = *f
+	}
+	return results
+}
@@ -2,8 +2,10 @@ package blockdb
 
 import (
 	"context"
+	"database/sql"
 	_ "embed"
 	"encoding/json"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -193,6 +195,7 @@ func TestQuery_Transactions(t *testing.T) {
 
 		require.EqualValues(t, 12, results[0].Height)
 		require.Equal(t, "1", string(results[0].Tx))
+		require.NotZero(t, results[0].ID)
 
 		require.EqualValues(t, 14, results[1].Height)
 		require.Equal(t, "2", string(results[1].Tx))
@@ -216,3 +219,322 @@ func TestQuery_Transactions(t *testing.T) {
 		require.Len(t, results, 0)
 	})
 }
+
+func TestQuery_Blocks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+
+	blockTime := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, chain.SaveBlock(ctx, 1, []Tx{{Data: []byte(`1`)}}))
+	require.NoError(t, chain.SaveFullBlock(ctx, Block{
+		Height:         2,
+		Time:           blockTime,
+		Proposer:       "AABB",
+		AppHash:        "CCDD",
+		ValidatorsHash: "EEFF",
+		Signatures: []CommitSig{
+			{ValidatorAddress: "AABB", Flag: SignatureCommit},
+			{ValidatorAddress: "1122", Flag: SignatureCommit},
+			{ValidatorAddress: "3344", Flag: SignatureAbsent},
+		},
+	}))
+
+	results, err := NewQuery(db).Blocks(ctx, chain.id)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	require.Equal(t, BlockResult{Height: 1, TxTotal: 1}, results[0])
+	require.Equal(t, BlockResult{
+		Height:         2,
+		Time:           blockTime.In(time.Local),
+		Proposer:       "AABB",
+		AppHash:        "CCDD",
+		ValidatorsHash: "EEFF",
+		Signatures:     2,
+		Absent:         1,
+	}, results[1])
+}
+
+func TestQuery_SubstrateExtrinsics(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "parachain", "polkadot")
+	require.NoError(t, err)
+	cosmosChain, err := tc.AddChain(ctx, "gaia", "cosmos")
+	require.NoError(t, err)
+
+	require.NoError(t, chain.SaveBlock(ctx, 3, []Tx{
+		{
+			Data:   []byte(`{"pallet":"Timestamp","call":"set","args":{"now":1682942400000}}`),
+			Events: []Event{{Type: "System.ExtrinsicSuccess"}},
+		},
+		{
+			Data: []byte(`{"pallet":"Ibc","call":"transfer","signer":"0xd435","args":{}}`),
+			Events: []Event{
+				{Type: "Ibc.TokenTransferInitiated"},
+				{Type: "send_packet"},
+				{Type: "System.ExtrinsicSuccess"},
+			},
+		},
+		{Data: []byte(`{"data":"0x1234"}`)},
+	}))
+	require.NoError(t, chain.SaveBlock(ctx, 4, []Tx{
+		{
+			Data:   []byte(`{"pallet":"Ibc","call":"deliver","signer":"0xd435","args":{}}`),
+			Events: []Event{{Type: "System.ExtrinsicFailed"}},
+		},
+	}))
+	require.NoError(t, cosmosChain.SaveBlock(ctx, 3, []Tx{{Data: []byte(`{"body":{"messages":[]}}`)}}))
+
+	results, err := NewQuery(db).SubstrateExtrinsics(ctx, chain.id)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	str := func(s string) sql.NullString { return sql.NullString{String: s, Valid: true} }
+	require.Equal(t, SubstrateExtrinsicResult{
+		Height: 3, Index: 0, Pallet: str("Timestamp"), Call: str("set"), Outcome: str("success"),
+	}, results[0])
+	require.Equal(t, SubstrateExtrinsicResult{
+		Height: 3, Index: 1, Pallet: str("Ibc"), Call: str("transfer"), Signer: str("0xd435"),
+		Outcome: str("success"), Events: str("Ibc.TokenTransferInitiated, send_packet"),
+	}, results[1])
+	require.Equal(t, SubstrateExtrinsicResult{Height: 3, Index: 2}, results[2])
+	require.Equal(t, SubstrateExtrinsicResult{
+		Height: 4, Index: 0, Pallet: str("Ibc"), Call: str("deliver"), Signer: str("0xd435"), Outcome: str("failed"),
+	}, results[3])
+
+	results, err = NewQuery(db).SubstrateExtrinsics(ctx, cosmosChain.id)
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
+
+func TestQuery_SearchTxs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	chainB, err := tc.AddChain(ctx, "chain-b", "cosmos")
+	require.NoError(t, err)
+	chainA, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+
+	msgTx := func(data, action, sender string, events ...Event) Tx {
+		return Tx{Data: []byte(data), Events: append(events, Event{Type: "message", Attributes: []EventAttribute{
+			{Key: "action", Value: action},
+			{Key: "sender", Value: sender},
+		}})}
+	}
+	sendPacket := func(seq string) Event {
+		return Event{Type: "send_packet", Attributes: []EventAttribute{{Key: "packet_sequence", Value: seq}}}
+	}
+	require.NoError(t, chainA.SaveBlock(ctx, 5, []Tx{
+		msgTx(`1`, "/ibc.applications.transfer.v1.MsgTransfer", "cosmos1alice", sendPacket("5")),
+		msgTx(`2`, "/cosmos.bank.v1beta1.MsgSend", "cosmos1bob"),
+	}))
+	require.NoError(t, chainB.SaveBlock(ctx, 3, []Tx{
+		msgTx(`3`, "/ibc.applications.transfer.v1.MsgTransfer", "cosmos1bob", sendPacket("6")),
+	}))
+
+	other, err := CreateTestCase(ctx, db, "other", "abc123")
+	require.NoError(t, err)
+	otherChain, err := other.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, otherChain.SaveBlock(ctx, 5, []Tx{
+		msgTx(`4`, "/ibc.applications.transfer.v1.MsgTransfer", "cosmos1alice", sendPacket("5")),
+	}))
+
+	q := NewQuery(db)
+	search := func(term string) []string {
+		results, err := q.SearchTxs(ctx, tc.id, term, 10)
+		require.NoError(t, err)
+		var txs []string
+		for _, res := range results {
+			txs = append(txs, res.ChainID+":"+string(res.Tx))
+		}
+		return txs
+	}
+
+	require.Equal(t, []string{"chain-a:1", "chain-b:3"}, search("send_packet"))
+	require.Equal(t, []string{"chain-a:1"}, search("packet_sequence=5"))
+	require.Equal(t, []string{"chain-a:1"}, search(" packet_sequence = 5 "))
+	require.Equal(t, []string{"chain-a:1", "chain-b:3"}, search("/ibc.applications.transfer.v1.MsgTransfer"))
+	require.Equal(t, []string{"chain-a:2", "chain-b:3"}, search("cosmos1bob"))
+	require.Empty(t, search("sender=cosmos1carol"))
+	require.Empty(t, search("  "))
+
+	results, err := q.SearchTxs(ctx, tc.id, "send_packet", 1)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	got := results[0]
+	require.Equal(t, chainA.id, got.ChainPKey)
+	require.EqualValues(t, 5, got.Height)
+	require.NotZero(t, got.TxID)
+}
+
+func TestQuery_TestFailures(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	now := time.Now()
+	for i, outcomes := range []map[string]string{
+		{"TestA": OutcomeFail, "TestB": OutcomePass, "TestC": OutcomeSkip},
+		{"TestA": OutcomePass, "TestB": OutcomePass, "TestC": OutcomeFail},
+		{"TestA": OutcomeFail, "TestB": OutcomePass, "TestC": OutcomeFail},
+	} {
+		tc, err := CreateTestCase(ctx, db, "Test"+strconv.Itoa(i), "sha"+strconv.Itoa(i))
+		require.NoError(t, err)
+		finishedAt := now.Add(time.Duration(i-2) * time.Hour)
+		for name, outcome := range outcomes {
+			require.NoError(t, tc.AddTestResult(ctx, TestResult{
+				Name:       name,
+				Outcome:    outcome,
+				StartedAt:  finishedAt.Add(-time.Minute),
+				FinishedAt: finishedAt,
+			}))
+		}
+	}
+
+	q := NewQuery(db)
+
+	failures, err := q.TestFailures(ctx, now.Add(-24*time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, failures, 4)
+	require.Equal(t, "TestA", failures[0].Name)
+	require.Equal(t, "sha2", failures[0].GitSha)
+	require.Equal(t, time.Minute, failures[0].Duration)
+	require.Equal(t, "TestC", failures[1].Name)
+	require.Equal(t, "sha2", failures[1].GitSha)
+	require.Equal(t, "TestC", failures[2].Name)
+	require.Equal(t, "sha1", failures[2].GitSha)
+	require.Equal(t, "TestA", failures[3].Name)
+	require.Equal(t, "sha0", failures[3].GitSha)
+
+	failures, err = q.TestFailures(ctx, now.Add(-90*time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, failures, 3)
+
+	failures, err = q.TestFailures(ctx, now.Add(-24*time.Hour), 1)
+	require.NoError(t, err)
+	require.Len(t, failures, 1)
+
+	history, err := q.TestHistory(ctx, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+
+	got := history[0]
+	require.Equal(t, "TestA", got.Name)
+	require.Equal(t, 3, got.Runs)
+	require.Equal(t, 2, got.Failures)
+	require.Zero(t, got.Skips)
+	require.WithinDuration(t, now, got.LastFailedAt, time.Second)
+	require.True(t, got.Flaky())
+
+	// Only failed when not skipped.
+	got = history[1]
+	require.Equal(t, "TestC", got.Name)
+	require.Equal(t, 2, got.Failures)
+	require.Equal(t, 1, got.Skips)
+	require.False(t, got.Flaky())
+
+	got = history[2]
+	require.Equal(t, "TestB", got.Name)
+	require.Zero(t, got.Failures)
+	require.True(t, got.LastFailedAt.IsZero())
+	require.False(t, got.Flaky())
+}
+
+func TestQuery_PacketFlows(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "sha")
+	require.NoError(t, err)
+	chainA, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	chainB, err := tc.AddChain(ctx, "chain-b", "cosmos")
+	require.NoError(t, err)
+
+	// Both chains relay on their channel-0, so packets are identified by the same attributes both ways.
+	packetTx := func(seq string, types ...string) Tx {
+		tx := Tx{Data: []byte(`{}`)}
+		for _, typ := range types {
+			tx.Events = append(tx.Events, Event{Type: typ, Attributes: []EventAttribute{
+				{Key: "packet_sequence", Value: seq},
+				{Key: "packet_src_port", Value: "transfer"},
+				{Key: "packet_src_channel", Value: "channel-0"},
+				{Key: "packet_dst_port", Value: "transfer"},
+				{Key: "packet_dst_channel", Value: "channel-0"},
+			}})
+		}
+		// Unrelated events are ignored.
+		tx.Events = append(tx.Events, Event{Type: "message", Attributes: []EventAttribute{{Key: "module", Value: "ibc"}}})
+		return tx
+	}
+
+	require.NoError(t, chainB.SaveBlock(ctx, 5, []Tx{packetTx("1", "recv_packet", "write_acknowledgement")}))
+	require.NoError(t, chainB.SaveBlock(ctx, 6, []Tx{packetTx("1", "send_packet")}))
+	require.NoError(t, chainA.SaveBlock(ctx, 10, []Tx{packetTx("1", "send_packet"), packetTx("2", "send_packet")}))
+	require.NoError(t, chainA.SaveBlock(ctx, 11, []Tx{packetTx("1", "recv_packet")}))
+	require.NoError(t, chainA.SaveBlock(ctx, 12, []Tx{packetTx("1", "acknowledge_packet")}))
+	require.NoError(t, chainA.SaveBlock(ctx, 15, []Tx{packetTx("2", "timeout_packet")}))
+
+	got, err := NewQuery(db).PacketFlows(ctx, tc.id)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+
+	height := func(h int64) sql.NullInt64 { return sql.NullInt64{Int64: h, Valid: true} }
+	require.Equal(t, PacketFlowResult{
+		Sequence:   1,
+		SrcChainID: "chain-a", SrcPort: "transfer", SrcChannel: "channel-0",
+		DstChainID: "chain-b", DstPort: "transfer", DstChannel: "channel-0",
+		SendHeight: height(10), RecvHeight: height(5), WriteAckHeight: height(5), AckHeight: height(12),
+	}, got[0])
+	require.True(t, got[0].Complete())
+
+	require.Equal(t, PacketFlowResult{
+		Sequence:   2,
+		SrcChainID: "chain-a", SrcPort: "transfer", SrcChannel: "channel-0",
+		DstPort: "transfer", DstChannel: "channel-0",
+		SendHeight: height(10), TimeoutHeight: height(15),
+	}, got[1])
+	require.True(t, got[1].Complete())
+
+	require.Equal(t, PacketFlowResult{
+		Sequence:   1,
+		SrcChainID: "chain-b", SrcPort: "transfer", SrcChannel: "channel-0",
+		DstChainID: "chain-a", DstPort: "transfer", DstChannel: "channel-0",
+		SendHeight: height(6), RecvHeight: height(11),
+	}, got[2])
+	require.False(t, got[2].Complete())
+
+	got, err = NewQuery(db).PacketFlows(ctx, tc.id+1)
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
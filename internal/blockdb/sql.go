@@ -37,5 +37,9 @@ func ConnectDB(ctx context.Context, databasePath string) (*sql.DB, error) {
 }
 
 func nowRFC3339() string {
-	return time.Now().UTC().Format(time.RFC3339)
+	return timeRFC3339(time.Now())
+}
+
+func timeRFC3339(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
 }
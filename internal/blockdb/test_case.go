@@ -3,17 +3,20 @@ package blockdb
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 // TestCase is a single test invocation.
 type TestCase struct {
-	db *sql.DB
-	id int64
+	db        *sql.DB
+	id        int64
+	createdAt time.Time
 }
 
 // CreateTestCase starts tracking new test case with testName.
 func CreateTestCase(ctx context.Context, db *sql.DB, testName, gitSha string) (*TestCase, error) {
-	res, err := db.ExecContext(ctx, `INSERT INTO test_case(name, created_at, git_sha) VALUES(?, ?, ?)`, testName, nowRFC3339(), gitSha)
+	now := time.Now()
+	res, err := db.ExecContext(ctx, `INSERT INTO test_case(name, created_at, git_sha) VALUES(?, ?, ?)`, testName, timeRFC3339(now), gitSha)
 	if err != nil {
 		return nil, err
 	}
@@ -22,8 +25,9 @@ func CreateTestCase(ctx context.Context, db *sql.DB, testName, gitSha string) (*
 		return nil, err
 	}
 	return &TestCase{
-		db: db,
-		id: id,
+		db:        db,
+		id:        id,
+		createdAt: now,
 	}, nil
 }
 
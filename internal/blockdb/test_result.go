@@ -0,0 +1,105 @@
+package blockdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+)
+
+// Test outcomes, as stored in the test_result table.
+const (
+	OutcomePass = "pass"
+	OutcomeFail = "fail"
+	OutcomeSkip = "skip"
+)
+
+// TestResult is the outcome of a test, or of one of its subtests, run by a test case.
+type TestResult struct {
+	Name       string // The test's full name, e.g. TestConformance/gaia/rly.
+	Outcome    string // One of OutcomePass, OutcomeFail or OutcomeSkip.
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// AddTestResult saves the result of a test. A test has one result per test case,
+// so saving a result again for the same test name replaces the previous one.
+func (tc *TestCase) AddTestResult(ctx context.Context, res TestResult) error {
+	_, err := tc.db.ExecContext(ctx, `INSERT INTO test_result(name, outcome, started_at, finished_at, duration_ms, fk_test_id)
+VALUES(?, ?, ?, ?, ?, ?)
+ON CONFLICT(name, fk_test_id) DO UPDATE SET
+    outcome=excluded.outcome, started_at=excluded.started_at, finished_at=excluded.finished_at, duration_ms=excluded.duration_ms`,
+		res.Name, res.Outcome, timeRFC3339(res.StartedAt), timeRFC3339(res.FinishedAt),
+		res.FinishedAt.Sub(res.StartedAt).Milliseconds(), tc.id)
+	if err != nil {
+		return fmt.Errorf("insert test result %s: %w", res.Name, err)
+	}
+	return nil
+}
+
+// AddRelayerExec saves a relayer command run by the test case,
+// as reported by a testreporter.RelayerExecMessage.
+func (tc *TestCase) AddRelayerExec(ctx context.Context, m testreporter.RelayerExecMessage) error {
+	cmd, err := json.Marshal(m.Command)
+	if err != nil {
+		return fmt.Errorf("marshal relayer command: %w", err)
+	}
+	_, err = tc.db.ExecContext(ctx, `INSERT INTO relayer_exec(
+    test_name, container_name, command, stdout, stderr, exit_code, error, started_at, finished_at, fk_test_id
+) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		m.Name, m.ContainerName, string(cmd), m.Stdout, m.Stderr, m.ExitCode, m.Error,
+		timeRFC3339(m.StartedAt), timeRFC3339(m.FinishedAt), tc.id)
+	if err != nil {
+		return fmt.Errorf("insert relayer exec: %w", err)
+	}
+	return nil
+}
+
+// ResultRecorder saves the results of a test case's tests, and the relayer commands they run,
+// from the messages of a testreporter.Reporter.
+type ResultRecorder struct {
+	tc *TestCase
+
+	// Start time of the tests that began but have not finished.
+	started map[string]time.Time
+}
+
+// ResultRecorder returns a new ResultRecorder saving to the test case.
+func (tc *TestCase) ResultRecorder() *ResultRecorder {
+	return &ResultRecorder{tc: tc, started: make(map[string]time.Time)}
+}
+
+// Record saves the test result or relayer exec reported by m, and ignores other messages.
+// A test that finishes without having begun, e.g. the test that created the test case,
+// is considered started when the test case was created.
+func (r *ResultRecorder) Record(ctx context.Context, m testreporter.Message) error {
+	switch m := m.(type) {
+	case testreporter.BeginTestMessage:
+		r.started[m.Name] = m.StartedAt
+	case testreporter.FinishTestMessage:
+		startedAt, ok := r.started[m.Name]
+		if !ok {
+			startedAt = r.tc.createdAt
+		}
+		delete(r.started, m.Name)
+
+		outcome := OutcomePass
+		switch {
+		case m.Skipped:
+			outcome = OutcomeSkip
+		case m.Failed:
+			outcome = OutcomeFail
+		}
+		return r.tc.AddTestResult(ctx, TestResult{
+			Name:       m.Name,
+			Outcome:    outcome,
+			StartedAt:  startedAt,
+			FinishedAt: m.FinishedAt,
+		})
+	case testreporter.RelayerExecMessage:
+		return r.tc.AddRelayerExec(ctx, m)
+	}
+	return nil
+}
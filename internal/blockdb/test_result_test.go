@@ -0,0 +1,107 @@
+package blockdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultRecorder_Record(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "TestFoo", "abc")
+	require.NoError(t, err)
+
+	start := time.Now().Add(-time.Minute)
+	rec := tc.ResultRecorder()
+	for _, m := range []testreporter.Message{
+		testreporter.BeginTestMessage{Name: "TestFoo/bar", StartedAt: start},
+		testreporter.RelayerExecMessage{
+			Name:          "TestFoo/bar",
+			StartedAt:     start,
+			FinishedAt:    start.Add(time.Second),
+			ContainerName: "relayer",
+			Command:       []string{"rly", "start"},
+			Stdout:        "out",
+			Stderr:        "err",
+			ExitCode:      1,
+			Error:         "exit code 1",
+		},
+		testreporter.FinishTestMessage{Name: "TestFoo/bar", FinishedAt: start.Add(3 * time.Second), Failed: true},
+		testreporter.BeginTestMessage{Name: "TestFoo/baz", StartedAt: start},
+		testreporter.TestSkipMessage{Name: "TestFoo/baz", When: start},
+		testreporter.FinishTestMessage{Name: "TestFoo/baz", FinishedAt: start, Skipped: true},
+		testreporter.FinishTestMessage{Name: "TestFoo", FinishedAt: time.Now(), Failed: true},
+	} {
+		require.NoError(t, rec.Record(ctx, m))
+	}
+
+	q := NewQuery(db)
+	results, err := q.TestResults(ctx, tc.id)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	got := results[0]
+	require.Equal(t, "TestFoo/baz", got.Name)
+	require.Equal(t, OutcomeSkip, got.Outcome)
+	require.Zero(t, got.Duration)
+
+	got = results[1]
+	require.Equal(t, "TestFoo/bar", got.Name)
+	require.Equal(t, OutcomeFail, got.Outcome)
+	require.Equal(t, "TestFoo", got.TestCaseName)
+	require.Equal(t, "abc", got.GitSha)
+	require.WithinDuration(t, start, got.StartedAt, time.Second)
+	require.Equal(t, 3*time.Second, got.Duration)
+
+	// Never began, so started when the test case was created.
+	got = results[2]
+	require.Equal(t, "TestFoo", got.Name)
+	require.Equal(t, OutcomeFail, got.Outcome)
+	require.WithinDuration(t, time.Now(), got.StartedAt, 10*time.Second)
+
+	execs, err := q.RelayerExecs(ctx, tc.id)
+	require.NoError(t, err)
+	require.Len(t, execs, 1)
+	exec := execs[0]
+	require.Equal(t, "TestFoo/bar", exec.TestName)
+	require.Equal(t, "relayer", exec.ContainerName)
+	require.Equal(t, []string{"rly", "start"}, exec.Command)
+	require.Equal(t, "out", exec.Stdout)
+	require.Equal(t, "err", exec.Stderr)
+	require.Equal(t, 1, exec.ExitCode)
+	require.Equal(t, "exit code 1", exec.Error)
+	require.WithinDuration(t, start.Add(time.Second), exec.FinishedAt, time.Second)
+}
+
+func TestTestCase_AddTestResult(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "TestFoo", "abc")
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.NoError(t, tc.AddTestResult(ctx, TestResult{Name: "TestFoo", Outcome: OutcomeFail, StartedAt: now, FinishedAt: now}))
+	// Replaces the previous result.
+	require.NoError(t, tc.AddTestResult(ctx, TestResult{Name: "TestFoo", Outcome: OutcomePass, StartedAt: now, FinishedAt: now}))
+
+	results, err := NewQuery(db).TestResults(ctx, tc.id)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, OutcomePass, results[0].Outcome)
+
+	require.Error(t, tc.AddTestResult(ctx, TestResult{Name: "TestFoo", Outcome: "unknown", StartedAt: now, FinishedAt: now}))
+}
@@ -40,8 +40,15 @@ var (
 	}
 
 	keyMap = map[mainContent][]keyBinding{
-		testCasesMain:      bindingsWithBase([]keyBinding{{"m", "cosmos messages"}, {"enter", "view txs"}}, tableNavKeys),
-		cosmosMessagesMain: bindingsWithBase(tableNavKeys),
+		testCasesMain: bindingsWithBase([]keyBinding{
+			{"m", "messages/extrinsics"},
+			{"p", "packet flows"},
+			{"/", "search txs"},
+			{"enter", "view txs"},
+		}, tableNavKeys),
+		cosmosMessagesMain:      bindingsWithBase(tableNavKeys),
+		substrateExtrinsicsMain: bindingsWithBase(tableNavKeys),
+		packetFlowsMain:         bindingsWithBase(tableNavKeys),
 		txDetailMain: bindingsWithBase([]keyBinding{
 			{"[", "previous tx"},
 			{"]", "next tx"},
@@ -49,6 +56,10 @@ var (
 			{"c", "copy all txs"},
 		}, textNavKeys),
 		errorModalMain: bindingsWithBase(nil),
+		txSearchMain: bindingsWithBase([]keyBinding{
+			{"/", "edit search"},
+			{"enter", "search or view tx"},
+		}, tableNavKeys),
 	}
 )
 
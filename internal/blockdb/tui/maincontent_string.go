@@ -12,11 +12,14 @@ func _() {
 	_ = x[cosmosMessagesMain-1]
 	_ = x[txDetailMain-2]
 	_ = x[errorModalMain-3]
+	_ = x[packetFlowsMain-4]
+	_ = x[txSearchMain-5]
+	_ = x[substrateExtrinsicsMain-6]
 }
 
-const _mainContent_name = "testCasesMaincosmosMessagesMaintxDetailMainerrorModalMain"
+const _mainContent_name = "testCasesMaincosmosMessagesMaintxDetailMainerrorModalMainpacketFlowsMaintxSearchMainsubstrateExtrinsicsMain"
 
-var _mainContent_index = [...]uint8{0, 13, 31, 43, 57}
+var _mainContent_index = [...]uint8{0, 13, 31, 43, 57, 72, 84, 107}
 
 func (i mainContent) String() string {
 	if i < 0 || i >= mainContent(len(_mainContent_index)-1) {
@@ -19,6 +19,9 @@ const (
 	cosmosMessagesMain
 	txDetailMain
 	errorModalMain
+	packetFlowsMain
+	txSearchMain
+	substrateExtrinsicsMain
 )
 
 type mainStack []mainContent
@@ -30,7 +33,10 @@ func (stack mainStack) Pop() []mainContent               { return stack[:len(sta
 // QueryService fetches data from a database.
 type QueryService interface {
 	CosmosMessages(ctx context.Context, chainPkey int64) ([]blockdb.CosmosMessageResult, error)
+	SubstrateExtrinsics(ctx context.Context, chainPkey int64) ([]blockdb.SubstrateExtrinsicResult, error)
 	Transactions(ctx context.Context, chainPkey int64) ([]blockdb.TxResult, error)
+	PacketFlows(ctx context.Context, testCaseID int64) ([]blockdb.PacketFlowResult, error)
+	SearchTxs(ctx context.Context, testCaseID int64, term string, limit int) ([]blockdb.TxSearchResult, error)
 }
 
 // Model encapsulates state that updates a view.
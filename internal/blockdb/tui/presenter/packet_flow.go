@@ -0,0 +1,48 @@
+package presenter
+
+import (
+	"database/sql"
+	"strconv"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+// PacketFlow presents a blockdb.PacketFlowResult.
+type PacketFlow struct {
+	Result blockdb.PacketFlowResult
+}
+
+func (p PacketFlow) Sequence() string { return strconv.FormatUint(p.Result.Sequence, 10) }
+
+// Source is the sending chain and its channel:port, e.g. chain-a channel-0:transfer.
+func (p PacketFlow) Source() string {
+	return p.end(p.Result.SrcChainID, p.Result.SrcChannel, p.Result.SrcPort)
+}
+
+// Destination is the receiving chain and its channel:port, e.g. chain-b channel-0:transfer.
+func (p PacketFlow) Destination() string {
+	return p.end(p.Result.DstChainID, p.Result.DstChannel, p.Result.DstPort)
+}
+
+func (p PacketFlow) end(chainID, channel, port string) string {
+	if chainID == "" {
+		chainID = "?"
+	}
+	return chainID + " " + channel + ":" + port
+}
+
+func (p PacketFlow) SendHeight() string     { return p.height(p.Result.SendHeight) }
+func (p PacketFlow) RecvHeight() string     { return p.height(p.Result.RecvHeight) }
+func (p PacketFlow) WriteAckHeight() string { return p.height(p.Result.WriteAckHeight) }
+func (p PacketFlow) AckHeight() string      { return p.height(p.Result.AckHeight) }
+func (p PacketFlow) TimeoutHeight() string  { return p.height(p.Result.TimeoutHeight) }
+
+func (p PacketFlow) height(h sql.NullInt64) string {
+	if !h.Valid {
+		return ""
+	}
+	return strconv.FormatInt(h.Int64, 10)
+}
+
+// Status is the furthest step the packet reached in its lifecycle.
+func (p PacketFlow) Status() string { return p.Result.Status() }
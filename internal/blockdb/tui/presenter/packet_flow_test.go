@@ -0,0 +1,54 @@
+package presenter
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacketFlow(t *testing.T) {
+	t.Parallel()
+
+	height := func(h int64) sql.NullInt64 { return sql.NullInt64{Int64: h, Valid: true} }
+
+	t.Run("complete", func(t *testing.T) {
+		pres := PacketFlow{blockdb.PacketFlowResult{
+			Sequence:   7,
+			SrcChainID: "chain-a", SrcPort: "transfer", SrcChannel: "channel-0",
+			DstChainID: "chain-b", DstPort: "transfer", DstChannel: "channel-1",
+			SendHeight: height(10), RecvHeight: height(5), WriteAckHeight: height(5), AckHeight: height(12),
+		}}
+
+		require.Equal(t, "7", pres.Sequence())
+		require.Equal(t, "chain-a channel-0:transfer", pres.Source())
+		require.Equal(t, "chain-b channel-1:transfer", pres.Destination())
+		require.Equal(t, "10", pres.SendHeight())
+		require.Equal(t, "5", pres.RecvHeight())
+		require.Equal(t, "5", pres.WriteAckHeight())
+		require.Equal(t, "12", pres.AckHeight())
+		require.Empty(t, pres.TimeoutHeight())
+		require.Equal(t, "acknowledged", pres.Status())
+	})
+
+	t.Run("status", func(t *testing.T) {
+		for _, tt := range []struct {
+			Result blockdb.PacketFlowResult
+			Want   string
+		}{
+			{blockdb.PacketFlowResult{SendHeight: height(1), TimeoutHeight: height(2)}, "timed out"},
+			{blockdb.PacketFlowResult{SendHeight: height(1), RecvHeight: height(2), WriteAckHeight: height(2)}, "ack pending"},
+			{blockdb.PacketFlowResult{RecvHeight: height(2)}, "received"},
+			{blockdb.PacketFlowResult{SendHeight: height(1)}, "sent"},
+			{blockdb.PacketFlowResult{}, "unknown"},
+		} {
+			require.Equal(t, tt.Want, PacketFlow{tt.Result}.Status(), tt)
+		}
+	})
+
+	t.Run("unknown chain", func(t *testing.T) {
+		pres := PacketFlow{blockdb.PacketFlowResult{DstPort: "transfer", DstChannel: "channel-1"}}
+		require.Equal(t, "? channel-1:transfer", pres.Destination())
+	})
+}
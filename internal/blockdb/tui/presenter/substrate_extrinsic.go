@@ -0,0 +1,36 @@
+package presenter
+
+import (
+	"strconv"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+// SubstrateExtrinsic presents a blockdb.SubstrateExtrinsicResult.
+type SubstrateExtrinsic struct {
+	Result blockdb.SubstrateExtrinsicResult
+}
+
+func (ext SubstrateExtrinsic) Height() string { return strconv.FormatInt(ext.Result.Height, 10) }
+
+// Index is the extrinsic's ordered position within the block.
+func (ext SubstrateExtrinsic) Index() string { return strconv.Itoa(ext.Result.Index) }
+
+// Call is the pallet and call of the extrinsic, e.g. Ibc.deliver.
+func (ext SubstrateExtrinsic) Call() string {
+	if !ext.Result.Pallet.Valid {
+		return "(undecoded)"
+	}
+	return ext.Result.Pallet.String + "." + ext.Result.Call.String
+}
+
+// Signer is blank for unsigned extrinsics, such as inherents.
+func (ext SubstrateExtrinsic) Signer() string { return ext.Result.Signer.String }
+
+func (ext SubstrateExtrinsic) Outcome() string { return ext.Result.Outcome.String }
+
+// Events are the runtime events emitted by the extrinsic, e.g. Ibc.Events, including IBC packet events.
+func (ext SubstrateExtrinsic) Events() string { return ext.Result.Events.String }
+
+// Failed is true if the extrinsic's dispatch failed.
+func (ext SubstrateExtrinsic) Failed() bool { return ext.Result.Outcome.String == "failed" }
@@ -0,0 +1,42 @@
+package presenter
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubstrateExtrinsic(t *testing.T) {
+	t.Parallel()
+
+	t.Run("decoded", func(t *testing.T) {
+		pres := SubstrateExtrinsic{blockdb.SubstrateExtrinsicResult{
+			Height:  12,
+			Index:   2,
+			Pallet:  sql.NullString{String: "Ibc", Valid: true},
+			Call:    sql.NullString{String: "deliver", Valid: true},
+			Signer:  sql.NullString{String: "0xd435", Valid: true},
+			Outcome: sql.NullString{String: "failed", Valid: true},
+			Events:  sql.NullString{String: "Ibc.Events, send_packet", Valid: true},
+		}}
+
+		require.Equal(t, "12", pres.Height())
+		require.Equal(t, "2", pres.Index())
+		require.Equal(t, "Ibc.deliver", pres.Call())
+		require.Equal(t, "0xd435", pres.Signer())
+		require.Equal(t, "failed", pres.Outcome())
+		require.Equal(t, "Ibc.Events, send_packet", pres.Events())
+		require.True(t, pres.Failed())
+	})
+
+	t.Run("undecoded", func(t *testing.T) {
+		pres := SubstrateExtrinsic{blockdb.SubstrateExtrinsicResult{Height: 3}}
+
+		require.Equal(t, "(undecoded)", pres.Call())
+		require.Empty(t, pres.Signer())
+		require.Empty(t, pres.Outcome())
+		require.False(t, pres.Failed())
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
@@ -63,3 +64,31 @@ func (txs Txs) ToJSON() []byte {
 	}
 	return b
 }
+
+// TxSearch presents a blockdb.TxSearchResult.
+type TxSearch struct {
+	Result blockdb.TxSearchResult
+}
+
+func (tx TxSearch) ChainID() string { return tx.Result.ChainID }
+func (tx TxSearch) Height() string  { return strconv.FormatInt(tx.Result.Height, 10) }
+
+// MessageTypes lists the type URLs of a Cosmos tx's messages, e.g. /ibc.core.channel.v1.MsgRecvPacket.
+// Empty if the tx is not a JSON encoded Cosmos tx.
+func (tx TxSearch) MessageTypes() string {
+	var cosmosTx struct {
+		Body struct {
+			Messages []struct {
+				Type string `json:"@type"`
+			} `json:"messages"`
+		} `json:"body"`
+	}
+	if err := json.Unmarshal(tx.Result.Tx, &cosmosTx); err != nil {
+		return ""
+	}
+	types := make([]string, len(cosmosTx.Body.Messages))
+	for i, msg := range cosmosTx.Body.Messages {
+		types[i] = msg.Type
+	}
+	return strings.Join(types, ", ")
+}
@@ -67,3 +67,19 @@ func TestTxs_ToJSON(t *testing.T) {
 		require.JSONEq(t, want, string(txs.ToJSON()))
 	})
 }
+
+func TestTxSearch(t *testing.T) {
+	t.Parallel()
+
+	pres := TxSearch{blockdb.TxSearchResult{
+		ChainID: "chain-a",
+		Height:  13,
+		Tx:      []byte(`{"body":{"messages":[{"@type":"/ibc.core.client.v1.MsgUpdateClient"},{"@type":"/ibc.core.channel.v1.MsgRecvPacket"}]}}`),
+	}}
+	require.Equal(t, "chain-a", pres.ChainID())
+	require.Equal(t, "13", pres.Height())
+	require.Equal(t, "/ibc.core.client.v1.MsgUpdateClient, /ibc.core.channel.v1.MsgRecvPacket", pres.MessageTypes())
+
+	pres.Result.Tx = []byte(`not json`)
+	require.Empty(t, pres.MessageTypes())
+}
@@ -38,6 +38,17 @@ func (m *Model) Update(ctx context.Context) func(event *tcell.EventKey) *tcell.E
 			m.pushMainView(txDetailMain, newTxDetailView(tc.ChainID, results))
 			return nil
 
+		case event.Rune() == 'm' && m.stack.Current() == testCasesMain && m.testCases[m.selectedRow()].ChainType == "polkadot":
+			// Show substrate extrinsics, in place of cosmos messages.
+			tc := m.testCases[m.selectedRow()]
+			results, err := m.querySvc.SubstrateExtrinsics(ctx, tc.ChainPKey)
+			if err != nil {
+				m.pushErrorModal(fmt.Errorf("query substrate extrinsics: %w", err))
+				return nil
+			}
+			m.pushMainView(substrateExtrinsicsMain, substrateExtrinsicsView(tc, results))
+			return nil
+
 		case event.Rune() == 'm' && m.stack.Current() == testCasesMain:
 			// Show cosmos messages.
 			tc := m.testCases[m.selectedRow()]
@@ -49,6 +60,53 @@ func (m *Model) Update(ctx context.Context) func(event *tcell.EventKey) *tcell.E
 			m.pushMainView(cosmosMessagesMain, cosmosMessagesView(tc, results))
 			return nil
 
+		case event.Rune() == 'p' && m.stack.Current() == testCasesMain:
+			// Show packet flows across all chains of the test case.
+			tc := m.testCases[m.selectedRow()]
+			results, err := m.querySvc.PacketFlows(ctx, tc.ID)
+			if err != nil {
+				m.pushErrorModal(fmt.Errorf("query packet flows: %w", err))
+				return nil
+			}
+			m.pushMainView(packetFlowsMain, packetFlowsView(tc, results))
+			return nil
+
+		case event.Rune() == '/' && m.stack.Current() == testCasesMain:
+			// Search txs across all chains of the test case.
+			tc := m.testCases[m.selectedRow()]
+			m.pushMainView(txSearchMain, newTxSearchView(tc))
+			return nil
+
+		case event.Key() == tcell.KeyEnter && m.stack.Current() == txSearchMain && m.txSearchView().Input.HasFocus():
+			search := m.txSearchView()
+			results, err := m.querySvc.SearchTxs(ctx, search.TestCase.ID, search.Input.GetText(), txSearchLimit)
+			if err != nil {
+				m.pushErrorModal(fmt.Errorf("search txs: %w", err))
+				return nil
+			}
+			search.ShowResults(results)
+			return nil
+
+		case event.Rune() == '/' && m.stack.Current() == txSearchMain && !m.txSearchView().Input.HasFocus():
+			m.txSearchView().ActivateInput()
+			return nil
+
+		case event.Key() == tcell.KeyEnter && m.stack.Current() == txSearchMain:
+			// Show the tx detail of the chain, starting at the matching tx.
+			res, ok := m.txSearchView().SelectedTx()
+			if !ok {
+				return nil
+			}
+			results, err := m.querySvc.Transactions(ctx, res.ChainPKey)
+			if err != nil {
+				m.pushErrorModal(fmt.Errorf("query transactions: %w", err))
+				return nil
+			}
+			detail := newTxDetailView(res.ChainID, results)
+			detail.ShowTx(res.TxID)
+			m.pushMainView(txDetailMain, detail)
+			return nil
+
 		case event.Rune() == '[' && m.stack.Current() == txDetailMain:
 			goToPrevPage(m.txDetailView().Pages)
 			return nil
@@ -106,6 +164,11 @@ func (m *Model) selectedRow() int {
 	return row - 1
 }
 
+func (m *Model) txSearchView() *txSearchView {
+	_, primitive := m.mainContentView().GetFrontPage()
+	return primitive.(*txSearchView)
+}
+
 func (m *Model) txDetailView() *txDetailView {
 	_, primitive := m.mainContentView().GetFrontPage()
 	return primitive.(*txDetailView)
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"testing"
@@ -29,10 +30,15 @@ func draw(view tview.Primitive) {
 }
 
 type mockQueryService struct {
-	GotChainPkey int64
-	Messages     []blockdb.CosmosMessageResult
-	Txs          []blockdb.TxResult
-	Err          error
+	GotChainPkey  int64
+	GotTestCaseID int64
+	Messages      []blockdb.CosmosMessageResult
+	Extrinsics    []blockdb.SubstrateExtrinsicResult
+	Txs           []blockdb.TxResult
+	Flows         []blockdb.PacketFlowResult
+	GotTerm       string
+	Found         []blockdb.TxSearchResult
+	Err           error
 }
 
 func (m *mockQueryService) Transactions(ctx context.Context, chainPkey int64) ([]blockdb.TxResult, error) {
@@ -51,6 +57,34 @@ func (m *mockQueryService) CosmosMessages(ctx context.Context, chainPkey int64)
 	return m.Messages, m.Err
 }
 
+func (m *mockQueryService) SubstrateExtrinsics(ctx context.Context, chainPkey int64) ([]blockdb.SubstrateExtrinsicResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotChainPkey = chainPkey
+	return m.Extrinsics, m.Err
+}
+
+func (m *mockQueryService) PacketFlows(ctx context.Context, testCaseID int64) ([]blockdb.PacketFlowResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotTestCaseID = testCaseID
+	return m.Flows, m.Err
+}
+
+func (m *mockQueryService) SearchTxs(ctx context.Context, testCaseID int64, term string, limit int) ([]blockdb.TxSearchResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	if limit <= 0 {
+		panic("no limit")
+	}
+	m.GotTestCaseID = testCaseID
+	m.GotTerm = term
+	return m.Found, m.Err
+}
+
 func TestModel_Update(t *testing.T) {
 	ctx := context.Background()
 
@@ -95,6 +129,123 @@ func TestModel_Update(t *testing.T) {
 		require.Contains(t, table.(*tview.Table).GetTitle(), "my-chain1")
 	})
 
+	t.Run("substrate extrinsics view", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Messages: []blockdb.CosmosMessageResult{{Height: 1}},
+			Extrinsics: []blockdb.SubstrateExtrinsicResult{
+				{Height: 10, Outcome: sql.NullString{String: "success", Valid: true}},
+				{Height: 11, Outcome: sql.NullString{String: "failed", Valid: true}},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ChainPKey: 5, ChainID: "parachain", ChainType: "polkadot"},
+			{ChainPKey: 6},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('m'))
+
+		require.EqualValues(t, 5, querySvc.GotChainPkey)
+
+		require.Equal(t, 2, model.mainContentView().GetPageCount())
+		_, primitive := model.mainContentView().GetFrontPage()
+		table := primitive.(*tview.Table)
+
+		// 3 rows: 1 header + 2 blockdb.SubstrateExtrinsicResult
+		require.Equal(t, 3, table.GetRowCount())
+		require.Contains(t, table.GetTitle(), "parachain")
+
+		// Only the failed extrinsic is highlighted.
+		require.Equal(t, textColor, table.GetCell(1, 0).Color)
+		require.Equal(t, errorTextColor, table.GetCell(2, 0).Color)
+	})
+
+	t.Run("packet flows view", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Flows: []blockdb.PacketFlowResult{
+				{Sequence: 1, AckHeight: sql.NullInt64{Int64: 12, Valid: true}},
+				{Sequence: 2},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ID: 3, Name: "TestFoo", ChainPKey: 5, ChainID: "my-chain1"},
+			{ID: 4, ChainPKey: 6},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('p'))
+
+		// By default, first row is selected in a rendered table.
+		require.EqualValues(t, 3, querySvc.GotTestCaseID)
+
+		require.Equal(t, 2, model.mainContentView().GetPageCount())
+		_, primitive := model.mainContentView().GetFrontPage()
+		table := primitive.(*tview.Table)
+
+		// 3 rows: 1 header + 2 blockdb.PacketFlowResult
+		require.Equal(t, 3, table.GetRowCount())
+		require.Contains(t, table.GetTitle(), "TestFoo")
+
+		// Only the packet that never completed is highlighted.
+		require.Equal(t, textColor, table.GetCell(1, 0).Color)
+		require.Equal(t, errorTextColor, table.GetCell(2, 0).Color)
+	})
+
+	t.Run("tx search", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Found: []blockdb.TxSearchResult{
+				{ChainPKey: 5, ChainID: "my-chain1", Height: 13, TxID: 22},
+				{ChainPKey: 6, ChainID: "my-chain2", Height: 3, TxID: 31},
+			},
+			Txs: []blockdb.TxResult{
+				{ID: 21, Height: 12, Tx: []byte(`{"tx":1}`)},
+				{ID: 22, Height: 13, Tx: []byte(`{"tx":2}`)},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ID: 3, Name: "TestFoo", ChainPKey: 5, ChainID: "my-chain1"},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('/'))
+
+		require.Equal(t, 2, model.mainContentView().GetPageCount())
+		search := model.txSearchView()
+		require.True(t, search.Input.HasFocus())
+
+		// Runes are typed into the search input.
+		require.NotNil(t, update(runeKey('p')))
+
+		search.Input.SetText("packet_sequence=5")
+		update(enterKey)
+
+		require.EqualValues(t, 3, querySvc.GotTestCaseID)
+		require.Equal(t, "packet_sequence=5", querySvc.GotTerm)
+		require.Len(t, search.Results, 2)
+		require.False(t, search.Input.HasFocus())
+
+		draw(model.RootView())
+
+		// Show the tx detail of the first result.
+		update(enterKey)
+
+		require.EqualValues(t, 5, querySvc.GotChainPkey)
+		require.Equal(t, 3, model.mainContentView().GetPageCount())
+		_, primitive := model.txDetailView().Pages.GetFrontPage()
+		require.Contains(t, primitive.(*tview.TextView).GetTitle(), "my-chain1 @ Height 13 [Tx 2 of 2]")
+
+		// Go back to edit the search.
+		update(escKey)
+		update(runeKey('/'))
+		require.True(t, model.txSearchView().Input.HasFocus())
+	})
+
 	t.Run("tx detail", func(t *testing.T) {
 		querySvc := &mockQueryService{
 			Txs: []blockdb.TxResult{
@@ -150,6 +150,88 @@ func cosmosMessagesView(tc blockdb.TestCaseResult, msgs []blockdb.CosmosMessageR
 	return detailTableView(title, headers, rows)
 }
 
+// substrateExtrinsicsView shows the extrinsics of a Substrate chain, the counterpart of cosmosMessagesView.
+// Extrinsics that failed are highlighted.
+func substrateExtrinsicsView(tc blockdb.TestCaseResult, exts []blockdb.SubstrateExtrinsicResult) *tview.Table {
+	headers := []string{
+		"Height",
+		"Index",
+		"Call",
+		"Signer",
+		"Outcome",
+		"Events",
+	}
+
+	rows := make([][]string, len(exts))
+	for i, ext := range exts {
+		pres := presenter.SubstrateExtrinsic{Result: ext}
+		rows[i] = []string{
+			pres.Height(),
+			pres.Index(),
+			pres.Call(),
+			pres.Signer(),
+			pres.Outcome(),
+			pres.Events(),
+		}
+	}
+
+	title := fmt.Sprintf("%s [%s]", tc.ChainID, presenter.FormatTime(tc.CreatedAt))
+	tbl := detailTableView(title, headers, rows)
+	for i, ext := range exts {
+		if !(presenter.SubstrateExtrinsic{Result: ext}).Failed() {
+			continue
+		}
+		for col := range headers {
+			tbl.GetCell(i+1, col).SetTextColor(errorTextColor) // 1 offsets header row
+		}
+	}
+	return tbl
+}
+
+// packetFlowsView shows the lifecycle of the packets across all chains of the test case.
+// Packets that were neither acknowledged nor timed out are highlighted.
+func packetFlowsView(tc blockdb.TestCaseResult, flows []blockdb.PacketFlowResult) *tview.Table {
+	headers := []string{
+		"Sequence",
+		"Source",
+		"Destination",
+		"Send",
+		"Recv",
+		"Write Ack",
+		"Ack",
+		"Timeout",
+		"Status",
+	}
+
+	rows := make([][]string, len(flows))
+	for i, flow := range flows {
+		pres := presenter.PacketFlow{Result: flow}
+		rows[i] = []string{
+			pres.Sequence(),
+			pres.Source(),
+			pres.Destination(),
+			pres.SendHeight(),
+			pres.RecvHeight(),
+			pres.WriteAckHeight(),
+			pres.AckHeight(),
+			pres.TimeoutHeight(),
+			pres.Status(),
+		}
+	}
+
+	title := fmt.Sprintf("Packets: %s [%s]", tc.Name, presenter.FormatTime(tc.CreatedAt))
+	tbl := detailTableView(title, headers, rows)
+	for i, flow := range flows {
+		if flow.Complete() {
+			continue
+		}
+		for col := range headers {
+			tbl.GetCell(i+1, col).SetTextColor(errorTextColor) // 1 offsets header row
+		}
+	}
+	return tbl
+}
+
 func errorModalView(err error) *tview.Flex {
 	modal := tview.NewModal().
 		SetText(fmt.Sprintf("Error: %v", err)).
@@ -191,7 +273,7 @@ func newTxDetailView(chainID string, txs []blockdb.TxResult) *txDetailView {
 
 	detail.Pages = tview.NewPages()
 	detail.replacePages("", "0")
-	detail.Search = detail.buildSearchInput()
+	detail.Search = newSearchInput("Search")
 
 	flex := tview.NewFlex().SetDirection(tview.FlexRow)
 	flex.SetBorder(false)
@@ -267,12 +349,22 @@ func (detail *txDetailView) replacePages(searchTerm, pageIdx string) {
 	detail.Pages.SwitchToPage(pageIdx)
 }
 
-func (*txDetailView) buildSearchInput() *tview.InputField {
+// ShowTx switches to the page of the tx with the primary key txID, if present.
+func (detail *txDetailView) ShowTx(txID int64) {
+	for i, tx := range detail.Txs {
+		if tx.ID == txID {
+			detail.Pages.SwitchToPage(strconv.Itoa(i))
+			return
+		}
+	}
+}
+
+func newSearchInput(title string) *tview.InputField {
 	input := tview.NewInputField().
 		SetFieldTextColor(searchInactiveColor).
 		SetFieldBackgroundColor(backgroundColor)
 
-	input.SetTitle("Search").
+	input.SetTitle(title).
 		SetTitleColor(searchInactiveColor).
 		SetTitleAlign(tview.AlignLeft).
 		SetBorder(true).
@@ -280,3 +372,94 @@ func (*txDetailView) buildSearchInput() *tview.InputField {
 		SetBorderColor(searchInactiveColor)
 	return input
 }
+
+// Maximum number of txs shown by a search.
+const txSearchLimit = 1000
+
+// txSearchView searches the txs of a test case across all its chains, by event type, attribute or message type.
+// The search input has focus until a search is done, then the results do.
+type txSearchView struct {
+	*tview.Flex
+
+	TestCase blockdb.TestCaseResult
+	Input    *tview.InputField
+	Results  []blockdb.TxSearchResult
+
+	table *tview.Table
+}
+
+func newTxSearchView(tc blockdb.TestCaseResult) *txSearchView {
+	search := &txSearchView{TestCase: tc}
+
+	search.Input = newSearchInput("Search: event type, attribute key=value, message type or address")
+	search.table = search.resultsTable()
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow)
+	flex.SetBorder(false)
+	flex.AddItem(search.Input, 3, 1, true)
+	flex.AddItem(search.table, 0, 9, false)
+
+	search.Flex = flex
+	search.ActivateInput()
+	return search
+}
+
+// ActivateInput focuses the search input, to edit the search.
+func (search *txSearchView) ActivateInput() {
+	search.Input.SetBorderColor(searchActiveColor)
+	search.Input.SetFieldTextColor(searchActiveColor)
+	search.Input.SetTitleColor(searchActiveColor)
+	search.table.Blur()
+	search.Input.Focus(nil)
+}
+
+func (search *txSearchView) deactivateInput() {
+	search.Input.SetBorderColor(searchInactiveColor)
+	search.Input.SetFieldTextColor(searchInactiveColor)
+	search.Input.SetTitleColor(searchInactiveColor)
+	search.Input.Blur()
+	search.table.Focus(nil)
+}
+
+// ShowResults replaces the results table, and focuses it to select a tx.
+func (search *txSearchView) ShowResults(results []blockdb.TxSearchResult) {
+	search.Results = results
+	search.Flex.RemoveItem(search.table)
+	search.table = search.resultsTable()
+	search.Flex.AddItem(search.table, 0, 9, false)
+	search.deactivateInput()
+}
+
+// SelectedTx returns the result selected in the results table, if any.
+func (search *txSearchView) SelectedTx() (blockdb.TxSearchResult, bool) {
+	row, _ := search.table.GetSelection()
+	// Offset by 1 to account for header row.
+	if row < 1 || row > len(search.Results) {
+		return blockdb.TxSearchResult{}, false
+	}
+	return search.Results[row-1], true
+}
+
+func (search *txSearchView) resultsTable() *tview.Table {
+	headers := []string{
+		"Chain",
+		"Height",
+		"Messages",
+	}
+
+	rows := make([][]string, len(search.Results))
+	for i, res := range search.Results {
+		pres := presenter.TxSearch{Result: res}
+		rows[i] = []string{
+			pres.ChainID(),
+			pres.Height(),
+			pres.MessageTypes(),
+		}
+	}
+
+	title := fmt.Sprintf("%s [%s]", search.TestCase.Name, presenter.FormatTime(search.TestCase.CreatedAt))
+	if term := search.Input.GetText(); term != "" {
+		title = fmt.Sprintf("%d txs matching %q in %s", len(search.Results), term, title)
+	}
+	return detailTableView(title, headers, rows)
+}
@@ -0,0 +1,262 @@
+package dockerutil
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"go.uber.org/zap"
+)
+
+// netfaultImageRef is the image used for the sidecar containers that manipulate
+// the network namespace of a target container. It ships with both tc and iptables.
+const netfaultImageRef = "nicolaka/netshoot:v0.11"
+
+// netfaultInterface is the interface inside the target container's network namespace
+// that faults are applied to. Containers created by interchaintest are attached to a single network,
+// so the only non-loopback interface is eth0.
+const netfaultInterface = "eth0"
+
+// Like busybox, allow multiple goroutines to check for the network fault image.
+var (
+	ensureNetfaultImageMu sync.Mutex
+	hasNetfaultImage      bool
+)
+
+func ensureNetfaultImage(ctx context.Context, cli *client.Client) error {
+	ensureNetfaultImageMu.Lock()
+	defer ensureNetfaultImageMu.Unlock()
+
+	if hasNetfaultImage {
+		return nil
+	}
+
+	images, err := cli.ImageList(ctx, types.ImageListOptions{
+		Filters: filters.NewArgs(filters.Arg("reference", netfaultImageRef)),
+	})
+	if err != nil {
+		return fmt.Errorf("listing images to check network fault image presence: %w", err)
+	}
+
+	if len(images) == 0 {
+		rc, err := cli.ImagePull(ctx, netfaultImageRef, types.ImagePullOptions{})
+		if err != nil {
+			return err
+		}
+
+		_, _ = io.Copy(io.Discard, rc)
+		_ = rc.Close()
+	}
+
+	hasNetfaultImage = true
+	return nil
+}
+
+// Netem describes the traffic control settings applied to all egress traffic of a container.
+// The zero value describes an unimpaired network.
+type Netem struct {
+	// Delay added to every outgoing packet.
+	Delay time.Duration
+
+	// Percentage of outgoing packets to drop, in the range [0, 100].
+	LossPercent float64
+}
+
+// IsZero reports whether n describes an unimpaired network.
+func (n Netem) IsZero() bool {
+	return n.Delay == 0 && n.LossPercent == 0
+}
+
+// Validate returns an error if the settings cannot be applied.
+func (n Netem) Validate() error {
+	if n.Delay < 0 {
+		return fmt.Errorf("negative delay %s", n.Delay)
+	}
+	if n.LossPercent < 0 || n.LossPercent > 100 {
+		return fmt.Errorf("loss percent %v out of range [0, 100]", n.LossPercent)
+	}
+	return nil
+}
+
+// netemCommand returns the shell command that replaces the root qdisc with the settings in n,
+// or removes the root qdisc if n is the zero value.
+func netemCommand(n Netem) string {
+	if n.IsZero() {
+		// Deleting a missing qdisc fails, which is fine when clearing.
+		return "tc qdisc del dev " + netfaultInterface + " root || true"
+	}
+
+	args := []string{"tc", "qdisc", "replace", "dev", netfaultInterface, "root", "netem"}
+	if n.Delay > 0 {
+		args = append(args, "delay", strconv.FormatInt(n.Delay.Microseconds(), 10)+"us")
+	}
+	if n.LossPercent > 0 {
+		args = append(args, "loss", strconv.FormatFloat(n.LossPercent, 'f', -1, 64)+"%")
+	}
+	return strings.Join(args, " ")
+}
+
+// blockCommand returns the shell command that drops all traffic to and from the given IPs,
+// or removes those rules if block is false.
+// Blocking is idempotent: a rule is only inserted if it is not already present,
+// and unblocking removes every copy of a rule, so that a partition applied twice is still healed.
+func blockCommand(peerIPs []string, block bool) string {
+	cmds := make([]string, 0, 2*len(peerIPs))
+	for _, ip := range peerIPs {
+		for _, rule := range []string{
+			fmt.Sprintf("INPUT -s %s -j DROP", ip),
+			fmt.Sprintf("OUTPUT -d %s -j DROP", ip),
+		} {
+			if block {
+				cmds = append(cmds, fmt.Sprintf("{ iptables -C %[1]s 2>/dev/null || iptables -I %[1]s; }", rule))
+			} else {
+				// Removing a rule that is not present fails, which ends the loop.
+				cmds = append(cmds, fmt.Sprintf("while iptables -D %s 2>/dev/null; do :; done", rule))
+			}
+		}
+	}
+	return strings.Join(cmds, " && ")
+}
+
+// NetworkFaultInjector injects network faults into running containers,
+// such as partitions, latency, and packet loss.
+//
+// Faults are applied by running a short-lived sidecar container with the NET_ADMIN capability
+// that joins the network namespace of the target container,
+// so the target image does not need tc or iptables installed.
+// Faults only affect the target container; other containers on the same network are unaffected
+// unless they are targeted separately.
+type NetworkFaultInjector struct {
+	log      *zap.Logger
+	client   *client.Client
+	testName string
+}
+
+// NewNetworkFaultInjector returns a valid NetworkFaultInjector.
+// "testName" should match the t.Name() from DockerSetup to ensure proper cleanup.
+func NewNetworkFaultInjector(log *zap.Logger, cli *client.Client, testName string) *NetworkFaultInjector {
+	return &NetworkFaultInjector{
+		log:      log,
+		client:   cli,
+		testName: testName,
+	}
+}
+
+// ContainerIP returns the IP address of the container with the given ID on the given network.
+func (f *NetworkFaultInjector) ContainerIP(ctx context.Context, containerID, networkID string) (string, error) {
+	cjson, err := f.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return "", fmt.Errorf("inspect container %s: %w", containerID, err)
+	}
+	if cjson.NetworkSettings == nil {
+		return "", fmt.Errorf("container %s has no network settings", containerID)
+	}
+	for _, ep := range cjson.NetworkSettings.Networks {
+		if ep.NetworkID == networkID && ep.IPAddress != "" {
+			return ep.IPAddress, nil
+		}
+	}
+	return "", fmt.Errorf("container %s has no address on network %s", containerID, networkID)
+}
+
+// SetNetem replaces the traffic control settings on the container's egress traffic.
+// Passing the zero value of Netem removes any previously applied settings.
+func (f *NetworkFaultInjector) SetNetem(ctx context.Context, containerID string, n Netem) error {
+	if err := n.Validate(); err != nil {
+		return err
+	}
+	return f.run(ctx, containerID, netemCommand(n))
+}
+
+// Block drops all traffic between the container and the given peer IPs.
+func (f *NetworkFaultInjector) Block(ctx context.Context, containerID string, peerIPs ...string) error {
+	if len(peerIPs) == 0 {
+		return nil
+	}
+	return f.run(ctx, containerID, blockCommand(peerIPs, true))
+}
+
+// Unblock removes rules previously added by Block for the given peer IPs.
+func (f *NetworkFaultInjector) Unblock(ctx context.Context, containerID string, peerIPs ...string) error {
+	if len(peerIPs) == 0 {
+		return nil
+	}
+	return f.run(ctx, containerID, blockCommand(peerIPs, false))
+}
+
+// run executes the shell command in a sidecar container sharing the network namespace of containerID.
+func (f *NetworkFaultInjector) run(ctx context.Context, containerID, shellCmd string) error {
+	if err := ensureNetfaultImage(ctx, f.client); err != nil {
+		return err
+	}
+
+	containerName := fmt.Sprintf("interchaintest-netfault-%d-%s", time.Now().UnixNano(), RandLowerCaseLetterString(5))
+
+	f.log.Info(
+		"Injecting network fault",
+		zap.String("target_container", containerID),
+		zap.String("command", shellCmd),
+	)
+
+	cc, err := f.client.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: netfaultImageRef,
+
+			Entrypoint: []string{"sh", "-c"},
+			Cmd:        []string{shellCmd},
+
+			User: GetRootUserString(),
+
+			Labels: map[string]string{CleanupLabel: f.testName},
+		},
+		&container.HostConfig{
+			NetworkMode: container.NetworkMode("container:" + containerID),
+			CapAdd:      []string{"NET_ADMIN"},
+		},
+		nil, // Networking is inherited from the target container.
+		nil,
+		containerName,
+	)
+	if err != nil {
+		return fmt.Errorf("creating network fault container: %w", err)
+	}
+
+	defer func() {
+		if err := f.client.ContainerRemove(context.Background(), cc.ID, types.ContainerRemoveOptions{
+			Force: true,
+		}); err != nil {
+			f.log.Warn("Failed to remove network fault container", zap.String("container_id", cc.ID), zap.Error(err))
+		}
+	}()
+
+	if err := StartContainer(ctx, f.client, cc.ID); err != nil {
+		return fmt.Errorf("starting network fault container: %w", err)
+	}
+
+	waitCh, errCh := f.client.ContainerWait(ctx, cc.ID, container.WaitConditionNotRunning)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errCh:
+		return err
+	case res := <-waitCh:
+		if res.Error != nil {
+			return fmt.Errorf("waiting for network fault container: %s", res.Error.Message)
+		}
+
+		if res.StatusCode != 0 {
+			return fmt.Errorf("network fault command %q on container %s exited %d", shellCmd, containerID, res.StatusCode)
+		}
+	}
+
+	return nil
+}
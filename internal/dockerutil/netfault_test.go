@@ -0,0 +1,47 @@
+package dockerutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetemCommand(t *testing.T) {
+	for _, tt := range []struct {
+		in   Netem
+		want string
+	}{
+		{Netem{}, "tc qdisc del dev eth0 root || true"},
+		{Netem{Delay: 150 * time.Millisecond}, "tc qdisc replace dev eth0 root netem delay 150000us"},
+		{Netem{LossPercent: 12.5}, "tc qdisc replace dev eth0 root netem loss 12.5%"},
+		{Netem{Delay: time.Second, LossPercent: 100}, "tc qdisc replace dev eth0 root netem delay 1000000us loss 100%"},
+	} {
+		require.Equal(t, tt.want, netemCommand(tt.in))
+	}
+}
+
+func TestNetem_Validate(t *testing.T) {
+	require.NoError(t, Netem{}.Validate())
+	require.NoError(t, Netem{Delay: time.Second, LossPercent: 50}.Validate())
+
+	require.Error(t, Netem{Delay: -time.Second}.Validate())
+	require.Error(t, Netem{LossPercent: -1}.Validate())
+	require.Error(t, Netem{LossPercent: 101}.Validate())
+}
+
+func TestBlockCommand(t *testing.T) {
+	require.Equal(t,
+		"{ iptables -C INPUT -s 10.0.0.2 -j DROP 2>/dev/null || iptables -I INPUT -s 10.0.0.2 -j DROP; } && "+
+			"{ iptables -C OUTPUT -d 10.0.0.2 -j DROP 2>/dev/null || iptables -I OUTPUT -d 10.0.0.2 -j DROP; } && "+
+			"{ iptables -C INPUT -s 10.0.0.3 -j DROP 2>/dev/null || iptables -I INPUT -s 10.0.0.3 -j DROP; } && "+
+			"{ iptables -C OUTPUT -d 10.0.0.3 -j DROP 2>/dev/null || iptables -I OUTPUT -d 10.0.0.3 -j DROP; }",
+		blockCommand([]string{"10.0.0.2", "10.0.0.3"}, true),
+	)
+
+	require.Equal(t,
+		"while iptables -D INPUT -s 10.0.0.2 -j DROP 2>/dev/null; do :; done && "+
+			"while iptables -D OUTPUT -d 10.0.0.2 -j DROP 2>/dev/null; do :; done",
+		blockCommand([]string{"10.0.0.2"}, false),
+	)
+}
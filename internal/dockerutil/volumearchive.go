@@ -0,0 +1,142 @@
+package dockerutil
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"go.uber.org/zap"
+)
+
+// VolumeArchiver saves the entire contents of a Docker volume to a tar archive,
+// and extracts such an archive into another volume.
+type VolumeArchiver struct {
+	log *zap.Logger
+
+	cli *client.Client
+
+	testName string
+}
+
+// NewVolumeArchiver returns a new VolumeArchiver.
+func NewVolumeArchiver(log *zap.Logger, cli *client.Client, testName string) *VolumeArchiver {
+	return &VolumeArchiver{log: log, cli: cli, testName: testName}
+}
+
+const volumeArchiveMountPath = "/mnt/dockervolume"
+
+// Archive writes the contents of the volume to w, as a tar archive whose paths are relative to the volume root.
+// File ownership and permissions are preserved.
+//
+// The volume should not be in use by a running container while it is archived,
+// or the archive may contain partially written files.
+func (a *VolumeArchiver) Archive(ctx context.Context, volumeName string, w io.Writer) error {
+	id, cleanup, err := a.createContainer(ctx, volumeName, "archive")
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	rc, _, err := a.cli.CopyFromContainer(ctx, id, volumeArchiveMountPath)
+	if err != nil {
+		return fmt.Errorf("copying volume %s from container: %w", volumeName, err)
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	// Docker names every entry after the copied directory,
+	// so strip that prefix to make the archive independent of the mount path.
+	prefix := path.Base(volumeArchiveMountPath)
+	tr := tar.NewReader(rc)
+	tw := tar.NewWriter(w)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading tar of volume %s: %w", volumeName, err)
+		}
+
+		name := strings.TrimPrefix(strings.TrimPrefix(hdr.Name, prefix), "/")
+		if name == "" {
+			// The volume root itself.
+			continue
+		}
+		hdr.Name = name
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("writing tar header: %w", err)
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return fmt.Errorf("writing tar content: %w", err)
+		}
+	}
+
+	return tw.Close()
+}
+
+// Extract writes the contents of the tar archive r, as produced by Archive, into the volume.
+// Files already in the volume are overwritten if the archive contains the same path,
+// and left untouched otherwise.
+func (a *VolumeArchiver) Extract(ctx context.Context, volumeName string, r io.Reader) error {
+	id, cleanup, err := a.createContainer(ctx, volumeName, "extract")
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	// The zero value of the options preserves the ownership recorded in the archive.
+	if err := a.cli.CopyToContainer(ctx, id, volumeArchiveMountPath, r, types.CopyToContainerOptions{}); err != nil {
+		return fmt.Errorf("copying archive to volume %s: %w", volumeName, err)
+	}
+	return nil
+}
+
+// createContainer creates, but does not start, a container with the volume mounted,
+// which is sufficient for copying files in and out of the volume.
+func (a *VolumeArchiver) createContainer(ctx context.Context, volumeName, op string) (string, func(), error) {
+	if err := ensureBusybox(ctx, a.cli); err != nil {
+		return "", nil, err
+	}
+
+	containerName := fmt.Sprintf("interchaintest-%svolume-%d-%s", op, time.Now().UnixNano(), RandLowerCaseLetterString(5))
+
+	cc, err := a.cli.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: busyboxRef,
+
+			// Use root user to avoid permission issues when accessing files in the volume.
+			User: GetRootUserString(),
+
+			Labels: map[string]string{CleanupLabel: a.testName},
+		},
+		&container.HostConfig{
+			Binds: []string{volumeName + ":" + volumeArchiveMountPath},
+		},
+		nil, // No networking necessary.
+		nil,
+		containerName,
+	)
+	if err != nil {
+		return "", nil, fmt.Errorf("creating container: %w", err)
+	}
+
+	cleanup := func() {
+		if err := a.cli.ContainerRemove(context.Background(), cc.ID, types.ContainerRemoveOptions{
+			Force: true,
+		}); err != nil {
+			a.log.Warn("Failed to remove volume archive container", zap.String("container_id", cc.ID), zap.Error(err))
+		}
+	}
+	return cc.ID, cleanup, nil
+}
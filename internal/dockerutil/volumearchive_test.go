@@ -0,0 +1,60 @@
+package dockerutil_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	volumetypes "github.com/docker/docker/api/types/volume"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestVolumeArchiver(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	t.Parallel()
+
+	cli, network := interchaintest.DockerSetup(t)
+
+	ctx := context.Background()
+	newVolume := func() string {
+		v, err := cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
+			Labels: map[string]string{dockerutil.CleanupLabel: t.Name()},
+		})
+		require.NoError(t, err)
+		return v.Name
+	}
+	src, dst := newVolume(), newVolume()
+
+	fw := dockerutil.NewFileWriter(zaptest.NewLogger(t), cli, t.Name())
+	require.NoError(t, fw.WriteFile(ctx, src, "top.txt", []byte("top")))
+	require.NoError(t, fw.WriteFile(ctx, src, "a/b/nested.txt", []byte("nested")))
+
+	va := dockerutil.NewVolumeArchiver(zaptest.NewLogger(t), cli, t.Name())
+	var buf bytes.Buffer
+	require.NoError(t, va.Archive(ctx, src, &buf))
+	require.NoError(t, va.Extract(ctx, dst, &buf))
+
+	img := dockerutil.NewImage(
+		zaptest.NewLogger(t),
+		cli,
+		network,
+		t.Name(),
+		"busybox", "stable",
+	)
+	res := img.Run(
+		ctx,
+		[]string{"sh", "-c", "cat /mnt/test/top.txt /mnt/test/a/b/nested.txt"},
+		dockerutil.ContainerOptions{
+			Binds: []string{dst + ":/mnt/test"},
+			User:  dockerutil.GetRootUserString(),
+		},
+	)
+	require.NoError(t, res.Err)
+	require.Equal(t, "topnested", string(res.Stdout))
+}
@@ -0,0 +1,264 @@
+package interchaintest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"go.uber.org/zap"
+)
+
+// ContainerOwner is implemented by chains, chain nodes, and relayers whose processes run in Docker containers.
+// Any ContainerOwner can be the target of network faults injected through the Interchain.
+//
+// The built-in chain implementations report every node container,
+// *cosmos.ChainNode reports its own single container,
+// and the built-in Docker relayers report the container started by StartRelayer.
+type ContainerOwner interface {
+	// ContainerIDs returns the IDs of the currently running containers.
+	ContainerIDs() []string
+}
+
+// networkFaults tracks the faults injected into containers during a test,
+// so that they can be combined and later reverted.
+type networkFaults struct {
+	mu sync.Mutex
+
+	injector  *dockerutil.NetworkFaultInjector
+	networkID string
+
+	// Key: container ID; Value: current traffic control settings.
+	netem map[string]dockerutil.Netem
+
+	// Key: container ID; Value: set of peer IPs whose traffic is dropped.
+	blocked map[string]map[string]struct{}
+}
+
+// newNetworkFaults returns the fault state for an Interchain built with the given options.
+func newNetworkFaults(log *zap.Logger, opts InterchainBuildOptions) *networkFaults {
+	return &networkFaults{
+		injector:  dockerutil.NewNetworkFaultInjector(log, opts.Client, opts.TestName),
+		networkID: opts.NetworkID,
+
+		netem:   make(map[string]dockerutil.Netem),
+		blocked: make(map[string]map[string]struct{}),
+	}
+}
+
+// errNotBuilt is returned by the network fault methods when called before Build.
+var errNotBuilt = errors.New("network faults require Interchain.Build to have been called")
+
+// faults returns the network fault state, or errNotBuilt if Build has not created it yet.
+func (ic *Interchain) faults() (*networkFaults, error) {
+	if ic.nf == nil {
+		return nil, errNotBuilt
+	}
+	return ic.nf, nil
+}
+
+// Partition drops all network traffic between the containers of a and b,
+// for example between a chain and a relayer, or between two nodes of the same chain.
+// Traffic to and from other containers is unaffected.
+//
+// Relayers only report their long-running container started by StartRelayer,
+// so the relayer must be running when Partition is called,
+// and one-off commands such as Flush are not partitioned.
+//
+// Call Heal to restore traffic between a and b.
+func (ic *Interchain) Partition(ctx context.Context, a, b ContainerOwner) error {
+	return ic.setPartition(ctx, a, b, true)
+}
+
+// Heal restores network traffic between a and b previously dropped by Partition.
+func (ic *Interchain) Heal(ctx context.Context, a, b ContainerOwner) error {
+	return ic.setPartition(ctx, a, b, false)
+}
+
+func (ic *Interchain) setPartition(ctx context.Context, a, b ContainerOwner, partition bool) error {
+	nf, err := ic.faults()
+	if err != nil {
+		return err
+	}
+
+	aIDs, err := containerIDs(a)
+	if err != nil {
+		return err
+	}
+	bIDs, err := containerIDs(b)
+	if err != nil {
+		return err
+	}
+
+	nf.mu.Lock()
+	defer nf.mu.Unlock()
+
+	aIPs, err := nf.containerIPs(ctx, aIDs)
+	if err != nil {
+		return err
+	}
+	bIPs, err := nf.containerIPs(ctx, bIDs)
+	if err != nil {
+		return err
+	}
+
+	// Rules are applied on both sides, so that the partition holds
+	// even if one side's container is restarted and loses its rules.
+	for _, id := range aIDs {
+		if err := nf.setBlocked(ctx, id, bIPs, partition); err != nil {
+			return err
+		}
+	}
+	for _, id := range bIDs {
+		if err := nf.setBlocked(ctx, id, aIPs, partition); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddLatency delays all outgoing network traffic of every container of target by d.
+// Any packet loss previously configured through DropPackets is preserved.
+// Passing a zero duration removes the added latency.
+func (ic *Interchain) AddLatency(ctx context.Context, target ContainerOwner, d time.Duration) error {
+	return ic.updateNetem(ctx, target, func(n *dockerutil.Netem) {
+		n.Delay = d
+	})
+}
+
+// DropPackets randomly drops the given percentage, in the range [0, 100],
+// of outgoing network traffic of every container of target.
+// Any latency previously configured through AddLatency is preserved.
+// Passing zero stops dropping packets.
+func (ic *Interchain) DropPackets(ctx context.Context, target ContainerOwner, percent float64) error {
+	return ic.updateNetem(ctx, target, func(n *dockerutil.Netem) {
+		n.LossPercent = percent
+	})
+}
+
+func (ic *Interchain) updateNetem(ctx context.Context, target ContainerOwner, update func(*dockerutil.Netem)) error {
+	nf, err := ic.faults()
+	if err != nil {
+		return err
+	}
+
+	ids, err := containerIDs(target)
+	if err != nil {
+		return err
+	}
+
+	nf.mu.Lock()
+	defer nf.mu.Unlock()
+
+	for _, id := range ids {
+		n := nf.netem[id]
+		update(&n)
+
+		if err := nf.injector.SetNetem(ctx, id, n); err != nil {
+			return fmt.Errorf("failed to set network conditions on container %s: %w", id, err)
+		}
+
+		if n.IsZero() {
+			delete(nf.netem, id)
+		} else {
+			nf.netem[id] = n
+		}
+	}
+
+	return nil
+}
+
+// ClearNetworkFaults reverts every partition, latency, and packet loss fault
+// injected through the Interchain.
+func (ic *Interchain) ClearNetworkFaults(ctx context.Context) error {
+	nf, err := ic.faults()
+	if err != nil {
+		return err
+	}
+
+	nf.mu.Lock()
+	defer nf.mu.Unlock()
+
+	for id := range nf.netem {
+		if err := nf.injector.SetNetem(ctx, id, dockerutil.Netem{}); err != nil {
+			return fmt.Errorf("failed to clear network conditions on container %s: %w", id, err)
+		}
+		delete(nf.netem, id)
+	}
+
+	for id, ips := range nf.blocked {
+		peers := make([]string, 0, len(ips))
+		for ip := range ips {
+			peers = append(peers, ip)
+		}
+		if err := nf.setBlocked(ctx, id, peers, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setBlocked adds or removes drop rules for peerIPs on the container with the given ID.
+// The caller must hold nf.mu.
+func (nf *networkFaults) setBlocked(ctx context.Context, id string, peerIPs []string, block bool) error {
+	if block {
+		if err := nf.injector.Block(ctx, id, peerIPs...); err != nil {
+			return fmt.Errorf("failed to partition container %s: %w", id, err)
+		}
+		if nf.blocked[id] == nil {
+			nf.blocked[id] = make(map[string]struct{}, len(peerIPs))
+		}
+		for _, ip := range peerIPs {
+			nf.blocked[id][ip] = struct{}{}
+		}
+		return nil
+	}
+
+	if err := nf.injector.Unblock(ctx, id, peerIPs...); err != nil {
+		return fmt.Errorf("failed to heal container %s: %w", id, err)
+	}
+	for _, ip := range peerIPs {
+		delete(nf.blocked[id], ip)
+	}
+	if len(nf.blocked[id]) == 0 {
+		delete(nf.blocked, id)
+	}
+	return nil
+}
+
+// containerIPs resolves the IP addresses of the given containers on the interchain's network.
+func (nf *networkFaults) containerIPs(ctx context.Context, ids []string) ([]string, error) {
+	ips := make([]string, len(ids))
+	for i, id := range ids {
+		ip, err := nf.injector.ContainerIP(ctx, id, nf.networkID)
+		if err != nil {
+			return nil, err
+		}
+		ips[i] = ip
+	}
+	return ips, nil
+}
+
+// containerIDs returns the non-empty container IDs of o,
+// or an error if o has no running containers.
+func containerIDs(o ContainerOwner) ([]string, error) {
+	if o == nil {
+		return nil, errors.New("cannot inject network faults into nil target")
+	}
+
+	var ids []string
+	for _, id := range o.ContainerIDs() {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%v has no running containers", o)
+	}
+	return ids, nil
+}
@@ -17,6 +17,24 @@ const (
 
 	// Whether the relayer supports a one-off flush command.
 	Flush
+
+	// Whether the relayer can create and relay on ORDERED channels.
+	OrderedChannel
+
+	// Whether the relayer can complete the handshake of an interchain accounts channel
+	// opened by a controller chain.
+	ICAChannel
+
+	// Whether the relayer can close channels.
+	ChannelClose
+
+	// Whether the relayer can restrict each path to a subset of channels,
+	// through the ibc.ChannelFilter passed to UpdatePath.
+	MultiPathFilter
+
+	// Whether the relayer implements SharedPathRelayer,
+	// to relay over the clients and connection of a path linked by another relayer.
+	SharedPath
 )
 
 // FullCapabilities returns a mapping of all known relayer features to true,
@@ -29,5 +47,11 @@ func FullCapabilities() map[Capability]bool {
 		HeightTimeout:    true,
 
 		Flush: true,
+
+		OrderedChannel:  true,
+		ICAChannel:      true,
+		ChannelClose:    true,
+		MultiPathFilter: true,
+		SharedPath:      true,
 	}
 }
@@ -11,11 +11,16 @@ func _() {
 	_ = x[TimestampTimeout-0]
 	_ = x[HeightTimeout-1]
 	_ = x[Flush-2]
+	_ = x[OrderedChannel-3]
+	_ = x[ICAChannel-4]
+	_ = x[ChannelClose-5]
+	_ = x[MultiPathFilter-6]
+	_ = x[SharedPath-7]
 }
 
-const _Capability_name = "TimestampTimeoutHeightTimeoutFlush"
+const _Capability_name = "TimestampTimeoutHeightTimeoutFlushOrderedChannelICAChannelChannelCloseMultiPathFilterSharedPath"
 
-var _Capability_index = [...]uint8{0, 16, 29, 34}
+var _Capability_index = [...]uint8{0, 16, 29, 34, 48, 58, 70, 85, 95}
 
 func (i Capability) String() string {
 	if i < 0 || i >= Capability(len(_Capability_index)-1) {
@@ -157,6 +157,38 @@ func (r *DockerRelayer) ModifyTomlConfigFile(ctx context.Context, relativePath s
 	return testutil.ModifyTomlConfigFile(ctx, r.log, r.client, r.testName, r.volumeName, relativePath, modification)
 }
 
+// IsRunning reports whether the relayer was started with StartRelayer and not stopped since.
+func (r *DockerRelayer) IsRunning() bool {
+	return r.containerLifecycle != nil
+}
+
+// HomeDirHoldsState reports whether the relayer home directory holds the relayer's full state,
+// so that ArchiveHomeDir and ExtractHomeDir can save and restore the relayer.
+func (r *DockerRelayer) HomeDirHoldsState() bool {
+	return true
+}
+
+// ArchiveHomeDir writes the entire relayer home directory to w as a tar archive,
+// for example to save the relayer's keys and paths.
+// The relayer should be stopped while its home directory is archived.
+func (r *DockerRelayer) ArchiveHomeDir(ctx context.Context, w io.Writer) error {
+	va := dockerutil.NewVolumeArchiver(r.log, r.client, r.testName)
+	if err := va.Archive(ctx, r.volumeName, w); err != nil {
+		return fmt.Errorf("failed to archive home directory: %w", err)
+	}
+	return nil
+}
+
+// ExtractHomeDir extracts a tar archive produced by ArchiveHomeDir into the relayer home directory,
+// overwriting any files present in both.
+func (r *DockerRelayer) ExtractHomeDir(ctx context.Context, rd io.Reader) error {
+	va := dockerutil.NewVolumeArchiver(r.log, r.client, r.testName)
+	if err := va.Extract(ctx, r.volumeName, rd); err != nil {
+		return fmt.Errorf("failed to extract home directory: %w", err)
+	}
+	return nil
+}
+
 // AddWallet adds a stores a wallet for the given chain ID.
 func (r *DockerRelayer) AddWallet(chainID string, wallet ibc.Wallet) {
 	r.wallets[chainID] = wallet
@@ -363,7 +395,7 @@ func (r *DockerRelayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecRep
 	r.containerLifecycle = dockerutil.NewContainerLifecycle(r.log, r.client, containerName)
 
 	if err := r.containerLifecycle.CreateContainer(
-		ctx, r.testName, r.networkID, containerImage, nil,
+		ctx, r.testName, r.networkID, containerImage, metricsPorts(r.c),
 		r.Bind(), r.HostName(joinedPaths), cmd,
 	); err != nil {
 		return err
@@ -445,6 +477,16 @@ func (r *DockerRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecRepo
 	return nil
 }
 
+// ContainerIDs returns the ID of the long-running relayer container created by StartRelayer,
+// or nil if the relayer is not currently running.
+// One-off containers created by Exec are not included.
+func (r *DockerRelayer) ContainerIDs() []string {
+	if r.containerLifecycle == nil {
+		return nil
+	}
+	return []string{r.containerLifecycle.ContainerID()}
+}
+
 func (r *DockerRelayer) containerImage() ibc.DockerImage {
 	if r.customImage != nil {
 		return *r.customImage
@@ -13,7 +13,10 @@ import (
 	"github.com/cosmos/ibc-go/v7/modules/core/23-commitment/types"
 )
 
-var _ relayer.RelayerCommander = &commander{}
+var (
+	_ relayer.RelayerCommander = &commander{}
+	_ relayer.MetricsCommander = &commander{}
+)
 
 type commander struct {
 	log *zap.Logger
@@ -138,13 +141,36 @@ func (c commander) StartRelayer(homeDir string, pathNames ...string) []string {
 	return []string{hermes, "--config", fmt.Sprintf("%s/%s", homeDir, hermesConfigPath), "start", "--full-scan"}
 }
 
-func (c commander) CreateWallet(keyName, address, mnemonic string) ibc.Wallet {
-	return NewWallet(keyName, address, mnemonic)
+func (c commander) MetricsPort() string {
+	return fmt.Sprintf("%d/tcp", telemetryPort)
 }
 
-func (c commander) UpdatePath(pathName, homeDir string, filter ibc.ChannelFilter) []string {
-	// TODO: figure out how to implement this.
-	panic("implement me")
+func (c commander) MetricsPath() string {
+	return "/metrics"
+}
+
+// MetricNames lists counters both with and without the _total suffix,
+// which hermes versions add or not depending on their telemetry exporter.
+// Confirmed packets are only counted with tx_confirmation enabled, as in the generated config.
+func (c commander) MetricNames() relayer.MetricNames {
+	return relayer.MetricNames{
+		PacketsRelayed: relayer.MetricName{
+			Names:      []string{"ibc_receive_packets_confirmed", "ibc_receive_packets_confirmed_total"},
+			ChainLabel: "dst_chain",
+		},
+		TxErrors: relayer.MetricName{
+			Names: []string{"broadcast_errors", "broadcast_errors_total"},
+		},
+		WalletBalance: relayer.MetricName{
+			Names:      []string{"wallet_balance"},
+			ChainLabel: "chain",
+			DenomLabel: "denom",
+		},
+	}
+}
+
+func (c commander) CreateWallet(keyName, address, mnemonic string) ibc.Wallet {
+	return NewWallet(keyName, address, mnemonic)
 }
 
 // the following methods do not have a single command that cleanly maps to a single hermes command without
@@ -154,6 +180,10 @@ func (c commander) UpdateClients(pathName, homeDir string) []string {
 	panic("update clients implemented in hermes relayer not the commander")
 }
 
+func (c commander) UpdatePath(pathName, homeDir string, filter ibc.ChannelFilter) []string {
+	panic("update path implemented in hermes relayer not the commander")
+}
+
 func (c commander) GeneratePath(srcChainID, dstChainID, pathName, homeDir string) []string {
 	panic("generate path implemented in hermes relayer not the commander")
 }
@@ -46,7 +46,8 @@ func NewConfig(chainConfigs ...ChainConfig) Config {
 				Numerator:   "1",
 				Denominator: "3",
 			},
-			MemoPrefix: "hermes",
+			MemoPrefix:   "hermes",
+			PacketFilter: hermesCfg.packetFilter,
 		},
 		)
 	}
@@ -67,18 +68,22 @@ func NewConfig(chainConfigs ...ChainConfig) Config {
 			Channels: Channels{
 				Enabled: true,
 			},
+			// Confirming transactions lets hermes count the packets it relayed in its metrics.
 			Packets: Packets{
 				Enabled:        true,
 				ClearInterval:  0,
 				ClearOnStart:   true,
-				TxConfirmation: false,
+				TxConfirmation: true,
 			},
 		},
 		Rest: Rest{
 			Enabled: false,
 		},
+		// Telemetry is served on all interfaces, for Relayer.Metrics to scrape from the host.
 		Telemetry: Telemetry{
-			Enabled: false,
+			Enabled: true,
+			Host:    "0.0.0.0",
+			Port:    telemetryPort,
 		},
 		Chains: chains,
 	}
@@ -171,4 +176,13 @@ type Chain struct {
 	TrustingPeriod string         `toml:"trusting_period"`
 	TrustThreshold TrustThreshold `toml:"trust_threshold"`
 	MemoPrefix     string         `toml:"memo_prefix,omitempty"`
+	PacketFilter   *PacketFilter  `toml:"packet_filter,omitempty"`
+}
+
+// PacketFilter restricts the channels of a chain that hermes relays packets on.
+type PacketFilter struct {
+	// Either "allow" or "deny".
+	Policy string `toml:"policy"`
+	// Port and channel ID pairs, which may contain wildcards.
+	List [][]string `toml:"list"`
 }
@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -23,32 +25,54 @@ const (
 	hermesDefaultUidGid = "1000:1000"
 	hermesHome          = "/home/hermes"
 	hermesConfigPath    = ".hermes/config.toml"
+	telemetryPort       = 3001
+
+	filterAllowlist = "allowlist"
+	filterDenylist  = "denylist"
 )
 
 var (
-	_ ibc.Relayer = &Relayer{}
+	_ ibc.Relayer               = &Relayer{}
+	_ relayer.SharedPathRelayer = &Relayer{}
 	// parseRestoreKeyOutputPattern extracts the address from the hermes output.
 	// SUCCESS Restored key 'g2-2' (cosmos1czklnpzwaq3hfxtv6ne4vas2p9m5q3p3fgkz8e) on chain g2-2
 	parseRestoreKeyOutputPattern = regexp.MustCompile(`\((.*)\)`)
 )
 
+// Capabilities returns the set of capabilities of the hermes relayer.
+func Capabilities() map[relayer.Capability]bool {
+	caps := relayer.FullCapabilities()
+	// Hermes filters packets per chain rather than per path,
+	// so a filter set on one path applies to every path sharing its source chain.
+	caps[relayer.MultiPathFilter] = false
+	return caps
+}
+
 // Relayer is the ibc.Relayer implementation for hermes.
 type Relayer struct {
 	*relayer.DockerRelayer
 	paths        map[string]*pathConfiguration
 	chainConfigs []ChainConfig
+
+	// Paths passed to StartRelayer while the relayer is running, to restart it after a config change.
+	running      bool
+	runningPaths []string
 }
 
 // ChainConfig holds all values required to write an entry in the "chains" section in the hermes config file.
 type ChainConfig struct {
 	cfg                        ibc.ChainConfig
 	keyName, rpcAddr, grpcAddr string
+	packetFilter               *PacketFilter
 }
 
 // pathConfiguration represents the concept of a "path" which is implemented at the interchain test level rather
 // than the hermes level.
 type pathConfiguration struct {
 	chainA, chainB pathChainConfig
+	filter         ibc.ChannelFilter
+	// Port and channel IDs on chainB of the counterparties of the filter's channels.
+	counterpartyFilter [][]string
 }
 
 // pathChainConfig holds all values that will be required when interacting with a path.
@@ -229,6 +253,190 @@ func (r *Relayer) Flush(ctx context.Context, rep ibc.RelayerExecReporter, pathNa
 	return res.Err
 }
 
+// UpdatePath sets the channel filter of the path, by rewriting the packet filters of the path's chains
+// in the hermes config file. The filter rule is either "allowlist" or "denylist", and the channel IDs refer
+// to the path's source chain. An empty rule relays on every channel.
+//
+// Hermes filters the packets sent from a chain by the channels of that chain, so the counterparty channels,
+// queried from the source chain, are added to the filter of the path's destination chain. That way the filter
+// applies to packets in both directions, like the filter of rly.
+//
+// As hermes filters packets per chain, paths sharing a chain must not use different rules,
+// and their channel lists are merged. A running relayer is restarted to load the new config.
+func (r *Relayer) UpdatePath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, filter ibc.ChannelFilter) error {
+	path, ok := r.paths[pathName]
+	if !ok {
+		return fmt.Errorf("path %s not found", pathName)
+	}
+	switch filter.Rule {
+	case "", filterAllowlist, filterDenylist:
+	default:
+		return fmt.Errorf("invalid channel filter rule %q", filter.Rule)
+	}
+
+	var counterpartyFilter [][]string
+	if filter.Rule != "" && len(filter.ChannelList) > 0 {
+		channels, err := r.GetChannels(ctx, rep, path.chainA.chainID)
+		if err != nil {
+			return fmt.Errorf("failed to get channels of %s: %w", path.chainA.chainID, err)
+		}
+		counterpartyFilter, err = counterpartyChannels(channels, filter.ChannelList)
+		if err != nil {
+			return fmt.Errorf("chain %s: %w", path.chainA.chainID, err)
+		}
+	}
+
+	prevFilter, prevCounterpartyFilter := path.filter, path.counterpartyFilter
+	prevPacketFilters := make([]*PacketFilter, len(r.chainConfigs))
+	for i := range r.chainConfigs {
+		prevPacketFilters[i] = r.chainConfigs[i].packetFilter
+	}
+	rollback := func() {
+		path.filter, path.counterpartyFilter = prevFilter, prevCounterpartyFilter
+		for i := range r.chainConfigs {
+			r.chainConfigs[i].packetFilter = prevPacketFilters[i]
+		}
+	}
+
+	path.filter, path.counterpartyFilter = filter, counterpartyFilter
+	packetFilters, err := r.packetFilters()
+	if err != nil {
+		rollback()
+		return err
+	}
+	for i := range r.chainConfigs {
+		r.chainConfigs[i].packetFilter = packetFilters[r.chainConfigs[i].cfg.ChainID]
+	}
+
+	if err := r.writeConfig(ctx); err != nil {
+		rollback()
+		return err
+	}
+	if err := r.validateConfig(ctx, rep); err != nil {
+		rollback()
+		if restoreErr := r.writeConfig(ctx); restoreErr != nil {
+			return fmt.Errorf("%w (restoring previous config: %v)", err, restoreErr)
+		}
+		return err
+	}
+
+	if !r.running {
+		return nil
+	}
+	pathNames := r.runningPaths
+	if err := r.StopRelayer(ctx, rep); err != nil {
+		return fmt.Errorf("failed to stop hermes to reload config: %w", err)
+	}
+	return r.StartRelayer(ctx, rep, pathNames...)
+}
+
+// counterpartyChannels returns the port and channel IDs of the counterparties of the channels with channelIDs.
+func counterpartyChannels(channels []ibc.ChannelOutput, channelIDs []string) ([][]string, error) {
+	counterparties := make([][]string, len(channelIDs))
+channelIDs:
+	for i, channelID := range channelIDs {
+		for _, ch := range channels {
+			if ch.ChannelID == channelID {
+				counterparties[i] = []string{ch.Counterparty.PortID, ch.Counterparty.ChannelID}
+				continue channelIDs
+			}
+		}
+		return nil, fmt.Errorf("channel %s not found", channelID)
+	}
+	return counterparties, nil
+}
+
+// writeConfig writes the hermes config file generated from the chain configs.
+func (r *Relayer) writeConfig(ctx context.Context) error {
+	configContent, err := toml.Marshal(NewConfig(r.chainConfigs...))
+	if err != nil {
+		return fmt.Errorf("failed to generate config content: %w", err)
+	}
+	if err := r.WriteFileToHomeDir(ctx, hermesConfigPath, configContent); err != nil {
+		return fmt.Errorf("failed to write hermes config: %w", err)
+	}
+	return nil
+}
+
+// UsePath sets the clients and connection of the path, so that it relays over ones linked by another relayer.
+// Hermes relays on every channel of its chains, so this only records the identifiers used by other path methods.
+func (r *Relayer) UsePath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, src, dst relayer.PathEnd) error {
+	path, ok := r.paths[pathName]
+	if !ok {
+		return fmt.Errorf("path %s not found", pathName)
+	}
+	path.chainA.clientID, path.chainA.connectionID = src.ClientID, src.ConnectionID
+	path.chainB.clientID, path.chainB.connectionID = dst.ClientID, dst.ConnectionID
+	return nil
+}
+
+// StartRelayer starts hermes, remembering the paths so that UpdatePath can restart it.
+func (r *Relayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecReporter, pathNames ...string) error {
+	if err := r.DockerRelayer.StartRelayer(ctx, rep, pathNames...); err != nil {
+		return err
+	}
+	r.running, r.runningPaths = true, pathNames
+	return nil
+}
+
+// StopRelayer stops hermes.
+func (r *Relayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	if err := r.DockerRelayer.StopRelayer(ctx, rep); err != nil {
+		return err
+	}
+	r.running, r.runningPaths = false, nil
+	return nil
+}
+
+// packetFilters returns the hermes packet filter of each chain, combining the channel filters of the paths
+// on their source chain and the counterparty channels on their destination chain. Chains without a filter are omitted.
+func (r *Relayer) packetFilters() (map[string]*PacketFilter, error) {
+	filters := make(map[string]*PacketFilter)
+	add := func(pathName, chainID, policy string, entries [][]string) error {
+		f, ok := filters[chainID]
+		if !ok {
+			f = &PacketFilter{Policy: policy, List: [][]string{}}
+			filters[chainID] = f
+		}
+		if f.Policy != policy {
+			return fmt.Errorf("path %s: hermes cannot combine allowlist and denylist filters on chain %s", pathName, chainID)
+		}
+		f.List = append(f.List, entries...)
+		return nil
+	}
+
+	// Iterate paths in a stable order, for a deterministic config file.
+	pathNames := make([]string, 0, len(r.paths))
+	for name := range r.paths {
+		pathNames = append(pathNames, name)
+	}
+	sort.Strings(pathNames)
+
+	for _, name := range pathNames {
+		path := r.paths[name]
+		if path.filter.Rule == "" {
+			continue
+		}
+		policy := "allow"
+		if path.filter.Rule == filterDenylist {
+			policy = "deny"
+		}
+
+		// Filters match source channels on any port, as ibc.ChannelFilter only lists channel IDs.
+		entries := make([][]string, len(path.filter.ChannelList))
+		for i, channelID := range path.filter.ChannelList {
+			entries[i] = []string{"*", channelID}
+		}
+		if err := add(name, path.chainA.chainID, policy, entries); err != nil {
+			return nil, err
+		}
+		if err := add(name, path.chainB.chainID, policy, path.counterpartyFilter); err != nil {
+			return nil, err
+		}
+	}
+	return filters, nil
+}
+
 // GeneratePath establishes an in memory path representation. The concept does not exist in hermes, so it is handled
 // at the interchain test level.
 func (r *Relayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string) error {
@@ -246,6 +454,18 @@ func (r *Relayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter,
 	return nil
 }
 
+// HomeDirHoldsState reports false for hermes, as paths and chain configurations are kept in memory
+// rather than in the home directory.
+func (r *Relayer) HomeDirHoldsState() bool {
+	return false
+}
+
+// ArchiveHomeDir is not supported for hermes, as paths and chain configurations are kept in memory
+// rather than in the home directory, so an archive would not capture the relayer's full state.
+func (r *Relayer) ArchiveHomeDir(ctx context.Context, w io.Writer) error {
+	return fmt.Errorf("hermes relayer state is not fully contained in its home directory and cannot be archived")
+}
+
 // configContent returns the contents of the hermes config file as a byte array. Note: as hermes expects a single file
 // rather than multiple config files, we need to maintain a list of chain configs each time they are added to write the
 // full correct file update calling Relayer.AddChainConfiguration.
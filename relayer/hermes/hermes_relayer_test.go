@@ -0,0 +1,107 @@
+package hermes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pelletier/go-toml"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacketFilters(t *testing.T) {
+	r := &Relayer{paths: map[string]*pathConfiguration{
+		"ab": {chainA: pathChainConfig{chainID: "a"}, chainB: pathChainConfig{chainID: "b"}},
+		"ac": {chainA: pathChainConfig{chainID: "a"}, chainB: pathChainConfig{chainID: "c"}},
+		"db": {chainA: pathChainConfig{chainID: "d"}, chainB: pathChainConfig{chainID: "b"}},
+	}}
+
+	filters, err := r.packetFilters()
+	require.NoError(t, err)
+	require.Empty(t, filters)
+
+	// Each path's filter applies to the channels on its source chain, and to their counterparties on its destination chain,
+	// so that packets are filtered in both directions.
+	r.paths["ab"].filter = ibc.ChannelFilter{Rule: filterAllowlist, ChannelList: []string{"channel-0"}}
+	r.paths["ab"].counterpartyFilter = [][]string{{"transfer", "channel-10"}}
+	r.paths["ac"].filter = ibc.ChannelFilter{Rule: filterAllowlist, ChannelList: []string{"channel-1"}}
+	r.paths["ac"].counterpartyFilter = [][]string{{"transfer", "channel-20"}}
+	r.paths["db"].filter = ibc.ChannelFilter{Rule: filterAllowlist, ChannelList: []string{"channel-2"}}
+	r.paths["db"].counterpartyFilter = [][]string{{"transfer", "channel-11"}}
+	filters, err = r.packetFilters()
+	require.NoError(t, err)
+	require.Equal(t, map[string]*PacketFilter{
+		"a": {Policy: "allow", List: [][]string{{"*", "channel-0"}, {"*", "channel-1"}}},
+		"b": {Policy: "allow", List: [][]string{{"transfer", "channel-10"}, {"transfer", "channel-11"}}},
+		"c": {Policy: "allow", List: [][]string{{"transfer", "channel-20"}}},
+		"d": {Policy: "allow", List: [][]string{{"*", "channel-2"}}},
+	}, filters)
+
+	// Paths sharing a destination chain conflict too.
+	r.paths["db"].filter.Rule = filterDenylist
+	_, err = r.packetFilters()
+	require.ErrorContains(t, err, "chain b")
+
+	r.paths["db"].filter.Rule = filterAllowlist
+	r.paths["ac"].filter.Rule = filterDenylist
+	_, err = r.packetFilters()
+	require.ErrorContains(t, err, "chain a")
+}
+
+func TestCounterpartyChannels(t *testing.T) {
+	channels := []ibc.ChannelOutput{
+		{ChannelID: "channel-0", PortID: "transfer", Counterparty: ibc.ChannelCounterparty{PortID: "transfer", ChannelID: "channel-10"}},
+		{ChannelID: "channel-1", PortID: "icahost", Counterparty: ibc.ChannelCounterparty{PortID: "icacontroller-x", ChannelID: "channel-11"}},
+	}
+
+	counterparties, err := counterpartyChannels(channels, []string{"channel-1", "channel-0"})
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"icacontroller-x", "channel-11"}, {"transfer", "channel-10"}}, counterparties)
+
+	_, err = counterpartyChannels(channels, []string{"channel-2"})
+	require.ErrorContains(t, err, "channel-2 not found")
+}
+
+func TestConfigPacketFilter(t *testing.T) {
+	chainCfg := func(chainID string, filter *PacketFilter) ChainConfig {
+		return ChainConfig{
+			cfg:          ibc.ChainConfig{ChainID: chainID, Denom: "stake", GasPrices: "0.01stake"},
+			packetFilter: filter,
+		}
+	}
+	bz, err := toml.Marshal(NewConfig(
+		chainCfg("a", &PacketFilter{Policy: "allow", List: [][]string{{"*", "channel-0"}}}),
+		chainCfg("b", nil),
+	))
+	require.NoError(t, err)
+
+	var cfg Config
+	require.NoError(t, toml.Unmarshal(bz, &cfg))
+	require.Len(t, cfg.Chains, 2)
+	require.Equal(t, &PacketFilter{Policy: "allow", List: [][]string{{"*", "channel-0"}}}, cfg.Chains[0].PacketFilter)
+	require.Nil(t, cfg.Chains[1].PacketFilter)
+}
+
+func TestUpdatePathRollback(t *testing.T) {
+	allowA := ibc.ChannelFilter{Rule: filterAllowlist, ChannelList: []string{"channel-0"}}
+	allowAFilter := &PacketFilter{Policy: "allow", List: [][]string{{"*", "channel-0"}}}
+	r := &Relayer{
+		paths: map[string]*pathConfiguration{
+			"ab": {chainA: pathChainConfig{chainID: "a"}, chainB: pathChainConfig{chainID: "b"}, filter: allowA},
+			"ac": {chainA: pathChainConfig{chainID: "a"}, chainB: pathChainConfig{chainID: "c"}},
+		},
+		chainConfigs: []ChainConfig{
+			{cfg: ibc.ChainConfig{ChainID: "a"}, packetFilter: allowAFilter},
+			{cfg: ibc.ChainConfig{ChainID: "b"}},
+		},
+	}
+
+	// The rule conflicts with the allowlist of chain a, before any channel is looked up.
+	err := r.UpdatePath(context.Background(), nil, "ac", ibc.ChannelFilter{Rule: filterDenylist})
+	require.ErrorContains(t, err, "chain a")
+	require.Equal(t, ibc.ChannelFilter{}, r.paths["ac"].filter)
+	require.Nil(t, r.paths["ac"].counterpartyFilter)
+	require.Equal(t, allowA, r.paths["ab"].filter)
+	require.Same(t, allowAFilter, r.chainConfigs[0].packetFilter)
+	require.Nil(t, r.chainConfigs[1].packetFilter)
+}
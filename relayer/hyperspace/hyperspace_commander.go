@@ -12,6 +12,7 @@ import (
 	"github.com/pelletier/go-toml/v2"
 	"github.com/strangelove-ventures/interchaintest/v7/chain/polkadot"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
 	"go.uber.org/zap"
 )
 
@@ -41,6 +42,20 @@ func (hyperspaceCommander) DockerUser() string {
 	return "1000:1000" // docker run -it --rm --entrypoint echo ghcr.io/cosmos/relayer "$(id -u):$(id -g)"
 }
 
+// MetricsPort is the port of the Prometheus endpoint enabled in the core config.
+func (hyperspaceCommander) MetricsPort() string {
+	return prometheusPort + "/tcp"
+}
+
+func (hyperspaceCommander) MetricsPath() string {
+	return "/metrics"
+}
+
+// MetricNames is empty, as hyperspace reports none of the normalized metrics.
+func (hyperspaceCommander) MetricNames() relayer.MetricNames {
+	return relayer.MetricNames{}
+}
+
 func (c *hyperspaceCommander) AddChainConfiguration(containerFilePath, homeDir string) []string {
 	fmt.Println("[hyperspace] AddChainConfiguration ", containerFilePath, homeDir)
 	//c.chainConfigPaths = append(c.chainConfigPaths, containerFilePath)
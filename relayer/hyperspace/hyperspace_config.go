@@ -15,7 +15,7 @@ import (
 )
 
 type HyperspaceRelayerCoreConfig struct {
-	PrometheusEndpoint string
+	PrometheusEndpoint string `toml:"prometheus_endpoint"`
 }
 
 type HyperspaceRelayerSubstrateChainConfig struct {
@@ -61,6 +61,8 @@ type HyperspaceRelayerCosmosChainConfig struct {
 const (
 	HyperspaceDefaultContainerImage   = "hyperspace"
 	HyperspaceDefaultContainerVersion = "local"
+
+	prometheusPort = "9615"
 )
 
 func GenKeyEntry(bech32Prefix, coinType, mnemonic string) KeyEntry {
@@ -43,7 +43,8 @@ func NewHyperspaceRelayer(log *zap.Logger, testName string, cli *client.Client,
 	}
 
 	coreConfig := HyperspaceRelayerCoreConfig{
-		PrometheusEndpoint: "",
+		// Served on all interfaces, for Metrics to scrape from the host.
+		PrometheusEndpoint: "0.0.0.0:" + prometheusPort,
 	}
 	bytes, err := toml.Marshal(coreConfig)
 	if err != nil {
@@ -61,13 +62,23 @@ func NewHyperspaceRelayer(log *zap.Logger, testName string, cli *client.Client,
 	return r
 }
 
-// HyperspaceCapabilities returns the set of capabilities of the Cosmos relayer.
+// HyperspaceCapabilities returns the set of capabilities of the hyperspace relayer.
 //
-// Note, this API may change if the rly package eventually needs
-// to distinguish between multiple rly versions.
+// Hyperspace relays unordered packets on paths configured outside of interchaintest,
+// so none of the optional features are supported.
 func HyperspaceCapabilities() map[relayer.Capability]bool {
-	// RC1 matches the full set of capabilities as of writing.
-	return nil // relayer.FullCapabilities()
+	return map[relayer.Capability]bool{
+		relayer.TimestampTimeout: false,
+		relayer.HeightTimeout:    false,
+
+		relayer.Flush: false,
+
+		relayer.OrderedChannel:  false,
+		relayer.ICAChannel:      false,
+		relayer.ChannelClose:    false,
+		relayer.MultiPathFilter: false,
+		relayer.SharedPath:      false,
+	}
 }
 
 // LinkPath performs the operations that happen when a path is linked. This includes creating clients, creating connections
@@ -0,0 +1,529 @@
+package inprocess
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	libclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module/testutil"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	connectiontypes "github.com/cosmos/ibc-go/v7/modules/core/03-connection/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v7/modules/core/23-commitment/types"
+	host "github.com/cosmos/ibc-go/v7/modules/core/24-host"
+	ibcexported "github.com/cosmos/ibc-go/v7/modules/core/exported"
+	ibctm "github.com/cosmos/ibc-go/v7/modules/light-clients/07-tendermint"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	// ibcStoreQueryPath is the ABCI query path for raw keys in the IBC store.
+	ibcStoreQueryPath = "store/ibc/key"
+
+	// txInclusionTimeout is how long to wait for a broadcast transaction to be included in a block.
+	txInclusionTimeout = time.Minute
+)
+
+// chain holds the connections and signing key the relayer uses to interact with a single Cosmos SDK chain.
+type chain struct {
+	cfg ibc.ChainConfig
+	enc testutil.TestEncodingConfig
+
+	rpc  *rpchttp.HTTP
+	grpc *grpc.ClientConn
+
+	kr      keyring.Keyring
+	keyName string
+	wallet  ibc.Wallet
+
+	// Serializes transactions, so that account sequences do not collide.
+	txMu sync.Mutex
+}
+
+func newChain(cfg ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) (*chain, error) {
+	enc := cosmos.DefaultEncoding()
+	if cfg.EncodingConfig != nil {
+		enc = *cfg.EncodingConfig
+	}
+
+	httpClient, err := libclient.DefaultHTTPClient(rpcAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http client for %s: %w", rpcAddr, err)
+	}
+	httpClient.Timeout = 10 * time.Second
+	rpc, err := rpchttp.NewWithClient(rpcAddr, "/websocket", httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rpc client for %s: %w", rpcAddr, err)
+	}
+
+	conn, err := grpc.Dial(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial grpc %s: %w", grpcAddr, err)
+	}
+
+	return &chain{
+		cfg: cfg,
+		enc: enc,
+
+		rpc:  rpc,
+		grpc: conn,
+
+		kr:      keyring.NewInMemory(enc.Codec),
+		keyName: keyName,
+	}, nil
+}
+
+func (c *chain) close() error {
+	return c.grpc.Close()
+}
+
+// chainID is a shorthand for the configured chain ID.
+func (c *chain) chainID() string {
+	return c.cfg.ChainID
+}
+
+// revision returns the IBC revision number encoded in the chain ID.
+func (c *chain) revision() uint64 {
+	return clienttypes.ParseChainID(c.cfg.ChainID)
+}
+
+// height returns the IBC height for the given block height on this chain.
+func (c *chain) height(h int64) clienttypes.Height {
+	return clienttypes.NewHeight(c.revision(), uint64(h))
+}
+
+// restoreKey imports the mnemonic into the relayer's in-memory keyring and sets it as the signing key.
+// An empty mnemonic generates a new key.
+func (c *chain) restoreKey(keyName, mnemonic, coinType string) (ibc.Wallet, error) {
+	ct, err := strconv.ParseUint(coinType, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid coin type %q: %w", coinType, err)
+	}
+
+	var record *keyring.Record
+	if mnemonic == "" {
+		record, mnemonic, err = c.kr.NewMnemonic(keyName, keyring.English, hd.CreateHDPath(uint32(ct), 0, 0).String(), "", hd.Secp256k1)
+	} else {
+		record, err = c.kr.NewAccount(keyName, mnemonic, "", hd.CreateHDPath(uint32(ct), 0, 0).String(), hd.Secp256k1)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to import key %s on chain %s: %w", keyName, c.chainID(), err)
+	}
+
+	addr, err := record.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	c.keyName = keyName
+	c.wallet = cosmos.NewWallet(keyName, addr, mnemonic, c.cfg)
+	return c.wallet, nil
+}
+
+// signer returns the bech32 address of the relayer's signing key.
+func (c *chain) signer() (string, error) {
+	if c.wallet == nil {
+		return "", fmt.Errorf("no key configured for chain %s", c.chainID())
+	}
+	return c.wallet.FormattedAddress(), nil
+}
+
+// status returns the latest block height and time.
+func (c *chain) status(ctx context.Context) (int64, time.Time, error) {
+	stat, err := c.rpc.Status(ctx)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to query status of chain %s: %w", c.chainID(), err)
+	}
+	return stat.SyncInfo.LatestBlockHeight, stat.SyncInfo.LatestBlockTime, nil
+}
+
+// waitForHeight blocks until the chain reaches at least height h, and returns the latest height.
+func (c *chain) waitForHeight(ctx context.Context, h int64) (int64, error) {
+	for {
+		latest, _, err := c.status(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if latest >= h {
+			return latest, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(250 * time.Millisecond):
+		}
+	}
+}
+
+// validatorSet returns the full validator set at height h.
+func (c *chain) validatorSet(ctx context.Context, h int64) (*tmtypes.ValidatorSet, error) {
+	var (
+		vals    []*tmtypes.Validator
+		page    = 1
+		perPage = 100
+	)
+	for {
+		res, err := c.rpc.Validators(ctx, &h, &page, &perPage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query validators of chain %s at height %d: %w", c.chainID(), h, err)
+		}
+		vals = append(vals, res.Validators...)
+		if len(vals) >= res.Total || len(res.Validators) == 0 {
+			break
+		}
+		page++
+	}
+	return tmtypes.NewValidatorSet(vals), nil
+}
+
+// signedHeader returns the signed header at height h.
+func (c *chain) signedHeader(ctx context.Context, h int64) (*tmtypes.SignedHeader, error) {
+	res, err := c.rpc.Commit(ctx, &h)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query commit of chain %s at height %d: %w", c.chainID(), h, err)
+	}
+	return &res.SignedHeader, nil
+}
+
+// clientHeader builds a Tendermint light client header for height h,
+// to be verified against the consensus state the counterparty client holds at trusted.
+func (c *chain) clientHeader(ctx context.Context, h int64, trusted clienttypes.Height) (*ibctm.Header, error) {
+	sh, err := c.signedHeader(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+	vals, err := c.validatorSet(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+	// The trusted consensus state commits to the validators of the block after the trusted height.
+	trustedVals, err := c.validatorSet(ctx, int64(trusted.RevisionHeight)+1)
+	if err != nil {
+		return nil, err
+	}
+
+	valsProto, err := vals.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	trustedValsProto, err := trustedVals.ToProto()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ibctm.Header{
+		SignedHeader:      sh.ToProto(),
+		ValidatorSet:      valsProto,
+		TrustedHeight:     trusted,
+		TrustedValidators: trustedValsProto,
+	}, nil
+}
+
+// unbondingPeriod queries the staking module's unbonding time.
+func (c *chain) unbondingPeriod(ctx context.Context) (time.Duration, error) {
+	res, err := stakingtypes.NewQueryClient(c.grpc).Params(ctx, &stakingtypes.QueryParamsRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to query staking params of chain %s: %w", c.chainID(), err)
+	}
+	return res.Params.UnbondingTime, nil
+}
+
+// queryIBCStore queries the raw value at key in the IBC store at height h, along with a merkle proof.
+// The returned proof is verifiable against the app hash of block h+1.
+// A nil value with a non-nil proof is a proof of absence.
+func (c *chain) queryIBCStore(ctx context.Context, key []byte, h int64) (value, proof []byte, err error) {
+	res, err := c.rpc.ABCIQueryWithOptions(ctx, ibcStoreQueryPath, key, rpcclient.ABCIQueryOptions{
+		Height: h,
+		Prove:  true,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query key %q on chain %s: %w", key, c.chainID(), err)
+	}
+	if !res.Response.IsOK() {
+		return nil, nil, fmt.Errorf("query key %q on chain %s failed with code %d: %s", key, c.chainID(), res.Response.Code, res.Response.Log)
+	}
+
+	merkleProof, err := commitmenttypes.ConvertProofs(res.Response.ProofOps)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert proof of key %q on chain %s: %w", key, c.chainID(), err)
+	}
+	proof, err = c.enc.Codec.Marshal(&merkleProof)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.Response.Value, proof, nil
+}
+
+// clientStateAt returns the client state stored under clientID at height h, along with its proof.
+func (c *chain) clientStateAt(ctx context.Context, clientID string, h int64) (ibcexported.ClientState, []byte, error) {
+	bz, proof, err := c.queryIBCStore(ctx, host.FullClientStateKey(clientID), h)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(bz) == 0 {
+		return nil, nil, fmt.Errorf("client %s not found on chain %s", clientID, c.chainID())
+	}
+
+	var cs ibcexported.ClientState
+	if err := c.enc.Codec.UnmarshalInterface(bz, &cs); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode client state %s on chain %s: %w", clientID, c.chainID(), err)
+	}
+	return cs, proof, nil
+}
+
+// clientLatestHeight returns the latest height of the client with the given ID.
+func (c *chain) clientLatestHeight(ctx context.Context, clientID string) (clienttypes.Height, error) {
+	res, err := clienttypes.NewQueryClient(c.grpc).ClientState(ctx, &clienttypes.QueryClientStateRequest{ClientId: clientID})
+	if err != nil {
+		return clienttypes.Height{}, fmt.Errorf("failed to query client %s on chain %s: %w", clientID, c.chainID(), err)
+	}
+	cs, err := clienttypes.UnpackClientState(res.ClientState)
+	if err != nil {
+		return clienttypes.Height{}, err
+	}
+	return cs.GetLatestHeight().(clienttypes.Height), nil
+}
+
+// connection returns the connection end with the given ID.
+func (c *chain) connection(ctx context.Context, connectionID string) (connectiontypes.ConnectionEnd, error) {
+	res, err := connectiontypes.NewQueryClient(c.grpc).Connection(ctx, &connectiontypes.QueryConnectionRequest{ConnectionId: connectionID})
+	if err != nil {
+		return connectiontypes.ConnectionEnd{}, fmt.Errorf("failed to query connection %s on chain %s: %w", connectionID, c.chainID(), err)
+	}
+	return *res.Connection, nil
+}
+
+// channelAt returns the channel end stored under the port and channel IDs at height h, along with its proof.
+func (c *chain) channelAt(ctx context.Context, portID, channelID string, h int64) (channeltypes.Channel, []byte, error) {
+	bz, proof, err := c.queryIBCStore(ctx, host.ChannelKey(portID, channelID), h)
+	if err != nil {
+		return channeltypes.Channel{}, nil, err
+	}
+	if len(bz) == 0 {
+		return channeltypes.Channel{}, nil, fmt.Errorf("channel %s/%s not found on chain %s", portID, channelID, c.chainID())
+	}
+
+	var ch channeltypes.Channel
+	if err := c.enc.Codec.Unmarshal(bz, &ch); err != nil {
+		return channeltypes.Channel{}, nil, fmt.Errorf("failed to decode channel %s/%s on chain %s: %w", portID, channelID, c.chainID(), err)
+	}
+	return ch, proof, nil
+}
+
+// channels returns every channel on the chain.
+func (c *chain) channels(ctx context.Context) ([]*channeltypes.IdentifiedChannel, error) {
+	var (
+		out []*channeltypes.IdentifiedChannel
+		key []byte
+	)
+	for {
+		res, err := channeltypes.NewQueryClient(c.grpc).Channels(ctx, &channeltypes.QueryChannelsRequest{
+			Pagination: &query.PageRequest{Key: key},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query channels on chain %s: %w", c.chainID(), err)
+		}
+		out = append(out, res.Channels...)
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return out, nil
+		}
+		key = res.Pagination.NextKey
+	}
+}
+
+// channel returns the channel with the given ID.
+// Channel IDs are unique on a chain regardless of the port, so the port does not need to be known.
+func (c *chain) channel(ctx context.Context, channelID string) (*channeltypes.IdentifiedChannel, error) {
+	chans, err := c.channels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, ch := range chans {
+		if ch.ChannelId == channelID {
+			return ch, nil
+		}
+	}
+	return nil, fmt.Errorf("channel %s not found on chain %s", channelID, c.chainID())
+}
+
+// connections returns every connection on the chain.
+func (c *chain) connections(ctx context.Context) ([]*connectiontypes.IdentifiedConnection, error) {
+	var (
+		out []*connectiontypes.IdentifiedConnection
+		key []byte
+	)
+	for {
+		res, err := connectiontypes.NewQueryClient(c.grpc).Connections(ctx, &connectiontypes.QueryConnectionsRequest{
+			Pagination: &query.PageRequest{Key: key},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query connections on chain %s: %w", c.chainID(), err)
+		}
+		out = append(out, res.Connections...)
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return out, nil
+		}
+		key = res.Pagination.NextKey
+	}
+}
+
+// clients returns every client on the chain.
+func (c *chain) clients(ctx context.Context) (clienttypes.IdentifiedClientStates, error) {
+	var (
+		out clienttypes.IdentifiedClientStates
+		key []byte
+	)
+	for {
+		res, err := clienttypes.NewQueryClient(c.grpc).ClientStates(ctx, &clienttypes.QueryClientStatesRequest{
+			Pagination: &query.PageRequest{Key: key},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query clients on chain %s: %w", c.chainID(), err)
+		}
+		out = append(out, res.ClientStates...)
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return out, nil
+		}
+		key = res.Pagination.NextKey
+	}
+}
+
+// account returns the account number and sequence of the relayer's signing key.
+func (c *chain) account(ctx context.Context) (uint64, uint64, error) {
+	addr, err := c.signer()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	res, err := authtypes.NewQueryClient(c.grpc).Account(ctx, &authtypes.QueryAccountRequest{Address: addr})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to query account %s on chain %s: %w", addr, c.chainID(), err)
+	}
+
+	var acc authtypes.AccountI
+	if err := c.enc.InterfaceRegistry.UnpackAny(res.Account, &acc); err != nil {
+		return 0, 0, err
+	}
+	return acc.GetAccountNumber(), acc.GetSequence(), nil
+}
+
+// sendMsgs signs, simulates, and broadcasts msgs in a single transaction,
+// and waits for the transaction to be included in a block.
+// It returns the events emitted by the transaction.
+func (c *chain) sendMsgs(ctx context.Context, msgs ...sdk.Msg) ([]abcitypes.Event, error) {
+	if len(msgs) == 0 {
+		return nil, nil
+	}
+
+	c.txMu.Lock()
+	defer c.txMu.Unlock()
+
+	accNum, seq, err := c.account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	gasAdjustment := c.cfg.GasAdjustment
+	if gasAdjustment == 0 {
+		gasAdjustment = 1.5
+	}
+
+	f := tx.Factory{}.
+		WithChainID(c.chainID()).
+		WithTxConfig(c.enc.TxConfig).
+		WithKeybase(c.kr).
+		WithSimulateAndExecute(true).
+		WithAccountNumber(accNum).
+		WithSequence(seq).
+		WithSignMode(signing.SignMode_SIGN_MODE_DIRECT).
+		WithGasAdjustment(gasAdjustment).
+		WithGasPrices(c.cfg.GasPrices).
+		WithMemo("interchaintest-inprocess")
+
+	_, gas, err := tx.CalculateGas(c.grpc, f, msgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate tx on chain %s: %w", c.chainID(), err)
+	}
+	f = f.WithGas(gas)
+
+	txb, err := f.BuildUnsignedTx(msgs...)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Sign(f, c.keyName, txb, true); err != nil {
+		return nil, fmt.Errorf("failed to sign tx on chain %s: %w", c.chainID(), err)
+	}
+	txBytes, err := c.enc.TxConfig.TxEncoder()(txb.GetTx())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.rpc.BroadcastTxSync(ctx, txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to broadcast tx on chain %s: %w", c.chainID(), err)
+	}
+	if res.Code != 0 {
+		return nil, fmt.Errorf("tx rejected by chain %s with code %d: %s", c.chainID(), res.Code, res.Log)
+	}
+
+	return c.waitForTx(ctx, res.Hash)
+}
+
+// waitForTx polls until the transaction with the given hash is included in a block,
+// and returns its events if it succeeded.
+func (c *chain) waitForTx(ctx context.Context, hash []byte) ([]abcitypes.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, txInclusionTimeout)
+	defer cancel()
+
+	for {
+		res, err := c.rpc.Tx(ctx, hash, false)
+		if err == nil {
+			if res.TxResult.Code != 0 {
+				return nil, fmt.Errorf("tx %X failed on chain %s with code %d: %s", hash, c.chainID(), res.TxResult.Code, res.TxResult.Log)
+			}
+			return res.TxResult.Events, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("tx %X not included on chain %s: %w (last query error: %v)", hash, c.chainID(), ctx.Err(), err)
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// eventAttribute returns the value of the first attribute with the given key
+// in the first event of the given type.
+func eventAttribute(events []abcitypes.Event, eventType, key string) (string, bool) {
+	for _, e := range events {
+		if e.Type != eventType {
+			continue
+		}
+		for _, attr := range e.Attributes {
+			if attr.Key == key {
+				return attr.Value, true
+			}
+		}
+	}
+	return "", false
+}
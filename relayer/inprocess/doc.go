@@ -0,0 +1,11 @@
+// Package inprocess contains a reference ibc.Relayer implementation written in Go,
+// which runs in the test process rather than in a Docker container.
+//
+// The relayer talks to Cosmos SDK chains directly over their host-exposed RPC and gRPC addresses,
+// so it reports false from UseDockerNetwork.
+// It creates Tendermint light clients, performs the connection and channel handshakes,
+// and relays packets, acknowledgements, and timeouts.
+//
+// Besides the ibc.Relayer interface, the relayer exposes lower-level methods such as
+// RelayPackets, RelayAcknowledgements, and RelayTimeouts, giving tests step-by-step control over each relay message.
+package inprocess
@@ -0,0 +1,48 @@
+package inprocess
+
+import (
+	"testing"
+
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"go.uber.org/zap"
+)
+
+// Capabilities returns the set of capabilities of the in-process relayer.
+// The relayer only performs full channel handshakes and relays packets,
+// so features that rely on other IBC messages are unsupported.
+func Capabilities() map[relayer.Capability]bool {
+	caps := relayer.FullCapabilities()
+	caps[relayer.ICAChannel] = false
+	caps[relayer.ChannelClose] = false
+	return caps
+}
+
+// RelayerFactory builds in-process relayers.
+// It satisfies interchaintest.RelayerFactory, so it can be used anywhere a built-in relayer factory can,
+// such as with the conformance tests.
+type RelayerFactory struct {
+	log *zap.Logger
+}
+
+// NewRelayerFactory returns a RelayerFactory whose relayers log to the given logger.
+func NewRelayerFactory(log *zap.Logger) RelayerFactory {
+	return RelayerFactory{log: log}
+}
+
+// Build returns a new in-process relayer.
+// The Docker client and network are unused, as the relayer does not run in Docker.
+func (f RelayerFactory) Build(t *testing.T, _ *client.Client, _ string) ibc.Relayer {
+	return NewRelayer(f.log.With(zap.String("test", t.Name())))
+}
+
+// Name returns the name of the in-process relayer.
+func (RelayerFactory) Name() string {
+	return "inprocess"
+}
+
+// Capabilities returns the set of capabilities of the in-process relayer.
+func (RelayerFactory) Capabilities() map[relayer.Capability]bool {
+	return Capabilities()
+}
@@ -0,0 +1,402 @@
+package inprocess
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	connectiontypes "github.com/cosmos/ibc-go/v7/modules/core/03-connection/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v7/modules/core/23-commitment/types"
+	host "github.com/cosmos/ibc-go/v7/modules/core/24-host"
+	ibcexported "github.com/cosmos/ibc-go/v7/modules/core/exported"
+	ibctm "github.com/cosmos/ibc-go/v7/modules/light-clients/07-tendermint"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+const (
+	// maxClockDrift is the clock drift tolerated by the light clients the relayer creates.
+	maxClockDrift = 10 * time.Minute
+
+	// defaultTrustingPeriodFraction is the fraction of the unbonding period used as the trusting period,
+	// when neither the client options nor the chain configuration specify one.
+	defaultTrustingPeriodFraction = 2.0 / 3.0
+)
+
+// ibcPrefix is the commitment prefix of the IBC store on Cosmos SDK chains.
+var ibcPrefix = commitmenttypes.NewMerklePrefix([]byte(ibcexported.StoreKey))
+
+// pathEnd is one side of a path: a chain, and the client and connection on it that track the counterparty.
+type pathEnd struct {
+	chainID      string
+	clientID     string
+	connectionID string
+}
+
+// path is the relayer's record of a path created through GeneratePath.
+type path struct {
+	src, dst pathEnd
+	filter   ibc.ChannelFilter
+}
+
+// proofHeight prepares dst's client of src to verify a proof of the current state of src.
+// It returns the height at which src should be queried, the height of the proof to submit to dst,
+// and, if the client needs to be updated first, the update message to include ahead of the proven message.
+func proofHeight(ctx context.Context, src, dst *chain, dstClientID string) (int64, clienttypes.Height, []sdk.Msg, error) {
+	trusted, err := dst.clientLatestHeight(ctx, dstClientID)
+	if err != nil {
+		return 0, clienttypes.Height{}, nil, err
+	}
+
+	latest, _, err := src.status(ctx)
+	if err != nil {
+		return 0, clienttypes.Height{}, nil, err
+	}
+
+	// State committed at height h is proven against the app hash in the header at h+1,
+	// so wait for the block after the latest one before proving.
+	target, err := src.waitForHeight(ctx, latest+1)
+	if err != nil {
+		return 0, clienttypes.Height{}, nil, err
+	}
+
+	if int64(trusted.RevisionHeight) >= target {
+		// The client is already ahead of the state we need to prove.
+		return int64(trusted.RevisionHeight) - 1, trusted, nil, nil
+	}
+
+	update, err := updateClientMsg(ctx, src, dst, dstClientID, target, trusted)
+	if err != nil {
+		return 0, clienttypes.Height{}, nil, err
+	}
+	return target - 1, src.height(target), []sdk.Msg{update}, nil
+}
+
+// updateClientMsg builds the message updating dst's client of src from trusted to height h.
+func updateClientMsg(ctx context.Context, src, dst *chain, dstClientID string, h int64, trusted clienttypes.Height) (sdk.Msg, error) {
+	header, err := src.clientHeader(ctx, h, trusted)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := dst.signer()
+	if err != nil {
+		return nil, err
+	}
+	msg, err := clienttypes.NewMsgUpdateClient(dstClientID, header, signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build client update for %s on chain %s: %w", dstClientID, dst.chainID(), err)
+	}
+	return msg, nil
+}
+
+// updateClient updates dst's client of src to the latest height of src.
+func updateClient(ctx context.Context, src, dst *chain, dstClientID string) error {
+	trusted, err := dst.clientLatestHeight(ctx, dstClientID)
+	if err != nil {
+		return err
+	}
+	latest, _, err := src.status(ctx)
+	if err != nil {
+		return err
+	}
+	if int64(trusted.RevisionHeight) >= latest {
+		return nil
+	}
+
+	msg, err := updateClientMsg(ctx, src, dst, dstClientID, latest, trusted)
+	if err != nil {
+		return err
+	}
+	_, err = dst.sendMsgs(ctx, msg)
+	return err
+}
+
+// createClient creates a Tendermint light client of src on dst, and returns the new client's ID.
+func createClient(ctx context.Context, src, dst *chain, opts ibc.CreateClientOptions) (string, error) {
+	unbonding, err := src.unbondingPeriod(ctx)
+	if err != nil {
+		return "", err
+	}
+	trusting, err := trustingPeriod(opts, src.cfg, unbonding)
+	if err != nil {
+		return "", err
+	}
+
+	latest, _, err := src.status(ctx)
+	if err != nil {
+		return "", err
+	}
+	sh, err := src.signedHeader(ctx, latest)
+	if err != nil {
+		return "", err
+	}
+
+	cs := ibctm.NewClientState(
+		src.chainID(), ibctm.DefaultTrustLevel,
+		trusting, unbonding, maxClockDrift,
+		src.height(latest), commitmenttypes.GetSDKSpecs(),
+		[]string{"upgrade", "upgradedIBCState"},
+	)
+	cons := ibctm.NewConsensusState(sh.Time, commitmenttypes.NewMerkleRoot(sh.AppHash), sh.NextValidatorsHash)
+
+	signer, err := dst.signer()
+	if err != nil {
+		return "", err
+	}
+	msg, err := clienttypes.NewMsgCreateClient(cs, cons, signer)
+	if err != nil {
+		return "", err
+	}
+
+	events, err := dst.sendMsgs(ctx, msg)
+	if err != nil {
+		return "", err
+	}
+	id, ok := eventAttribute(events, clienttypes.EventTypeCreateClient, clienttypes.AttributeKeyClientID)
+	if !ok {
+		return "", fmt.Errorf("client creation on chain %s did not report a client ID", dst.chainID())
+	}
+	return id, nil
+}
+
+// trustingPeriod resolves the trusting period for a new client of a chain with the given configuration.
+// A zero duration in opts falls back to the chain configuration, and then to a fraction of the unbonding period.
+func trustingPeriod(opts ibc.CreateClientOptions, cfg ibc.ChainConfig, unbonding time.Duration) (time.Duration, error) {
+	tp := opts.TrustingPeriod
+	if tp == "" || tp == "0" {
+		tp = cfg.TrustingPeriod
+	}
+	if tp != "" {
+		d, err := time.ParseDuration(tp)
+		if err != nil {
+			return 0, fmt.Errorf("invalid trusting period %q: %w", tp, err)
+		}
+		if d > 0 {
+			if d >= unbonding {
+				return 0, fmt.Errorf("trusting period %s must be less than unbonding period %s", d, unbonding)
+			}
+			return d, nil
+		}
+	}
+	return time.Duration(float64(unbonding) * defaultTrustingPeriodFraction), nil
+}
+
+// connectionProofs holds the proofs of a connection, its client state, and its client's consensus state,
+// which the connection handshake messages submitted to the counterparty require.
+type connectionProofs struct {
+	clientState     ibcexported.ClientState
+	connection      []byte
+	client          []byte
+	consensus       []byte
+	consensusHeight clienttypes.Height
+}
+
+// queryConnectionProofs queries the connection handshake proofs on src at height h.
+func queryConnectionProofs(ctx context.Context, src *chain, clientID, connectionID string, h int64) (connectionProofs, error) {
+	_, connProof, err := src.queryIBCStore(ctx, host.ConnectionKey(connectionID), h)
+	if err != nil {
+		return connectionProofs{}, err
+	}
+	cs, clientProof, err := src.clientStateAt(ctx, clientID, h)
+	if err != nil {
+		return connectionProofs{}, err
+	}
+	consHeight := cs.GetLatestHeight().(clienttypes.Height)
+	_, consProof, err := src.queryIBCStore(ctx, host.FullConsensusStateKey(clientID, consHeight), h)
+	if err != nil {
+		return connectionProofs{}, err
+	}
+
+	return connectionProofs{
+		clientState:     cs,
+		connection:      connProof,
+		client:          clientProof,
+		consensus:       consProof,
+		consensusHeight: consHeight,
+	}, nil
+}
+
+// createConnection performs the four-step connection handshake between a and b,
+// whose clients must already exist, and returns the connection IDs on a and b.
+func createConnection(ctx context.Context, a, b *chain, aClientID, bClientID string) (string, string, error) {
+	aSigner, err := a.signer()
+	if err != nil {
+		return "", "", err
+	}
+	bSigner, err := b.signer()
+	if err != nil {
+		return "", "", err
+	}
+
+	// Init on a.
+	events, err := a.sendMsgs(ctx, connectiontypes.NewMsgConnectionOpenInit(
+		aClientID, bClientID, ibcPrefix, connectiontypes.DefaultIBCVersion, 0, aSigner,
+	))
+	if err != nil {
+		return "", "", fmt.Errorf("connection open init: %w", err)
+	}
+	aConnID, ok := eventAttribute(events, connectiontypes.EventTypeConnectionOpenInit, connectiontypes.AttributeKeyConnectionID)
+	if !ok {
+		return "", "", fmt.Errorf("connection open init on chain %s did not report a connection ID", a.chainID())
+	}
+
+	// Try on b.
+	qh, ph, msgs, err := proofHeight(ctx, a, b, bClientID)
+	if err != nil {
+		return "", "", err
+	}
+	proofs, err := queryConnectionProofs(ctx, a, aClientID, aConnID, qh)
+	if err != nil {
+		return "", "", err
+	}
+	msgs = append(msgs, connectiontypes.NewMsgConnectionOpenTry(
+		bClientID, aConnID, aClientID, proofs.clientState, ibcPrefix,
+		connectiontypes.ExportedVersionsToProto(connectiontypes.GetCompatibleVersions()), 0,
+		proofs.connection, proofs.client, proofs.consensus,
+		ph, proofs.consensusHeight, bSigner,
+	))
+	events, err = b.sendMsgs(ctx, msgs...)
+	if err != nil {
+		return "", "", fmt.Errorf("connection open try: %w", err)
+	}
+	bConnID, ok := eventAttribute(events, connectiontypes.EventTypeConnectionOpenTry, connectiontypes.AttributeKeyConnectionID)
+	if !ok {
+		return "", "", fmt.Errorf("connection open try on chain %s did not report a connection ID", b.chainID())
+	}
+
+	// Ack on a.
+	qh, ph, msgs, err = proofHeight(ctx, b, a, aClientID)
+	if err != nil {
+		return "", "", err
+	}
+	proofs, err = queryConnectionProofs(ctx, b, bClientID, bConnID, qh)
+	if err != nil {
+		return "", "", err
+	}
+	bConn, err := b.connection(ctx, bConnID)
+	if err != nil {
+		return "", "", err
+	}
+	if len(bConn.Versions) == 0 {
+		return "", "", fmt.Errorf("connection %s on chain %s has no version", bConnID, b.chainID())
+	}
+	msgs = append(msgs, connectiontypes.NewMsgConnectionOpenAck(
+		aConnID, bConnID, proofs.clientState,
+		proofs.connection, proofs.client, proofs.consensus,
+		ph, proofs.consensusHeight, bConn.Versions[0], aSigner,
+	))
+	if _, err := a.sendMsgs(ctx, msgs...); err != nil {
+		return "", "", fmt.Errorf("connection open ack: %w", err)
+	}
+
+	// Confirm on b.
+	qh, ph, msgs, err = proofHeight(ctx, a, b, bClientID)
+	if err != nil {
+		return "", "", err
+	}
+	_, ackProof, err := a.queryIBCStore(ctx, host.ConnectionKey(aConnID), qh)
+	if err != nil {
+		return "", "", err
+	}
+	msgs = append(msgs, connectiontypes.NewMsgConnectionOpenConfirm(bConnID, ackProof, ph, bSigner))
+	if _, err := b.sendMsgs(ctx, msgs...); err != nil {
+		return "", "", fmt.Errorf("connection open confirm: %w", err)
+	}
+
+	return aConnID, bConnID, nil
+}
+
+// createChannel performs the four-step channel handshake between a and b over the given connections,
+// and returns the channel IDs on a and b.
+func createChannel(ctx context.Context, a, b *chain, aEnd, bEnd pathEnd, opts ibc.CreateChannelOptions) (string, string, error) {
+	aSigner, err := a.signer()
+	if err != nil {
+		return "", "", err
+	}
+	bSigner, err := b.signer()
+	if err != nil {
+		return "", "", err
+	}
+	order := channelOrder(opts.Order)
+
+	// Init on a.
+	events, err := a.sendMsgs(ctx, channeltypes.NewMsgChannelOpenInit(
+		opts.SourcePortName, opts.Version, order, []string{aEnd.connectionID}, opts.DestPortName, aSigner,
+	))
+	if err != nil {
+		return "", "", fmt.Errorf("channel open init: %w", err)
+	}
+	aChanID, ok := eventAttribute(events, channeltypes.EventTypeChannelOpenInit, channeltypes.AttributeKeyChannelID)
+	if !ok {
+		return "", "", fmt.Errorf("channel open init on chain %s did not report a channel ID", a.chainID())
+	}
+
+	// Try on b.
+	qh, ph, msgs, err := proofHeight(ctx, a, b, bEnd.clientID)
+	if err != nil {
+		return "", "", err
+	}
+	_, initProof, err := a.queryIBCStore(ctx, host.ChannelKey(opts.SourcePortName, aChanID), qh)
+	if err != nil {
+		return "", "", err
+	}
+	msgs = append(msgs, channeltypes.NewMsgChannelOpenTry(
+		opts.DestPortName, opts.Version, order, []string{bEnd.connectionID},
+		opts.SourcePortName, aChanID, opts.Version,
+		initProof, ph, bSigner,
+	))
+	events, err = b.sendMsgs(ctx, msgs...)
+	if err != nil {
+		return "", "", fmt.Errorf("channel open try: %w", err)
+	}
+	bChanID, ok := eventAttribute(events, channeltypes.EventTypeChannelOpenTry, channeltypes.AttributeKeyChannelID)
+	if !ok {
+		return "", "", fmt.Errorf("channel open try on chain %s did not report a channel ID", b.chainID())
+	}
+
+	// Ack on a.
+	qh, ph, msgs, err = proofHeight(ctx, b, a, aEnd.clientID)
+	if err != nil {
+		return "", "", err
+	}
+	bChan, tryProof, err := b.channelAt(ctx, opts.DestPortName, bChanID, qh)
+	if err != nil {
+		return "", "", err
+	}
+	msgs = append(msgs, channeltypes.NewMsgChannelOpenAck(
+		opts.SourcePortName, aChanID, bChanID, bChan.Version, tryProof, ph, aSigner,
+	))
+	if _, err := a.sendMsgs(ctx, msgs...); err != nil {
+		return "", "", fmt.Errorf("channel open ack: %w", err)
+	}
+
+	// Confirm on b.
+	qh, ph, msgs, err = proofHeight(ctx, a, b, bEnd.clientID)
+	if err != nil {
+		return "", "", err
+	}
+	_, ackProof, err := a.queryIBCStore(ctx, host.ChannelKey(opts.SourcePortName, aChanID), qh)
+	if err != nil {
+		return "", "", err
+	}
+	msgs = append(msgs, channeltypes.NewMsgChannelOpenConfirm(opts.DestPortName, bChanID, ackProof, ph, bSigner))
+	if _, err := b.sendMsgs(ctx, msgs...); err != nil {
+		return "", "", fmt.Errorf("channel open confirm: %w", err)
+	}
+
+	return aChanID, bChanID, nil
+}
+
+// channelOrder converts the interchaintest channel order to the IBC channel order.
+func channelOrder(o ibc.Order) channeltypes.Order {
+	switch o {
+	case ibc.Ordered:
+		return channeltypes.ORDERED
+	case ibc.Unordered:
+		return channeltypes.UNORDERED
+	default:
+		return channeltypes.NONE
+	}
+}
@@ -0,0 +1,353 @@
+package inprocess
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	host "github.com/cosmos/ibc-go/v7/modules/core/24-host"
+)
+
+// leg is one direction of a channel: packets sent from src to dst, and acknowledgements back from dst to src.
+type leg struct {
+	src, dst *chain
+
+	// Client on src tracking dst, and client on dst tracking src.
+	srcClientID, dstClientID string
+
+	srcPort, srcChannel string
+	dstPort, dstChannel string
+
+	order channeltypes.Order
+}
+
+// reverse returns the leg in the opposite direction over the same channel.
+func (l leg) reverse() leg {
+	return leg{
+		src: l.dst, dst: l.src,
+		srcClientID: l.dstClientID, dstClientID: l.srcClientID,
+		srcPort: l.dstPort, srcChannel: l.dstChannel,
+		dstPort: l.srcPort, dstChannel: l.srcChannel,
+		order: l.order,
+	}
+}
+
+// pendingPackets returns the packets sent on the leg that dst has not received yet,
+// split into those that can still be delivered and those that have timed out on dst.
+func pendingPackets(ctx context.Context, l leg) (deliverable, timedOut []channeltypes.Packet, err error) {
+	var (
+		seqs []uint64
+		key  []byte
+	)
+	for {
+		res, err := channeltypes.NewQueryClient(l.src.grpc).PacketCommitments(ctx, &channeltypes.QueryPacketCommitmentsRequest{
+			PortId:     l.srcPort,
+			ChannelId:  l.srcChannel,
+			Pagination: &query.PageRequest{Key: key},
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to query packet commitments on chain %s: %w", l.src.chainID(), err)
+		}
+		for _, c := range res.Commitments {
+			seqs = append(seqs, c.Sequence)
+		}
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			break
+		}
+		key = res.Pagination.NextKey
+	}
+	if len(seqs) == 0 {
+		return nil, nil, nil
+	}
+	// Commitments are returned in store key order, which is not numeric order,
+	// and ordered channels must receive packets in sequence.
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+
+	unreceived, err := channeltypes.NewQueryClient(l.dst.grpc).UnreceivedPackets(ctx, &channeltypes.QueryUnreceivedPacketsRequest{
+		PortId:                    l.dstPort,
+		ChannelId:                 l.dstChannel,
+		PacketCommitmentSequences: seqs,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query unreceived packets on chain %s: %w", l.dst.chainID(), err)
+	}
+	if len(unreceived.Sequences) == 0 {
+		return nil, nil, nil
+	}
+
+	dstHeight, dstTime, err := l.dst.status(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, seq := range unreceived.Sequences {
+		attrs, err := l.src.packetEvent(ctx, channeltypes.EventTypeSendPacket,
+			channeltypes.AttributeKeySrcPort, channeltypes.AttributeKeySrcChannel,
+			l.srcPort, l.srcChannel, seq,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		p, err := packetFromAttributes(attrs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse packet %d sent on chain %s: %w", seq, l.src.chainID(), err)
+		}
+
+		if packetTimedOut(p, l.dst.height(dstHeight), dstTime) {
+			timedOut = append(timedOut, p)
+		} else {
+			deliverable = append(deliverable, p)
+		}
+	}
+
+	return deliverable, timedOut, nil
+}
+
+// relayRecvs delivers the given packets to dst, and returns the number of packets delivered.
+func relayRecvs(ctx context.Context, l leg, packets []channeltypes.Packet) (int, error) {
+	if len(packets) == 0 {
+		return 0, nil
+	}
+
+	signer, err := l.dst.signer()
+	if err != nil {
+		return 0, err
+	}
+	qh, ph, msgs, err := proofHeight(ctx, l.src, l.dst, l.dstClientID)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, p := range packets {
+		_, proof, err := l.src.queryIBCStore(ctx, host.PacketCommitmentKey(p.SourcePort, p.SourceChannel, p.Sequence), qh)
+		if err != nil {
+			return 0, err
+		}
+		msgs = append(msgs, channeltypes.NewMsgRecvPacket(p, proof, ph, signer))
+	}
+
+	if _, err := l.dst.sendMsgs(ctx, msgs...); err != nil {
+		return 0, fmt.Errorf("failed to deliver packets: %w", err)
+	}
+	return len(packets), nil
+}
+
+// relayTimeouts times out the given packets on src, and returns the number of packets timed out.
+func relayTimeouts(ctx context.Context, l leg, packets []channeltypes.Packet) (int, error) {
+	if len(packets) == 0 {
+		return 0, nil
+	}
+
+	signer, err := l.src.signer()
+	if err != nil {
+		return 0, err
+	}
+	qh, ph, msgs, err := proofHeight(ctx, l.dst, l.src, l.srcClientID)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, p := range packets {
+		var (
+			nextSeq = p.Sequence
+			proof   []byte
+		)
+		if l.order == channeltypes.ORDERED {
+			var bz []byte
+			bz, proof, err = l.dst.queryIBCStore(ctx, host.NextSequenceRecvKey(p.DestinationPort, p.DestinationChannel), qh)
+			if err != nil {
+				return 0, err
+			}
+			nextSeq = sdk.BigEndianToUint64(bz)
+		} else {
+			// Proof of absence of the packet receipt.
+			_, proof, err = l.dst.queryIBCStore(ctx, host.PacketReceiptKey(p.DestinationPort, p.DestinationChannel, p.Sequence), qh)
+			if err != nil {
+				return 0, err
+			}
+		}
+		msgs = append(msgs, channeltypes.NewMsgTimeout(p, nextSeq, proof, ph, signer))
+
+		if l.order == channeltypes.ORDERED {
+			// Timing out a packet closes an ordered channel, so later packets cannot be timed out in the same transaction.
+			break
+		}
+	}
+
+	if _, err := l.src.sendMsgs(ctx, msgs...); err != nil {
+		return 0, fmt.Errorf("failed to time out packets: %w", err)
+	}
+	if l.order == channeltypes.ORDERED {
+		return 1, nil
+	}
+	return len(packets), nil
+}
+
+// relayAcks relays the acknowledgements written on dst back to src,
+// and returns the number of acknowledgements relayed.
+func relayAcks(ctx context.Context, l leg) (int, error) {
+	var (
+		seqs []uint64
+		key  []byte
+	)
+	for {
+		res, err := channeltypes.NewQueryClient(l.dst.grpc).PacketAcknowledgements(ctx, &channeltypes.QueryPacketAcknowledgementsRequest{
+			PortId:     l.dstPort,
+			ChannelId:  l.dstChannel,
+			Pagination: &query.PageRequest{Key: key},
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to query packet acknowledgements on chain %s: %w", l.dst.chainID(), err)
+		}
+		for _, a := range res.Acknowledgements {
+			seqs = append(seqs, a.Sequence)
+		}
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			break
+		}
+		key = res.Pagination.NextKey
+	}
+	if len(seqs) == 0 {
+		return 0, nil
+	}
+	// Ordered channels must acknowledge packets in sequence.
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+
+	unreceived, err := channeltypes.NewQueryClient(l.src.grpc).UnreceivedAcks(ctx, &channeltypes.QueryUnreceivedAcksRequest{
+		PortId:             l.srcPort,
+		ChannelId:          l.srcChannel,
+		PacketAckSequences: seqs,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to query unreceived acknowledgements on chain %s: %w", l.src.chainID(), err)
+	}
+	if len(unreceived.Sequences) == 0 {
+		return 0, nil
+	}
+
+	signer, err := l.src.signer()
+	if err != nil {
+		return 0, err
+	}
+	qh, ph, msgs, err := proofHeight(ctx, l.dst, l.src, l.srcClientID)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, seq := range unreceived.Sequences {
+		attrs, err := l.dst.packetEvent(ctx, channeltypes.EventTypeWriteAck,
+			channeltypes.AttributeKeyDstPort, channeltypes.AttributeKeyDstChannel,
+			l.dstPort, l.dstChannel, seq,
+		)
+		if err != nil {
+			return 0, err
+		}
+		p, err := packetFromAttributes(attrs)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse packet %d received on chain %s: %w", seq, l.dst.chainID(), err)
+		}
+		ack, err := hex.DecodeString(attrs[channeltypes.AttributeKeyAckHex])
+		if err != nil {
+			return 0, fmt.Errorf("failed to decode acknowledgement of packet %d on chain %s: %w", seq, l.dst.chainID(), err)
+		}
+
+		_, proof, err := l.dst.queryIBCStore(ctx, host.PacketAcknowledgementKey(l.dstPort, l.dstChannel, seq), qh)
+		if err != nil {
+			return 0, err
+		}
+		msgs = append(msgs, channeltypes.NewMsgAcknowledgement(p, ack, proof, ph, signer))
+	}
+
+	if _, err := l.src.sendMsgs(ctx, msgs...); err != nil {
+		return 0, fmt.Errorf("failed to relay acknowledgements: %w", err)
+	}
+	return len(unreceived.Sequences), nil
+}
+
+// packetEvent searches the chain's transactions for the event of the given type
+// that refers to the packet with the given port, channel, and sequence,
+// and returns the event's attributes.
+func (c *chain) packetEvent(ctx context.Context, eventType, portKey, channelKey, portID, channelID string, seq uint64) (map[string]string, error) {
+	q := fmt.Sprintf("%s.%s='%s' AND %s.%s='%s' AND %s.%s='%d'",
+		eventType, portKey, portID,
+		eventType, channelKey, channelID,
+		eventType, channeltypes.AttributeKeySequence, seq,
+	)
+
+	page, perPage := 1, 100
+	res, err := c.rpc.TxSearch(ctx, q, false, &page, &perPage, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %s events on chain %s: %w", eventType, c.chainID(), err)
+	}
+
+	for _, tx := range res.Txs {
+		if attrs, ok := findPacketEvent(tx.TxResult.Events, eventType, portKey, channelKey, portID, channelID, seq); ok {
+			return attrs, nil
+		}
+	}
+	return nil, fmt.Errorf("no %s event found on chain %s for packet %s/%s/%d", eventType, c.chainID(), portID, channelID, seq)
+}
+
+// findPacketEvent returns the attributes of the event of the given type
+// that refers to the packet with the given port, channel, and sequence.
+// A single transaction may contain events for several packets, so every event of the type is checked.
+func findPacketEvent(events []abcitypes.Event, eventType, portKey, channelKey, portID, channelID string, seq uint64) (map[string]string, bool) {
+	want := strconv.FormatUint(seq, 10)
+	for _, e := range events {
+		if e.Type != eventType {
+			continue
+		}
+		attrs := make(map[string]string, len(e.Attributes))
+		for _, attr := range e.Attributes {
+			attrs[attr.Key] = attr.Value
+		}
+		if attrs[portKey] == portID && attrs[channelKey] == channelID && attrs[channeltypes.AttributeKeySequence] == want {
+			return attrs, true
+		}
+	}
+	return nil, false
+}
+
+// packetFromAttributes rebuilds a packet from the attributes of a send_packet or write_acknowledgement event.
+func packetFromAttributes(attrs map[string]string) (channeltypes.Packet, error) {
+	seq, err := strconv.ParseUint(attrs[channeltypes.AttributeKeySequence], 10, 64)
+	if err != nil {
+		return channeltypes.Packet{}, fmt.Errorf("invalid sequence: %w", err)
+	}
+	data, err := hex.DecodeString(attrs[channeltypes.AttributeKeyDataHex])
+	if err != nil {
+		return channeltypes.Packet{}, fmt.Errorf("invalid data: %w", err)
+	}
+	timeoutHeight, err := clienttypes.ParseHeight(attrs[channeltypes.AttributeKeyTimeoutHeight])
+	if err != nil {
+		return channeltypes.Packet{}, fmt.Errorf("invalid timeout height: %w", err)
+	}
+	timeoutTimestamp, err := strconv.ParseUint(attrs[channeltypes.AttributeKeyTimeoutTimestamp], 10, 64)
+	if err != nil {
+		return channeltypes.Packet{}, fmt.Errorf("invalid timeout timestamp: %w", err)
+	}
+
+	return channeltypes.NewPacket(
+		data, seq,
+		attrs[channeltypes.AttributeKeySrcPort], attrs[channeltypes.AttributeKeySrcChannel],
+		attrs[channeltypes.AttributeKeyDstPort], attrs[channeltypes.AttributeKeyDstChannel],
+		timeoutHeight, timeoutTimestamp,
+	), nil
+}
+
+// packetTimedOut reports whether the packet can no longer be received
+// by a destination chain at the given height and block time.
+func packetTimedOut(p channeltypes.Packet, dstHeight clienttypes.Height, dstTime time.Time) bool {
+	if !p.TimeoutHeight.IsZero() && dstHeight.GTE(p.TimeoutHeight) {
+		return true
+	}
+	return p.TimeoutTimestamp != 0 && uint64(dstTime.UnixNano()) >= p.TimeoutTimestamp
+}
@@ -0,0 +1,85 @@
+package inprocess
+
+import (
+	"testing"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"github.com/stretchr/testify/require"
+)
+
+func sendPacketEvent(seq string) abcitypes.Event {
+	attrs := map[string]string{
+		channeltypes.AttributeKeyDataHex:          "7b7d", // "{}"
+		channeltypes.AttributeKeyTimeoutHeight:    "1-100",
+		channeltypes.AttributeKeyTimeoutTimestamp: "1700000000000000000",
+		channeltypes.AttributeKeySequence:         seq,
+		channeltypes.AttributeKeySrcPort:          "transfer",
+		channeltypes.AttributeKeySrcChannel:       "channel-0",
+		channeltypes.AttributeKeyDstPort:          "transfer",
+		channeltypes.AttributeKeyDstChannel:       "channel-1",
+	}
+	e := abcitypes.Event{Type: channeltypes.EventTypeSendPacket}
+	for k, v := range attrs {
+		e.Attributes = append(e.Attributes, abcitypes.EventAttribute{Key: k, Value: v})
+	}
+	return e
+}
+
+func TestFindPacketEvent(t *testing.T) {
+	events := []abcitypes.Event{
+		{Type: "message"},
+		sendPacketEvent("1"),
+		sendPacketEvent("2"),
+	}
+
+	attrs, ok := findPacketEvent(events, channeltypes.EventTypeSendPacket,
+		channeltypes.AttributeKeySrcPort, channeltypes.AttributeKeySrcChannel,
+		"transfer", "channel-0", 2,
+	)
+	require.True(t, ok)
+	require.Equal(t, "2", attrs[channeltypes.AttributeKeySequence])
+
+	_, ok = findPacketEvent(events, channeltypes.EventTypeSendPacket,
+		channeltypes.AttributeKeySrcPort, channeltypes.AttributeKeySrcChannel,
+		"transfer", "channel-1", 2,
+	)
+	require.False(t, ok)
+}
+
+func TestPacketFromAttributes(t *testing.T) {
+	attrs, ok := findPacketEvent([]abcitypes.Event{sendPacketEvent("7")}, channeltypes.EventTypeSendPacket,
+		channeltypes.AttributeKeySrcPort, channeltypes.AttributeKeySrcChannel,
+		"transfer", "channel-0", 7,
+	)
+	require.True(t, ok)
+
+	p, err := packetFromAttributes(attrs)
+	require.NoError(t, err)
+	require.Equal(t, channeltypes.NewPacket(
+		[]byte("{}"), 7,
+		"transfer", "channel-0",
+		"transfer", "channel-1",
+		clienttypes.NewHeight(1, 100), 1700000000000000000,
+	), p)
+
+	attrs[channeltypes.AttributeKeyTimeoutHeight] = "bad"
+	_, err = packetFromAttributes(attrs)
+	require.Error(t, err)
+}
+
+func TestPacketTimedOut(t *testing.T) {
+	deadline := time.Unix(1_700_000_000, 0)
+
+	byHeight := channeltypes.Packet{TimeoutHeight: clienttypes.NewHeight(1, 100)}
+	require.False(t, packetTimedOut(byHeight, clienttypes.NewHeight(1, 99), deadline))
+	require.True(t, packetTimedOut(byHeight, clienttypes.NewHeight(1, 100), deadline))
+
+	byTime := channeltypes.Packet{TimeoutTimestamp: uint64(deadline.UnixNano())}
+	require.False(t, packetTimedOut(byTime, clienttypes.NewHeight(1, 1_000), deadline.Add(-time.Second)))
+	require.True(t, packetTimedOut(byTime, clienttypes.NewHeight(1, 1_000), deadline))
+
+	require.False(t, packetTimedOut(channeltypes.Packet{}, clienttypes.NewHeight(1, 1_000), deadline))
+}
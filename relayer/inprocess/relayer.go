@@ -0,0 +1,776 @@
+package inprocess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"go.uber.org/zap"
+)
+
+const (
+	// relayInterval is how often the background worker started by StartRelayer flushes its paths.
+	relayInterval = time.Second
+
+	// Channel filter rules, matching the rules accepted by the Go relayer.
+	filterAllowlist = "allowlist"
+	filterDenylist  = "denylist"
+)
+
+// Relayer is an ibc.Relayer that relays between Cosmos SDK chains from within the test process.
+type Relayer struct {
+	log *zap.Logger
+
+	mu sync.Mutex
+
+	// Key: chain ID.
+	chains map[string]*chain
+
+	// Key: path name.
+	paths map[string]*path
+
+	// Set while the background worker started by StartRelayer is running.
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	// Serializes relaying, so that the background worker and explicit calls
+	// do not submit the same messages concurrently.
+	relayMu sync.Mutex
+}
+
+var (
+	_ ibc.Relayer               = (*Relayer)(nil)
+	_ relayer.SharedPathRelayer = (*Relayer)(nil)
+)
+
+// NewRelayer returns a new in-process relayer.
+// Chains are added to the relayer through AddChainConfiguration,
+// which happens automatically when the relayer is added to an Interchain.
+func NewRelayer(log *zap.Logger) *Relayer {
+	return &Relayer{
+		log: log,
+
+		chains: make(map[string]*chain),
+		paths:  make(map[string]*path),
+	}
+}
+
+// track reports a relayer step to rep as if it had been a relayer command.
+func track(rep ibc.RelayerExecReporter, cmd []string, startedAt time.Time, stdout string, err error) {
+	exitCode := 0
+	if err != nil {
+		exitCode = 1
+	}
+	rep.TrackRelayerExec("", append([]string{"inprocess"}, cmd...), stdout, "", exitCode, startedAt, time.Now(), err)
+}
+
+func (r *Relayer) chain(chainID string) (*chain, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.chains[chainID]
+	if !ok {
+		return nil, fmt.Errorf("chain %s is not configured", chainID)
+	}
+	return c, nil
+}
+
+// path returns a copy of the named path along with its chains.
+func (r *Relayer) path(pathName string) (path, *chain, *chain, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.paths[pathName]
+	if !ok {
+		return path{}, nil, nil, fmt.Errorf("path %s does not exist", pathName)
+	}
+	src, ok := r.chains[p.src.chainID]
+	if !ok {
+		return path{}, nil, nil, fmt.Errorf("chain %s is not configured", p.src.chainID)
+	}
+	dst, ok := r.chains[p.dst.chainID]
+	if !ok {
+		return path{}, nil, nil, fmt.Errorf("chain %s is not configured", p.dst.chainID)
+	}
+	return *p, src, dst, nil
+}
+
+// AddChainConfiguration connects the relayer to the chain at the given host RPC and gRPC addresses.
+func (r *Relayer) AddChainConfiguration(ctx context.Context, rep ibc.RelayerExecReporter, chainConfig ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) error {
+	start := time.Now()
+	c, err := newChain(chainConfig, keyName, rpcAddr, grpcAddr)
+	track(rep, []string{"chains", "add", chainConfig.ChainID, rpcAddr, grpcAddr}, start, "", err)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if old, ok := r.chains[chainConfig.ChainID]; ok {
+		_ = old.close()
+	}
+	r.chains[chainConfig.ChainID] = c
+	return nil
+}
+
+// RestoreKey sets the signing key for the chain from the mnemonic.
+func (r *Relayer) RestoreKey(ctx context.Context, rep ibc.RelayerExecReporter, cfg ibc.ChainConfig, keyName, mnemonic string) error {
+	start := time.Now()
+	err := r.restoreKey(cfg, keyName, mnemonic)
+	track(rep, []string{"keys", "restore", cfg.ChainID, keyName}, start, "", err)
+	return err
+}
+
+func (r *Relayer) restoreKey(cfg ibc.ChainConfig, keyName, mnemonic string) error {
+	c, err := r.chain(cfg.ChainID)
+	if err != nil {
+		return err
+	}
+	coinType, err := cfg.VerifyCoinType()
+	if err != nil {
+		return err
+	}
+	_, err = c.restoreKey(keyName, mnemonic, coinType)
+	return err
+}
+
+// AddKey generates a new signing key for the chain.
+func (r *Relayer) AddKey(ctx context.Context, rep ibc.RelayerExecReporter, chainID, keyName, coinType string) (ibc.Wallet, error) {
+	start := time.Now()
+	w, err := r.addKey(chainID, keyName, coinType)
+	out := ""
+	if w != nil {
+		out = w.FormattedAddress()
+	}
+	track(rep, []string{"keys", "add", chainID, keyName}, start, out, err)
+	return w, err
+}
+
+func (r *Relayer) addKey(chainID, keyName, coinType string) (ibc.Wallet, error) {
+	c, err := r.chain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	if coinType == "" {
+		coinType, err = c.cfg.VerifyCoinType()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.restoreKey(keyName, "", coinType)
+}
+
+// GetWallet returns the signing key for the chain.
+func (r *Relayer) GetWallet(chainID string) (ibc.Wallet, bool) {
+	c, err := r.chain(chainID)
+	if err != nil || c.wallet == nil {
+		return nil, false
+	}
+	return c.wallet, true
+}
+
+// GeneratePath records a new path between the two chains.
+func (r *Relayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string) error {
+	start := time.Now()
+	err := r.generatePath(srcChainID, dstChainID, pathName)
+	track(rep, []string{"paths", "new", srcChainID, dstChainID, pathName}, start, "", err)
+	return err
+}
+
+func (r *Relayer) generatePath(srcChainID, dstChainID, pathName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.paths[pathName]; ok {
+		return fmt.Errorf("path %s already exists", pathName)
+	}
+	if srcChainID == dstChainID {
+		return fmt.Errorf("path %s must connect two different chains", pathName)
+	}
+	r.paths[pathName] = &path{
+		src: pathEnd{chainID: srcChainID},
+		dst: pathEnd{chainID: dstChainID},
+	}
+	return nil
+}
+
+// UpdatePath sets the channel filter of the path.
+// The filter rule is either "allowlist" or "denylist", and the channel IDs refer to the path's source chain.
+// An empty rule relays on every channel.
+func (r *Relayer) UpdatePath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, filter ibc.ChannelFilter) error {
+	start := time.Now()
+	err := r.updatePath(pathName, filter)
+	track(rep, []string{"paths", "update", pathName, filter.Rule, strings.Join(filter.ChannelList, ",")}, start, "", err)
+	return err
+}
+
+func (r *Relayer) updatePath(pathName string, filter ibc.ChannelFilter) error {
+	switch filter.Rule {
+	case "", filterAllowlist, filterDenylist:
+	default:
+		return fmt.Errorf("invalid channel filter rule %q", filter.Rule)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.paths[pathName]
+	if !ok {
+		return fmt.Errorf("path %s does not exist", pathName)
+	}
+	p.filter = filter
+	return nil
+}
+
+// channelAllowed reports whether the filter permits relaying on the channel.
+func channelAllowed(filter ibc.ChannelFilter, channelID string) bool {
+	listed := false
+	for _, id := range filter.ChannelList {
+		if id == channelID {
+			listed = true
+			break
+		}
+	}
+
+	switch filter.Rule {
+	case filterAllowlist:
+		return listed
+	case filterDenylist:
+		return !listed
+	default:
+		return true
+	}
+}
+
+// UsePath sets the clients and connection of the path, so that it relays over ones linked by another relayer.
+func (r *Relayer) UsePath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, src, dst relayer.PathEnd) error {
+	start := time.Now()
+	err := r.usePath(pathName, src, dst)
+	track(rep, []string{"paths", "use", pathName, src.ClientID, src.ConnectionID, dst.ClientID, dst.ConnectionID}, start, "", err)
+	return err
+}
+
+func (r *Relayer) usePath(pathName string, src, dst relayer.PathEnd) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.paths[pathName]
+	if !ok {
+		return fmt.Errorf("path %s does not exist", pathName)
+	}
+	p.src.clientID, p.src.connectionID = src.ClientID, src.ConnectionID
+	p.dst.clientID, p.dst.connectionID = dst.ClientID, dst.ConnectionID
+	return nil
+}
+
+// LinkPath creates the clients, connection, and channel of the path.
+func (r *Relayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, channelOpts ibc.CreateChannelOptions, clientOpts ibc.CreateClientOptions) error {
+	if err := r.CreateClients(ctx, rep, pathName, clientOpts); err != nil {
+		return err
+	}
+	if err := r.CreateConnections(ctx, rep, pathName); err != nil {
+		return err
+	}
+	return r.CreateChannel(ctx, rep, pathName, channelOpts)
+}
+
+// CreateClients creates a light client of each chain of the path on the other chain.
+func (r *Relayer) CreateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateClientOptions) error {
+	start := time.Now()
+	out, err := r.createClients(ctx, pathName, opts)
+	track(rep, []string{"tx", "clients", pathName}, start, out, err)
+	return err
+}
+
+func (r *Relayer) createClients(ctx context.Context, pathName string, opts ibc.CreateClientOptions) (string, error) {
+	if err := opts.Validate(); err != nil {
+		return "", err
+	}
+	_, src, dst, err := r.path(pathName)
+	if err != nil {
+		return "", err
+	}
+
+	srcClientID, err := createClient(ctx, dst, src, opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to create client of %s on %s: %w", dst.chainID(), src.chainID(), err)
+	}
+	dstClientID, err := createClient(ctx, src, dst, opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to create client of %s on %s: %w", src.chainID(), dst.chainID(), err)
+	}
+
+	r.mu.Lock()
+	r.paths[pathName].src.clientID = srcClientID
+	r.paths[pathName].dst.clientID = dstClientID
+	r.mu.Unlock()
+
+	return fmt.Sprintf("%s: %s\n%s: %s\n", src.chainID(), srcClientID, dst.chainID(), dstClientID), nil
+}
+
+// CreateConnections performs the connection handshake over the clients of the path.
+func (r *Relayer) CreateConnections(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
+	start := time.Now()
+	out, err := r.createConnections(ctx, pathName)
+	track(rep, []string{"tx", "connection", pathName}, start, out, err)
+	return err
+}
+
+func (r *Relayer) createConnections(ctx context.Context, pathName string) (string, error) {
+	p, src, dst, err := r.path(pathName)
+	if err != nil {
+		return "", err
+	}
+	if p.src.clientID == "" || p.dst.clientID == "" {
+		return "", fmt.Errorf("path %s has no clients", pathName)
+	}
+
+	srcConnID, dstConnID, err := createConnection(ctx, src, dst, p.src.clientID, p.dst.clientID)
+	if err != nil {
+		return "", fmt.Errorf("failed to create connection on path %s: %w", pathName, err)
+	}
+
+	r.mu.Lock()
+	r.paths[pathName].src.connectionID = srcConnID
+	r.paths[pathName].dst.connectionID = dstConnID
+	r.mu.Unlock()
+
+	return fmt.Sprintf("%s: %s\n%s: %s\n", src.chainID(), srcConnID, dst.chainID(), dstConnID), nil
+}
+
+// CreateChannel performs the channel handshake over the connection of the path.
+func (r *Relayer) CreateChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) error {
+	start := time.Now()
+	out, err := r.createChannel(ctx, pathName, opts)
+	track(rep, []string{"tx", "channel", pathName, opts.SourcePortName, opts.DestPortName, opts.Order.String(), opts.Version}, start, out, err)
+	return err
+}
+
+func (r *Relayer) createChannel(ctx context.Context, pathName string, opts ibc.CreateChannelOptions) (string, error) {
+	if err := opts.Validate(); err != nil {
+		return "", err
+	}
+	p, src, dst, err := r.path(pathName)
+	if err != nil {
+		return "", err
+	}
+	if p.src.connectionID == "" || p.dst.connectionID == "" {
+		return "", fmt.Errorf("path %s has no connection", pathName)
+	}
+
+	srcChanID, dstChanID, err := createChannel(ctx, src, dst, p.src, p.dst, opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to create channel on path %s: %w", pathName, err)
+	}
+	return fmt.Sprintf("%s: %s\n%s: %s\n", src.chainID(), srcChanID, dst.chainID(), dstChanID), nil
+}
+
+// UpdateClients updates both clients of the path to the latest height of the chain they track.
+func (r *Relayer) UpdateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
+	start := time.Now()
+	err := r.updateClients(ctx, pathName)
+	track(rep, []string{"tx", "update-clients", pathName}, start, "", err)
+	return err
+}
+
+func (r *Relayer) updateClients(ctx context.Context, pathName string) error {
+	p, src, dst, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	if p.src.clientID == "" || p.dst.clientID == "" {
+		return fmt.Errorf("path %s has no clients", pathName)
+	}
+
+	if err := updateClient(ctx, dst, src, p.src.clientID); err != nil {
+		return fmt.Errorf("failed to update client %s on %s: %w", p.src.clientID, src.chainID(), err)
+	}
+	if err := updateClient(ctx, src, dst, p.dst.clientID); err != nil {
+		return fmt.Errorf("failed to update client %s on %s: %w", p.dst.clientID, dst.chainID(), err)
+	}
+	return nil
+}
+
+// GetChannels returns every channel on the chain.
+func (r *Relayer) GetChannels(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) ([]ibc.ChannelOutput, error) {
+	start := time.Now()
+	out, err := r.getChannels(ctx, chainID)
+	track(rep, []string{"q", "channels", chainID}, start, "", err)
+	return out, err
+}
+
+func (r *Relayer) getChannels(ctx context.Context, chainID string) ([]ibc.ChannelOutput, error) {
+	c, err := r.chain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	chans, err := c.channels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]ibc.ChannelOutput, len(chans))
+	for i, ch := range chans {
+		out[i] = ibc.ChannelOutput{
+			State:    ch.State.String(),
+			Ordering: ch.Ordering.String(),
+			Counterparty: ibc.ChannelCounterparty{
+				PortID:    ch.Counterparty.PortId,
+				ChannelID: ch.Counterparty.ChannelId,
+			},
+			ConnectionHops: ch.ConnectionHops,
+			Version:        ch.Version,
+			PortID:         ch.PortId,
+			ChannelID:      ch.ChannelId,
+		}
+	}
+	return out, nil
+}
+
+// GetConnections returns every connection on the chain.
+func (r *Relayer) GetConnections(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (ibc.ConnectionOutputs, error) {
+	start := time.Now()
+	out, err := r.getConnections(ctx, chainID)
+	track(rep, []string{"q", "connections", chainID}, start, "", err)
+	return out, err
+}
+
+func (r *Relayer) getConnections(ctx context.Context, chainID string) (ibc.ConnectionOutputs, error) {
+	c, err := r.chain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	conns, err := c.connections(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(ibc.ConnectionOutputs, len(conns))
+	for i, conn := range conns {
+		counterparty := conn.Counterparty
+		out[i] = &ibc.ConnectionOutput{
+			ID:           conn.Id,
+			ClientID:     conn.ClientId,
+			Versions:     conn.Versions,
+			State:        conn.State.String(),
+			Counterparty: &counterparty,
+			DelayPeriod:  strconv.FormatUint(conn.DelayPeriod, 10),
+		}
+	}
+	return out, nil
+}
+
+// GetClients returns every client on the chain.
+func (r *Relayer) GetClients(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (ibc.ClientOutputs, error) {
+	start := time.Now()
+	out, err := r.getClients(ctx, chainID)
+	track(rep, []string{"q", "clients", chainID}, start, "", err)
+	return out, err
+}
+
+func (r *Relayer) getClients(ctx context.Context, chainID string) (ibc.ClientOutputs, error) {
+	c, err := r.chain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	clients, err := c.clients(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(ibc.ClientOutputs, len(clients))
+	for i, ic := range clients {
+		o := &ibc.ClientOutput{ClientID: ic.ClientId}
+		if cs, err := clienttypes.UnpackClientState(ic.ClientState); err == nil {
+			// Only some client types, such as Tendermint clients, track a chain ID.
+			if withChainID, ok := cs.(interface{ GetChainID() string }); ok {
+				o.ClientState.ChainID = withChainID.GetChainID()
+			}
+		}
+		out[i] = o
+	}
+	return out, nil
+}
+
+// leg resolves the direction of the path that carries packets sent from srcChainID on srcChannelID.
+func (r *Relayer) leg(ctx context.Context, pathName, srcChainID, srcChannelID string) (leg, error) {
+	p, a, b, err := r.path(pathName)
+	if err != nil {
+		return leg{}, err
+	}
+
+	srcEnd, dstEnd := p.src, p.dst
+	switch srcChainID {
+	case a.chainID():
+	case b.chainID():
+		a, b = b, a
+		srcEnd, dstEnd = dstEnd, srcEnd
+	default:
+		return leg{}, fmt.Errorf("chain %s is not on path %s", srcChainID, pathName)
+	}
+
+	ch, err := a.channel(ctx, srcChannelID)
+	if err != nil {
+		return leg{}, err
+	}
+	if len(ch.ConnectionHops) != 1 || ch.ConnectionHops[0] != srcEnd.connectionID {
+		return leg{}, fmt.Errorf("channel %s on chain %s is not on the connection of path %s", srcChannelID, srcChainID, pathName)
+	}
+
+	return leg{
+		src: a, dst: b,
+		srcClientID: srcEnd.clientID, dstClientID: dstEnd.clientID,
+		srcPort: ch.PortId, srcChannel: ch.ChannelId,
+		dstPort: ch.Counterparty.PortId, dstChannel: ch.Counterparty.ChannelId,
+		order: ch.Ordering,
+	}, nil
+}
+
+// RelayPackets delivers to the counterparty chain every packet sent from srcChainID on srcChannelID
+// that has not been received and has not timed out.
+// It returns the number of packets delivered.
+func (r *Relayer) RelayPackets(ctx context.Context, rep ibc.RelayerExecReporter, pathName, srcChainID, srcChannelID string) (int, error) {
+	start := time.Now()
+	n, err := r.relayPackets(ctx, pathName, srcChainID, srcChannelID)
+	track(rep, []string{"tx", "relay-packets", pathName, srcChainID, srcChannelID}, start, strconv.Itoa(n), err)
+	return n, err
+}
+
+func (r *Relayer) relayPackets(ctx context.Context, pathName, srcChainID, srcChannelID string) (int, error) {
+	l, err := r.leg(ctx, pathName, srcChainID, srcChannelID)
+	if err != nil {
+		return 0, err
+	}
+
+	r.relayMu.Lock()
+	defer r.relayMu.Unlock()
+
+	deliverable, _, err := pendingPackets(ctx, l)
+	if err != nil {
+		return 0, err
+	}
+	return relayRecvs(ctx, l, deliverable)
+}
+
+// RelayTimeouts times out on srcChainID every packet sent from srcChainID on srcChannelID
+// that has not been received and can no longer be received by the counterparty chain.
+// It returns the number of packets timed out.
+func (r *Relayer) RelayTimeouts(ctx context.Context, rep ibc.RelayerExecReporter, pathName, srcChainID, srcChannelID string) (int, error) {
+	start := time.Now()
+	n, err := r.relayTimeouts(ctx, pathName, srcChainID, srcChannelID)
+	track(rep, []string{"tx", "relay-timeouts", pathName, srcChainID, srcChannelID}, start, strconv.Itoa(n), err)
+	return n, err
+}
+
+func (r *Relayer) relayTimeouts(ctx context.Context, pathName, srcChainID, srcChannelID string) (int, error) {
+	l, err := r.leg(ctx, pathName, srcChainID, srcChannelID)
+	if err != nil {
+		return 0, err
+	}
+
+	r.relayMu.Lock()
+	defer r.relayMu.Unlock()
+
+	_, timedOut, err := pendingPackets(ctx, l)
+	if err != nil {
+		return 0, err
+	}
+	return relayTimeouts(ctx, l, timedOut)
+}
+
+// RelayAcknowledgements relays back to srcChainID the acknowledgements the counterparty chain wrote
+// for packets sent from srcChainID on srcChannelID.
+// It returns the number of acknowledgements relayed.
+func (r *Relayer) RelayAcknowledgements(ctx context.Context, rep ibc.RelayerExecReporter, pathName, srcChainID, srcChannelID string) (int, error) {
+	start := time.Now()
+	n, err := r.relayAcknowledgements(ctx, pathName, srcChainID, srcChannelID)
+	track(rep, []string{"tx", "relay-acknowledgements", pathName, srcChainID, srcChannelID}, start, strconv.Itoa(n), err)
+	return n, err
+}
+
+func (r *Relayer) relayAcknowledgements(ctx context.Context, pathName, srcChainID, srcChannelID string) (int, error) {
+	l, err := r.leg(ctx, pathName, srcChainID, srcChannelID)
+	if err != nil {
+		return 0, err
+	}
+
+	r.relayMu.Lock()
+	defer r.relayMu.Unlock()
+
+	return relayAcks(ctx, l)
+}
+
+// Flush relays all outstanding packets, acknowledgements, and timeouts in both directions
+// on the given channel of the path's source chain,
+// or on every open channel of the path that its filter allows if channelID is empty.
+func (r *Relayer) Flush(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) error {
+	start := time.Now()
+	err := r.flush(ctx, pathName, channelID)
+	track(rep, []string{"tx", "flush", pathName, channelID}, start, "", err)
+	return err
+}
+
+func (r *Relayer) flush(ctx context.Context, pathName, channelID string) error {
+	p, src, _, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+
+	var channelIDs []string
+	if channelID != "" {
+		channelIDs = []string{channelID}
+	} else {
+		chans, err := src.channels(ctx)
+		if err != nil {
+			return err
+		}
+		for _, ch := range chans {
+			if ch.State != channeltypes.OPEN || len(ch.ConnectionHops) != 1 || ch.ConnectionHops[0] != p.src.connectionID {
+				continue
+			}
+			if !channelAllowed(p.filter, ch.ChannelId) {
+				continue
+			}
+			channelIDs = append(channelIDs, ch.ChannelId)
+		}
+	}
+
+	r.relayMu.Lock()
+	defer r.relayMu.Unlock()
+
+	for _, id := range channelIDs {
+		l, err := r.leg(ctx, pathName, p.src.chainID, id)
+		if err != nil {
+			return err
+		}
+		if err := flushLeg(ctx, l); err != nil {
+			return err
+		}
+		if err := flushLeg(ctx, l.reverse()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flushLeg relays the packets, acknowledgements, and timeouts outstanding on a single leg.
+// The caller must hold r.relayMu.
+func flushLeg(ctx context.Context, l leg) error {
+	deliverable, timedOut, err := pendingPackets(ctx, l)
+	if err != nil {
+		return err
+	}
+	if _, err := relayRecvs(ctx, l, deliverable); err != nil {
+		return err
+	}
+	if _, err := relayAcks(ctx, l); err != nil {
+		return err
+	}
+	_, err = relayTimeouts(ctx, l, timedOut)
+	return err
+}
+
+// StartRelayer starts a background worker that repeatedly flushes the given paths until StopRelayer is called.
+func (r *Relayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecReporter, pathNames ...string) error {
+	start := time.Now()
+	err := r.startRelayer(pathNames)
+	track(rep, append([]string{"start"}, pathNames...), start, "", err)
+	return err
+}
+
+func (r *Relayer) startRelayer(pathNames []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		return errors.New("tried to start relayer again without stopping first")
+	}
+	for _, name := range pathNames {
+		if _, ok := r.paths[name]; !ok {
+			return fmt.Errorf("path %s does not exist", name)
+		}
+	}
+
+	// The worker must outlive the context passed to StartRelayer.
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+
+	go r.relayLoop(ctx, r.done, pathNames)
+	return nil
+}
+
+func (r *Relayer) relayLoop(ctx context.Context, done chan<- struct{}, pathNames []string) {
+	defer close(done)
+
+	ticker := time.NewTicker(relayInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, name := range pathNames {
+			if err := r.flush(ctx, name, ""); err != nil && ctx.Err() == nil {
+				r.log.Info("Failed to flush path", zap.String("path", name), zap.Error(err))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// StopRelayer stops the background worker started by StartRelayer and waits for it to exit.
+func (r *Relayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	start := time.Now()
+	err := r.stopRelayer(ctx)
+	track(rep, []string{"stop"}, start, "", err)
+	return err
+}
+
+func (r *Relayer) stopRelayer(ctx context.Context) error {
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.cancel, r.done = nil, nil
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// UseDockerNetwork reports false, as the relayer reaches the chains through their host-exposed ports.
+func (r *Relayer) UseDockerNetwork() bool {
+	return false
+}
+
+// Exec is not supported, as the relayer has no command line.
+func (r *Relayer) Exec(ctx context.Context, rep ibc.RelayerExecReporter, cmd []string, env []string) ibc.RelayerExecResult {
+	start := time.Now()
+	err := fmt.Errorf("in-process relayer does not support executing commands: %v", cmd)
+	track(rep, cmd, start, "", err)
+	return ibc.RelayerExecResult{Err: err}
+}
+
+// SetClientContractHash is not supported, as the relayer only creates Tendermint clients.
+func (r *Relayer) SetClientContractHash(ctx context.Context, rep ibc.RelayerExecReporter, cfg ibc.ChainConfig, hash string) error {
+	return errors.New("in-process relayer does not support wasm clients")
+}
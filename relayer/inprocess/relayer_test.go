@@ -0,0 +1,74 @@
+package inprocess
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestChannelAllowed(t *testing.T) {
+	list := []string{"channel-0", "channel-2"}
+
+	require.True(t, channelAllowed(ibc.ChannelFilter{}, "channel-1"))
+
+	allow := ibc.ChannelFilter{Rule: filterAllowlist, ChannelList: list}
+	require.True(t, channelAllowed(allow, "channel-0"))
+	require.False(t, channelAllowed(allow, "channel-1"))
+
+	deny := ibc.ChannelFilter{Rule: filterDenylist, ChannelList: list}
+	require.False(t, channelAllowed(deny, "channel-0"))
+	require.True(t, channelAllowed(deny, "channel-1"))
+}
+
+func TestTrustingPeriod(t *testing.T) {
+	const unbonding = 21 * 24 * time.Hour
+
+	d, err := trustingPeriod(ibc.DefaultClientOpts(), ibc.ChainConfig{}, unbonding)
+	require.NoError(t, err)
+	require.Equal(t, 14*24*time.Hour, d)
+
+	d, err = trustingPeriod(ibc.DefaultClientOpts(), ibc.ChainConfig{TrustingPeriod: "48h"}, unbonding)
+	require.NoError(t, err)
+	require.Equal(t, 48*time.Hour, d)
+
+	d, err = trustingPeriod(ibc.CreateClientOptions{TrustingPeriod: "1h"}, ibc.ChainConfig{TrustingPeriod: "48h"}, unbonding)
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, d)
+
+	_, err = trustingPeriod(ibc.CreateClientOptions{TrustingPeriod: "504h"}, ibc.ChainConfig{}, unbonding)
+	require.Error(t, err)
+}
+
+func TestRelayer_Paths(t *testing.T) {
+	ctx := context.Background()
+	rep := ibc.NopRelayerExecReporter{}
+	r := NewRelayer(zap.NewNop())
+
+	require.False(t, r.UseDockerNetwork())
+
+	require.NoError(t, r.GeneratePath(ctx, rep, "chain-a", "chain-b", "ab"))
+	require.Error(t, r.GeneratePath(ctx, rep, "chain-a", "chain-b", "ab"), "duplicate path")
+	require.Error(t, r.GeneratePath(ctx, rep, "chain-a", "chain-a", "aa"), "path to self")
+
+	require.NoError(t, r.UpdatePath(ctx, rep, "ab", ibc.ChannelFilter{Rule: filterAllowlist, ChannelList: []string{"channel-0"}}))
+	require.Error(t, r.UpdatePath(ctx, rep, "ab", ibc.ChannelFilter{Rule: "bogus"}))
+	require.Error(t, r.UpdatePath(ctx, rep, "missing", ibc.ChannelFilter{}))
+
+	src := relayer.PathEnd{ClientID: "07-tendermint-0", ConnectionID: "connection-0"}
+	dst := relayer.PathEnd{ClientID: "07-tendermint-1", ConnectionID: "connection-1"}
+	require.NoError(t, r.UsePath(ctx, rep, "ab", src, dst))
+	require.Equal(t, pathEnd{chainID: "chain-a", clientID: "07-tendermint-0", connectionID: "connection-0"}, r.paths["ab"].src)
+	require.Equal(t, pathEnd{chainID: "chain-b", clientID: "07-tendermint-1", connectionID: "connection-1"}, r.paths["ab"].dst)
+	require.Error(t, r.UsePath(ctx, rep, "missing", src, dst))
+
+	// The chains of the path were never configured.
+	require.Error(t, r.Flush(ctx, rep, "ab", ""))
+	require.Error(t, r.StartRelayer(ctx, rep, "missing"))
+
+	require.NoError(t, r.StopRelayer(ctx, rep), "stopping a relayer that was never started")
+}
@@ -0,0 +1,224 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// MetricsCommander is implemented by a RelayerCommander whose relayer exposes Prometheus metrics
+// while it runs. The relayer's generated config or start command must enable the endpoint.
+type MetricsCommander interface {
+	// MetricsPort is the container port serving metrics, e.g. "3001/tcp".
+	MetricsPort() string
+
+	// MetricsPath is the HTTP path of the metrics, e.g. "/metrics".
+	MetricsPath() string
+
+	// MetricNames are the metrics the relayer reports for the accessors of RelayerMetrics.
+	MetricNames() MetricNames
+}
+
+// MetricNames are the names and labels under which a relayer implementation reports
+// the metrics that RelayerMetrics normalizes across implementations.
+// A zero MetricName means the relayer does not report the metric.
+type MetricNames struct {
+	// PacketsRelayed counts the packets the relayer delivered to a chain with MsgRecvPacket.
+	PacketsRelayed MetricName
+
+	// TxErrors counts the transactions of the relayer that failed, on any chain.
+	TxErrors MetricName
+
+	// WalletBalance is the balance of the relayer wallet on a chain, in one denom.
+	WalletBalance MetricName
+}
+
+// MetricName selects the samples of one metric.
+type MetricName struct {
+	// Names of the metric. The samples of every name are summed,
+	// as relayer versions differ in metric names, e.g. in the _total suffix of counters.
+	Names []string
+
+	// Labels that every selected sample must have, e.g. the message type.
+	Labels map[string]string
+
+	// ChainLabel and DenomLabel are the labels holding the chain ID and the denom,
+	// for the accessors that select samples by chain and denom.
+	ChainLabel, DenomLabel string
+}
+
+// Metrics are the Prometheus metrics of a relayer, keyed by metric name.
+// Metric names and labels are specific to each relayer implementation,
+// e.g. rly reports relayed packets as cosmos_relayer_relayed_packets.
+//
+// Histograms and summaries are reported as their _sum and _count samples.
+type Metrics map[string][]MetricSample
+
+// MetricSample is the value of a metric for one set of labels.
+type MetricSample struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// Sum returns the sum of the values of the named metric's samples that have all the given labels,
+// or 0 if the relayer did not report the metric.
+func (m Metrics) Sum(name string, labels map[string]string) float64 {
+	var sum float64
+SAMPLES:
+	for _, s := range m[name] {
+		for k, v := range labels {
+			if s.Labels[k] != v {
+				continue SAMPLES
+			}
+		}
+		sum += s.Value
+	}
+	return sum
+}
+
+// RelayerMetrics are the metrics scraped from a relayer.
+// Besides the raw Metrics, it has accessors for the metrics that every relayer reports under its own names.
+type RelayerMetrics struct {
+	Metrics
+
+	names MetricNames
+}
+
+// NewRelayerMetrics returns the metrics of a relayer that reports them under names.
+func NewRelayerMetrics(m Metrics, names MetricNames) RelayerMetrics {
+	return RelayerMetrics{Metrics: m, names: names}
+}
+
+// PacketsRelayed returns the number of packets the relayer delivered to the chain with MsgRecvPacket.
+func (m RelayerMetrics) PacketsRelayed(chainID string) (float64, error) {
+	return m.sum("packets relayed", m.names.PacketsRelayed, chainID, "")
+}
+
+// TxErrors returns the number of transactions of the relayer that failed, on any chain.
+func (m RelayerMetrics) TxErrors() (float64, error) {
+	return m.sum("tx errors", m.names.TxErrors, "", "")
+}
+
+// WalletBalance returns the balance of the relayer wallet on the chain, in denom.
+func (m RelayerMetrics) WalletBalance(chainID, denom string) (float64, error) {
+	return m.sum("wallet balances", m.names.WalletBalance, chainID, denom)
+}
+
+func (m RelayerMetrics) sum(desc string, n MetricName, chainID, denom string) (float64, error) {
+	if len(n.Names) == 0 {
+		return 0, fmt.Errorf("relayer does not report %s", desc)
+	}
+	labels := make(map[string]string, len(n.Labels)+2)
+	for k, v := range n.Labels {
+		labels[k] = v
+	}
+	if chainID != "" {
+		labels[n.ChainLabel] = chainID
+	}
+	if denom != "" {
+		labels[n.DenomLabel] = denom
+	}
+
+	var sum float64
+	for _, name := range n.Names {
+		sum += m.Sum(name, labels)
+	}
+	return sum, nil
+}
+
+// ParseMetrics parses metrics in the Prometheus text exposition format.
+func ParseMetrics(r io.Reader) (Metrics, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := make(Metrics, len(families))
+	for name, f := range families {
+		for _, m := range f.GetMetric() {
+			labels := make(map[string]string, len(m.GetLabel()))
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			add := func(name string, v float64) {
+				metrics[name] = append(metrics[name], MetricSample{Labels: labels, Value: v})
+			}
+
+			switch f.GetType() {
+			case dto.MetricType_COUNTER:
+				add(name, m.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add(name, m.GetGauge().GetValue())
+			case dto.MetricType_HISTOGRAM:
+				add(name+"_sum", m.GetHistogram().GetSampleSum())
+				add(name+"_count", float64(m.GetHistogram().GetSampleCount()))
+			case dto.MetricType_SUMMARY:
+				add(name+"_sum", m.GetSummary().GetSampleSum())
+				add(name+"_count", float64(m.GetSummary().GetSampleCount()))
+			default:
+				add(name, m.GetUntyped().GetValue())
+			}
+		}
+	}
+	return metrics, nil
+}
+
+// metricsPorts returns the ports to expose on the host for the commander's metrics, if any.
+func metricsPorts(c RelayerCommander) nat.PortSet {
+	mc, ok := c.(MetricsCommander)
+	if !ok {
+		return nil
+	}
+	return nat.PortSet{nat.Port(mc.MetricsPort()): {}}
+}
+
+// Metrics scrapes the Prometheus metrics of the running relayer,
+// from the telemetry endpoint enabled in the relayer's config and exposed on the host.
+// It returns an error if the relayer is not running, or does not expose metrics.
+func (r *DockerRelayer) Metrics(ctx context.Context) (RelayerMetrics, error) {
+	mc, ok := r.c.(MetricsCommander)
+	if !ok {
+		return RelayerMetrics{}, fmt.Errorf("%s relayer does not expose metrics", r.c.Name())
+	}
+	if r.containerLifecycle == nil {
+		return RelayerMetrics{}, errors.New("relayer is not running")
+	}
+
+	ports, err := r.containerLifecycle.GetHostPorts(ctx, mc.MetricsPort())
+	if err != nil {
+		return RelayerMetrics{}, fmt.Errorf("failed to get metrics host port: %w", err)
+	}
+	if ports[0] == "" {
+		return RelayerMetrics{}, fmt.Errorf("metrics port %s is not exposed on the host", mc.MetricsPort())
+	}
+
+	// Scraping should be quick, but the relayer may be busy right after starting.
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+ports[0]+mc.MetricsPath(), nil)
+	if err != nil {
+		return RelayerMetrics{}, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return RelayerMetrics{}, fmt.Errorf("failed to scrape relayer metrics: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return RelayerMetrics{}, fmt.Errorf("failed to scrape relayer metrics: %s", res.Status)
+	}
+
+	metrics, err := ParseMetrics(res.Body)
+	if err != nil {
+		return RelayerMetrics{}, fmt.Errorf("failed to parse relayer metrics: %w", err)
+	}
+	return NewRelayerMetrics(metrics, mc.MetricNames()), nil
+}
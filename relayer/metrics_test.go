@@ -0,0 +1,85 @@
+package relayer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetrics = `# HELP cosmos_relayer_relayed_packets The total number of packets relayed
+# TYPE cosmos_relayer_relayed_packets counter
+cosmos_relayer_relayed_packets{chain="a",channel="channel-0",path_name="ab",port="transfer",type="MsgRecvPacket"} 3
+cosmos_relayer_relayed_packets{chain="b",channel="channel-0",path_name="ab",port="transfer",type="MsgAcknowledgement"} 2
+# HELP cosmos_relayer_wallet_balance The current balance for the relayer's wallet
+# TYPE cosmos_relayer_wallet_balance gauge
+cosmos_relayer_wallet_balance{chain="a",denom="uatom",key="key"} 9.5e+06
+# TYPE tx_latency histogram
+tx_latency_bucket{le="1"} 1
+tx_latency_bucket{le="+Inf"} 2
+tx_latency_sum 3.5
+tx_latency_count 2
+untyped_metric 7
+`
+
+func TestParseMetrics(t *testing.T) {
+	m, err := relayer.ParseMetrics(strings.NewReader(testMetrics))
+	require.NoError(t, err)
+
+	require.Equal(t, 5.0, m.Sum("cosmos_relayer_relayed_packets", nil))
+	require.Equal(t, 3.0, m.Sum("cosmos_relayer_relayed_packets", map[string]string{"path_name": "ab", "type": "MsgRecvPacket"}))
+	require.Zero(t, m.Sum("cosmos_relayer_relayed_packets", map[string]string{"path_name": "cd"}))
+	require.Equal(t, 9_500_000.0, m.Sum("cosmos_relayer_wallet_balance", map[string]string{"chain": "a"}))
+	require.Equal(t, 3.5, m.Sum("tx_latency_sum", nil))
+	require.Equal(t, 2.0, m.Sum("tx_latency_count", nil))
+	require.Equal(t, 7.0, m.Sum("untyped_metric", nil))
+	require.Zero(t, m.Sum("missing", nil))
+
+	_, err = relayer.ParseMetrics(strings.NewReader("not metrics{"))
+	require.Error(t, err)
+}
+
+func TestRelayerMetrics(t *testing.T) {
+	m, err := relayer.ParseMetrics(strings.NewReader(testMetrics + `# TYPE broadcast_errors_total counter
+broadcast_errors_total{account="cosmos1relayer",error_code="32",error_description="incorrect account sequence"} 2
+`))
+	require.NoError(t, err)
+
+	rm := relayer.NewRelayerMetrics(m, relayer.MetricNames{
+		PacketsRelayed: relayer.MetricName{
+			Names:      []string{"cosmos_relayer_relayed_packets"},
+			Labels:     map[string]string{"type": "MsgRecvPacket"},
+			ChainLabel: "chain",
+		},
+		TxErrors: relayer.MetricName{
+			Names: []string{"broadcast_errors", "broadcast_errors_total"},
+		},
+		WalletBalance: relayer.MetricName{
+			Names:      []string{"cosmos_relayer_wallet_balance"},
+			ChainLabel: "chain",
+			DenomLabel: "denom",
+		},
+	})
+
+	packets, err := rm.PacketsRelayed("a")
+	require.NoError(t, err)
+	require.Equal(t, 3.0, packets)
+	packets, err = rm.PacketsRelayed("b")
+	require.NoError(t, err)
+	require.Zero(t, packets, "acknowledgements are not received packets")
+
+	txErrors, err := rm.TxErrors()
+	require.NoError(t, err)
+	require.Equal(t, 2.0, txErrors)
+
+	balance, err := rm.WalletBalance("a", "uatom")
+	require.NoError(t, err)
+	require.Equal(t, 9_500_000.0, balance)
+	balance, err = rm.WalletBalance("a", "uosmo")
+	require.NoError(t, err)
+	require.Zero(t, balance)
+
+	_, err = relayer.NewRelayerMetrics(m, relayer.MetricNames{}).PacketsRelayed("a")
+	require.Error(t, err)
+}
@@ -44,6 +44,21 @@ func NewCosmosRelayer(log *zap.Logger, testName string, cli *client.Client, netw
 	return r
 }
 
+var _ relayer.SharedPathRelayer = (*CosmosRelayer)(nil)
+
+// UsePath sets the clients and connection of the path, so that it relays over ones linked by another relayer.
+func (r *CosmosRelayer) UsePath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, src, dst relayer.PathEnd) error {
+	cmd := []string{
+		"rly", "paths", "update", pathName,
+		"--src-client-id", src.ClientID,
+		"--src-connection-id", src.ConnectionID,
+		"--dst-client-id", dst.ClientID,
+		"--dst-connection-id", dst.ConnectionID,
+		"--home", r.HomeDir(),
+	}
+	return r.Exec(ctx, rep, cmd, nil).Err
+}
+
 type CosmosRelayerChainConfigValue struct {
 	AccountPrefix  string  `json:"account-prefix"`
 	ChainID        string  `json:"chain-id"`
@@ -67,6 +82,8 @@ type CosmosRelayerChainConfig struct {
 const (
 	DefaultContainerImage   = "ghcr.io/cosmos/relayer"
 	DefaultContainerVersion = "v2.3.1"
+
+	metricsPort = "5183"
 )
 
 // Capabilities returns the set of capabilities of the Cosmos relayer.
@@ -238,12 +255,40 @@ func (c commander) StartRelayer(homeDir string, pathNames ...string) []string {
 	cmd := []string{
 		"rly", "start", "--debug",
 		"--home", homeDir,
+		"--debug-addr", "0.0.0.0:" + metricsPort,
 	}
 	cmd = append(cmd, c.extraStartFlags...)
 	cmd = append(cmd, pathNames...)
 	return cmd
 }
 
+// MetricsPort is the port of the debug server started by rly, which serves its metrics.
+func (commander) MetricsPort() string {
+	return metricsPort + "/tcp"
+}
+
+func (commander) MetricsPath() string {
+	return "/relayer/metrics"
+}
+
+func (commander) MetricNames() relayer.MetricNames {
+	return relayer.MetricNames{
+		PacketsRelayed: relayer.MetricName{
+			Names:      []string{"cosmos_relayer_relayed_packets"},
+			Labels:     map[string]string{"type": "MsgRecvPacket"},
+			ChainLabel: "chain",
+		},
+		TxErrors: relayer.MetricName{
+			Names: []string{"cosmos_relayer_tx_failure"},
+		},
+		WalletBalance: relayer.MetricName{
+			Names:      []string{"cosmos_relayer_wallet_balance"},
+			ChainLabel: "chain",
+			DenomLabel: "denom",
+		},
+	}
+}
+
 func (commander) UpdateClients(pathName, homeDir string) []string {
 	return []string{
 		"rly", "tx", "update-clients", pathName,
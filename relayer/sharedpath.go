@@ -0,0 +1,61 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// PathEnd identifies the light client and connection of one chain's end of a path.
+type PathEnd struct {
+	ClientID     string
+	ConnectionID string
+}
+
+// SharedPathRelayer is implemented by relayers that can relay over the clients and connection
+// of a path linked by another relayer, so that several relayers serve the same channels.
+type SharedPathRelayer interface {
+	// UsePath configures a path created through GeneratePath to relay over existing clients and connection,
+	// instead of linking new ones. The ends refer to the path's source and destination chains.
+	UsePath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, src, dst PathEnd) error
+}
+
+// SharePath configures the path of the relayer to, generated between chainID and the channel's counterparty chain,
+// to relay over the clients and connection of the channel on chainID relayed by the relayer from.
+func SharePath(ctx context.Context, rep ibc.RelayerExecReporter, from ibc.Relayer, chainID, channelID string, to ibc.Relayer, toPath string) error {
+	shared, ok := to.(SharedPathRelayer)
+	if !ok {
+		return fmt.Errorf("relayer %T cannot relay on an existing path", to)
+	}
+
+	channels, err := from.GetChannels(ctx, rep, chainID)
+	if err != nil {
+		return fmt.Errorf("failed to get channels on %s: %w", chainID, err)
+	}
+	var connectionID string
+	for _, ch := range channels {
+		if ch.ChannelID == channelID && len(ch.ConnectionHops) == 1 {
+			connectionID = ch.ConnectionHops[0]
+			break
+		}
+	}
+	if connectionID == "" {
+		return fmt.Errorf("channel %s not found on %s", channelID, chainID)
+	}
+
+	connections, err := from.GetConnections(ctx, rep, chainID)
+	if err != nil {
+		return fmt.Errorf("failed to get connections on %s: %w", chainID, err)
+	}
+	for _, conn := range connections {
+		if conn.ID != connectionID || conn.Counterparty == nil {
+			continue
+		}
+		return shared.UsePath(ctx, rep, toPath,
+			PathEnd{ClientID: conn.ClientID, ConnectionID: conn.ID},
+			PathEnd{ClientID: conn.Counterparty.ClientId, ConnectionID: conn.Counterparty.ConnectionId},
+		)
+	}
+	return fmt.Errorf("connection %s of channel %s not found on %s", connectionID, channelID, chainID)
+}
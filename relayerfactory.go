@@ -94,6 +94,14 @@ func (f builtinRelayerFactory) Name() string {
 			}
 		}
 		return "hermes@" + hermes.DefaultContainerVersion
+	case ibc.Hyperspace:
+		for _, opt := range f.options {
+			switch o := opt.(type) {
+			case relayer.RelayerOptionDockerImage:
+				return "hyperspace@" + o.DockerImage.Version
+			}
+		}
+		return "hyperspace@" + hyperspace.HyperspaceDefaultContainerVersion
 	default:
 		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
 	}
@@ -106,8 +114,9 @@ func (f builtinRelayerFactory) Capabilities() map[relayer.Capability]bool {
 	case ibc.CosmosRly:
 		return rly.Capabilities()
 	case ibc.Hermes:
-		// TODO: specify capability for hermes.
-		return rly.Capabilities()
+		return hermes.Capabilities()
+	case ibc.Hyperspace:
+		return hyperspace.HyperspaceCapabilities()
 	default:
 		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
 	}
@@ -0,0 +1,32 @@
+package interchaintest_test
+
+import (
+	"strings"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestBuiltinRelayerFactory_Capabilities(t *testing.T) {
+	for _, impl := range []ibc.RelayerImplementation{ibc.CosmosRly, ibc.Hermes, ibc.Hyperspace} {
+		rf := interchaintest.NewBuiltinRelayerFactory(impl, zaptest.NewLogger(t))
+		t.Run(rf.Name(), func(t *testing.T) {
+			caps := rf.Capabilities()
+			// Every known capability must be declared, so that conformance tests
+			// requiring a new capability are deliberately enabled or skipped per relayer.
+			for c := relayer.TimestampTimeout; c <= relayer.SharedPath; c++ {
+				require.False(t, strings.HasPrefix(c.String(), "Capability("), "capability %d has no name", c)
+				_, ok := caps[c]
+				require.Truef(t, ok, "capability %s not declared", c)
+			}
+		})
+	}
+
+	require.True(t, interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Capabilities()[relayer.OrderedChannel])
+	require.False(t, interchaintest.NewBuiltinRelayerFactory(ibc.Hermes, zaptest.NewLogger(t)).Capabilities()[relayer.MultiPathFilter])
+	require.False(t, interchaintest.NewBuiltinRelayerFactory(ibc.Hyperspace, zaptest.NewLogger(t)).Capabilities()[relayer.SharedPath])
+}
@@ -0,0 +1,92 @@
+package interchaintest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer/inprocess"
+	"go.uber.org/zap"
+)
+
+// RelayerFactoryConstructor returns the factory of a registered relayer type.
+// The options are the overrides of a topology or matrix file;
+// relayers that do not run in Docker may ignore those that do not apply to them.
+type RelayerFactoryConstructor func(log *zap.Logger, options ...relayer.RelayerOption) RelayerFactory
+
+var (
+	relayerTypesMu sync.RWMutex
+	relayerTypes   = make(map[string]RelayerFactoryConstructor)
+)
+
+// RegisterRelayerType registers the factory constructor of relayers named typ,
+// so that topology files, matrix files and the conformance tests can use relayers implemented
+// outside of interchaintest, whether or not they run in Docker.
+// The tests run against a relayer depend on the Capabilities of its factory.
+// The rly (or cosmos/relayer), hermes, hyperspace and inprocess types are registered by default.
+//
+// RegisterRelayerType is typically called from an init function.
+// It panics if typ is empty, newFactory is nil, or typ is already registered.
+func RegisterRelayerType(typ string, newFactory RelayerFactoryConstructor) {
+	if typ == "" {
+		panic("interchaintest: RegisterRelayerType with empty relayer type")
+	}
+	if newFactory == nil {
+		panic("interchaintest: RegisterRelayerType with nil constructor for relayer type " + typ)
+	}
+
+	relayerTypesMu.Lock()
+	defer relayerTypesMu.Unlock()
+	if _, dup := relayerTypes[typ]; dup {
+		panic("interchaintest: RegisterRelayerType called twice for relayer type " + typ)
+	}
+	relayerTypes[typ] = newFactory
+}
+
+// unregisterRelayerType removes the relayer type registered as typ, so that tests can register it again.
+func unregisterRelayerType(typ string) {
+	relayerTypesMu.Lock()
+	defer relayerTypesMu.Unlock()
+	delete(relayerTypes, typ)
+}
+
+// RelayerTypes returns the sorted names of the registered relayer types.
+func RelayerTypes() []string {
+	relayerTypesMu.RLock()
+	defer relayerTypesMu.RUnlock()
+	types := make([]string, 0, len(relayerTypes))
+	for typ := range relayerTypes {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// NewRelayerFactory returns the factory of the relayer type registered as typ.
+func NewRelayerFactory(typ string, log *zap.Logger, options ...relayer.RelayerOption) (RelayerFactory, error) {
+	relayerTypesMu.RLock()
+	newFactory, ok := relayerTypes[typ]
+	relayerTypesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown relayer type %q (valid types: %s)", typ, strings.Join(RelayerTypes(), ", "))
+	}
+	return newFactory(log, options...), nil
+}
+
+func init() {
+	builtin := func(impl ibc.RelayerImplementation) RelayerFactoryConstructor {
+		return func(log *zap.Logger, options ...relayer.RelayerOption) RelayerFactory {
+			return NewBuiltinRelayerFactory(impl, log, options...)
+		}
+	}
+	RegisterRelayerType("rly", builtin(ibc.CosmosRly))
+	RegisterRelayerType("cosmos/relayer", builtin(ibc.CosmosRly))
+	RegisterRelayerType("hermes", builtin(ibc.Hermes))
+	RegisterRelayerType("hyperspace", builtin(ibc.Hyperspace))
+	RegisterRelayerType("inprocess", func(log *zap.Logger, _ ...relayer.RelayerOption) RelayerFactory {
+		return inprocess.NewRelayerFactory(log)
+	})
+}
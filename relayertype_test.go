@@ -0,0 +1,59 @@
+package interchaintest_test
+
+import (
+	"testing"
+
+	"github.com/docker/docker/client"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+)
+
+type testRelayerFactory struct {
+	options relayer.RelayerOptions
+}
+
+func (testRelayerFactory) Build(*testing.T, *client.Client, string) ibc.Relayer { return nil }
+
+func (testRelayerFactory) Name() string { return "test-relayer@v1" }
+
+func (testRelayerFactory) Capabilities() map[relayer.Capability]bool {
+	return map[relayer.Capability]bool{relayer.TimestampTimeout: true}
+}
+
+func TestRegisterRelayerType(t *testing.T) {
+	require.Subset(t, interchaintest.RelayerTypes(), []string{"cosmos/relayer", "hermes", "hyperspace", "inprocess", "rly"})
+
+	t.Cleanup(func() { interchaintest.UnregisterRelayerType("test-relayer") })
+	interchaintest.RegisterRelayerType("test-relayer", func(_ *zap.Logger, options ...relayer.RelayerOption) interchaintest.RelayerFactory {
+		return testRelayerFactory{options: options}
+	})
+	require.PanicsWithValue(t, "interchaintest: RegisterRelayerType called twice for relayer type test-relayer", func() {
+		interchaintest.RegisterRelayerType("test-relayer", func(*zap.Logger, ...relayer.RelayerOption) interchaintest.RelayerFactory {
+			return nil
+		})
+	})
+
+	log := zaptest.NewLogger(t)
+	rf, err := interchaintest.NewRelayerFactory("test-relayer", log, relayer.HomeDir("/tmp/relayer"))
+	require.NoError(t, err)
+	require.Equal(t, "test-relayer@v1", rf.Name())
+	require.True(t, rf.Capabilities()[relayer.TimestampTimeout])
+	require.Len(t, rf.(testRelayerFactory).options, 1)
+
+	rf, err = interchaintest.NewRelayerFactory("hermes", log)
+	require.NoError(t, err)
+	require.True(t, rf.Capabilities()[relayer.TimestampTimeout])
+
+	_, err = interchaintest.NewRelayerFactory("bogus", log)
+	require.ErrorContains(t, err, `unknown relayer type "bogus" (valid types: `)
+
+	topo := &interchaintest.Topology{
+		Chains:   []interchaintest.TopologyChain{{ChainSpec: &interchaintest.ChainSpec{Name: "gaia", Version: "v1.0.0"}}},
+		Relayers: []interchaintest.TopologyRelayer{{Name: "r", Type: "test-relayer"}},
+	}
+	require.NoError(t, topo.Validate(log))
+}
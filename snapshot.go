@@ -0,0 +1,556 @@
+package interchaintest
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"golang.org/x/sync/errgroup"
+)
+
+// snapshotManifestFile is the name of the file in a snapshot directory describing the saved topology.
+// It is written last, so its presence indicates a complete snapshot.
+const snapshotManifestFile = "interchain.json"
+
+// snapshotableChain is implemented by chains whose state can be saved by Interchain.Snapshot
+// and resumed by RestoreInterchain. The built-in Cosmos chains implement it.
+type snapshotableChain interface {
+	ibc.Chain
+
+	// NodeVolumes returns the volume of each node, keyed by a node identifier that does not depend on the test name.
+	NodeVolumes() map[string]string
+
+	StopAllNodes(ctx context.Context) error
+	StartAllNodes(ctx context.Context) error
+
+	// StartFromState starts the chain from the state in its node volumes, in place of Start.
+	StartFromState(ctx context.Context) error
+}
+
+// snapshotableRelayer is implemented by relayers whose state can be saved by Interchain.Snapshot
+// and resumed by RestoreInterchain. The built-in Cosmos relayer implements it.
+type snapshotableRelayer interface {
+	ibc.Relayer
+
+	// IsRunning reports whether the relayer was started and not stopped since.
+	IsRunning() bool
+
+	// HomeDirHoldsState reports whether ArchiveHomeDir captures the relayer's full state.
+	// It is checked before any chain is stopped.
+	HomeDirHoldsState() bool
+
+	ArchiveHomeDir(ctx context.Context, w io.Writer) error
+	ExtractHomeDir(ctx context.Context, r io.Reader) error
+
+	AddWallet(chainID string, wallet ibc.Wallet)
+}
+
+// interchainSnapshot is the JSON-encoded topology of a snapshotted Interchain.
+type interchainSnapshot struct {
+	Chains   []chainSnapshot   `json:"chains"`
+	Relayers []relayerSnapshot `json:"relayers"`
+	Links    []linkSnapshot    `json:"links"`
+}
+
+type chainSnapshot struct {
+	Name    string   `json:"name"`
+	ChainID string   `json:"chain_id"`
+	Nodes   []string `json:"nodes"`
+}
+
+type relayerSnapshot struct {
+	Name   string                 `json:"name"`
+	Chains []relayerChainSnapshot `json:"chains"`
+}
+
+// relayerChainSnapshot records how a relayer was configured for a chain.
+// The addresses are saved so that they can be replaced in the relayer's home directory on restore,
+// since they contain host names that depend on the test name.
+type relayerChainSnapshot struct {
+	ChainID  string `json:"chain_id"`
+	RPCAddr  string `json:"rpc_addr"`
+	GRPCAddr string `json:"grpc_addr"`
+
+	KeyName  string `json:"key_name"`
+	Address  []byte `json:"address"`
+	Mnemonic string `json:"mnemonic"`
+}
+
+type linkSnapshot struct {
+	Relayer string `json:"relayer"`
+	Path    string `json:"path"`
+
+	// Chain IDs of the linked chains.
+	Chain1 string `json:"chain1"`
+	Chain2 string `json:"chain2"`
+
+	CreateClientOpts  ibc.CreateClientOptions  `json:"create_client_opts"`
+	CreateChannelOpts ibc.CreateChannelOptions `json:"create_channel_opts"`
+}
+
+// Snapshot saves the state of every chain and relayer of a built Interchain to dir,
+// along with the chain, relayer, and link topology,
+// so that later tests can resume from the same state through RestoreInterchain,
+// instead of starting every chain from genesis and linking every path again.
+//
+// Chain nodes are stopped while their volumes are saved, and restarted afterwards.
+// Relayers must not be running, i.e. StopRelayer must have been called after any StartRelayer;
+// Snapshot returns an error otherwise.
+//
+// Only chains and relayers that support snapshots may be part of the Interchain;
+// currently those are the Cosmos chains and the Cosmos relayer.
+func (ic *Interchain) Snapshot(ctx context.Context, dir string) error {
+	if !ic.built {
+		return errors.New("Interchain.Snapshot called before Build")
+	}
+
+	chains := make(map[string]snapshotableChain, len(ic.chains))
+	for c, id := range ic.chains {
+		sc, ok := c.(snapshotableChain)
+		if !ok {
+			return fmt.Errorf("chain %s does not support snapshots", id)
+		}
+		chains[id] = sc
+	}
+	relayers := make(map[string]snapshotableRelayer, len(ic.relayers))
+	for r, name := range ic.relayers {
+		sr, ok := r.(snapshotableRelayer)
+		if !ok || !sr.HomeDirHoldsState() {
+			return fmt.Errorf("relayer %s does not support snapshots", name)
+		}
+		if sr.IsRunning() {
+			return fmt.Errorf("relayer %s is running; call StopRelayer before Snapshot", name)
+		}
+		relayers[name] = sr
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	manifest := interchainSnapshot{
+		Links: ic.linkSnapshots(),
+	}
+
+	// Stop every chain, so that node databases are not written to while archived.
+	var eg errgroup.Group
+	for _, c := range chains {
+		c := c
+		eg.Go(func() error {
+			return c.StopAllNodes(ctx)
+		})
+	}
+	saveErr := eg.Wait()
+
+	if saveErr == nil {
+		for id, c := range chains {
+			cs, err := ic.saveChain(ctx, dir, id, c)
+			if err != nil {
+				saveErr = err
+				break
+			}
+			manifest.Chains = append(manifest.Chains, cs)
+		}
+	}
+
+	// Restart the chains regardless of whether saving succeeded, so the test can continue.
+	// A new group is used, as a group keeps the first error of a previous Wait.
+	var restartEg errgroup.Group
+	for _, c := range chains {
+		c := c
+		restartEg.Go(func() error {
+			if err := c.StartAllNodes(ctx); err != nil {
+				return fmt.Errorf("failed to restart chain %s after snapshot: %w", c.Config().Name, err)
+			}
+			return nil
+		})
+	}
+	if err := restartEg.Wait(); err != nil {
+		if saveErr != nil {
+			return fmt.Errorf("%v; %w", saveErr, err)
+		}
+		return err
+	}
+	if saveErr != nil {
+		return saveErr
+	}
+
+	for name, r := range relayers {
+		rs, err := ic.saveRelayer(ctx, dir, name, r)
+		if err != nil {
+			return err
+		}
+		manifest.Relayers = append(manifest.Relayers, rs)
+	}
+
+	sort.Slice(manifest.Chains, func(i, j int) bool { return manifest.Chains[i].ChainID < manifest.Chains[j].ChainID })
+	sort.Slice(manifest.Relayers, func(i, j int) bool { return manifest.Relayers[i].Name < manifest.Relayers[j].Name })
+
+	bz, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, snapshotManifestFile), bz, 0o644); err != nil {
+		return fmt.Errorf("failed to write snapshot manifest: %w", err)
+	}
+	return nil
+}
+
+// saveChain archives every node volume of the stopped chain.
+func (ic *Interchain) saveChain(ctx context.Context, dir, chainID string, c snapshotableChain) (chainSnapshot, error) {
+	chainDir := filepath.Join(dir, "chains", chainID)
+	if err := os.MkdirAll(chainDir, 0o755); err != nil {
+		return chainSnapshot{}, fmt.Errorf("failed to create snapshot directory for chain %s: %w", chainID, err)
+	}
+
+	cs := chainSnapshot{
+		Name:    c.Config().Name,
+		ChainID: chainID,
+	}
+	for node, volume := range c.NodeVolumes() {
+		if err := writeArchive(filepath.Join(chainDir, node+".tar"), func(w io.Writer) error {
+			return ic.archiver.Archive(ctx, volume, w)
+		}); err != nil {
+			return chainSnapshot{}, fmt.Errorf("failed to save node %s of chain %s: %w", node, chainID, err)
+		}
+		cs.Nodes = append(cs.Nodes, node)
+	}
+	sort.Strings(cs.Nodes)
+	return cs, nil
+}
+
+// saveRelayer archives the relayer home directory and records the relayer's chain configuration.
+func (ic *Interchain) saveRelayer(ctx context.Context, dir, name string, r snapshotableRelayer) (relayerSnapshot, error) {
+	relayersDir := filepath.Join(dir, "relayers")
+	if err := os.MkdirAll(relayersDir, 0o755); err != nil {
+		return relayerSnapshot{}, fmt.Errorf("failed to create snapshot directory for relayers: %w", err)
+	}
+	if err := writeArchive(filepath.Join(relayersDir, name+".tar"), func(w io.Writer) error {
+		return r.ArchiveHomeDir(ctx, w)
+	}); err != nil {
+		return relayerSnapshot{}, fmt.Errorf("failed to save relayer %s: %w", name, err)
+	}
+
+	rs := relayerSnapshot{Name: name}
+	for _, c := range ic.relayerChains()[r] {
+		rpcAddr, grpcAddr := relayerChainAddrs(r, c)
+		w := ic.relayerWallets[relayerChain{R: r, C: c}]
+		rs.Chains = append(rs.Chains, relayerChainSnapshot{
+			ChainID:  c.Config().ChainID,
+			RPCAddr:  rpcAddr,
+			GRPCAddr: grpcAddr,
+
+			KeyName:  w.KeyName(),
+			Address:  w.Address(),
+			Mnemonic: w.Mnemonic(),
+		})
+	}
+	sort.Slice(rs.Chains, func(i, j int) bool { return rs.Chains[i].ChainID < rs.Chains[j].ChainID })
+	return rs, nil
+}
+
+// linkSnapshots returns the links of the Interchain in a stable order.
+func (ic *Interchain) linkSnapshots() []linkSnapshot {
+	links := make([]linkSnapshot, 0, len(ic.links))
+	for rp, link := range ic.links {
+		links = append(links, linkSnapshot{
+			Relayer: ic.relayers[rp.Relayer],
+			Path:    rp.Path,
+
+			Chain1: ic.chains[link.chains[0]],
+			Chain2: ic.chains[link.chains[1]],
+
+			CreateClientOpts:  link.createClientOpts,
+			CreateChannelOpts: link.createChannelOpts,
+		})
+	}
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].Relayer != links[j].Relayer {
+			return links[i].Relayer < links[j].Relayer
+		}
+		return links[i].Path < links[j].Path
+	})
+	return links
+}
+
+// RestoreInterchain starts the chains and configures the relayers of ic from a snapshot saved in dir
+// by Interchain.Snapshot. It is used in place of Build, and the same build options apply,
+// except that SkipPathCreation has no effect, as paths are restored as they were saved.
+//
+// ic must contain chains and relayers constructed the same way as in the snapshotted Interchain.
+// Chains are matched by chain ID and relayers by name.
+// Links need not be added again, as they are restored from the snapshot;
+// if any are added, they must match the snapshot.
+//
+// The restored chains resume with the same channels, connections, clients, and keys,
+// including the faucet and relayer wallets.
+// Light clients expire if the snapshot is older than their trusting period.
+func RestoreInterchain(ctx context.Context, ic *Interchain, rep *testreporter.RelayerExecReporter, dir string, opts InterchainBuildOptions) error {
+	if ic.built {
+		panic(fmt.Errorf("RestoreInterchain called on an Interchain that was already built"))
+	}
+
+	bz, err := os.ReadFile(filepath.Join(dir, snapshotManifestFile))
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot manifest: %w", err)
+	}
+	var manifest interchainSnapshot
+	if err := json.Unmarshal(bz, &manifest); err != nil {
+		return fmt.Errorf("failed to decode snapshot manifest: %w", err)
+	}
+
+	chains, relayers, err := ic.matchSnapshot(manifest)
+	if err != nil {
+		return err
+	}
+
+	ic.built = true
+
+	chainList := make([]ibc.Chain, 0, len(chains))
+	for _, c := range chains {
+		chainList = append(chainList, c)
+	}
+	ic.cs = newChainSet(ic.log, chainList)
+	ic.nf = newNetworkFaults(ic.log, opts)
+	ic.archiver = dockerutil.NewVolumeArchiver(ic.log, opts.Client, opts.TestName)
+
+	if err := ic.cs.Initialize(ctx, opts.TestName, opts.Client, opts.NetworkID); err != nil {
+		return fmt.Errorf("failed to initialize chains: %w", err)
+	}
+
+	var eg errgroup.Group
+	for _, snap := range manifest.Chains {
+		snap := snap
+		c := chains[snap.ChainID]
+		eg.Go(func() error {
+			return ic.restoreChain(ctx, dir, snap, c)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	if err := ic.cs.TrackBlocks(ctx, opts.TestName, opts.BlockDatabaseFile, opts.GitSha, rep); err != nil {
+		return fmt.Errorf("failed to track blocks: %w", err)
+	}
+
+	ic.relayerWallets = make(map[relayerChain]ibc.Wallet)
+	for _, snap := range manifest.Relayers {
+		if err := ic.restoreRelayer(ctx, dir, snap, relayers[snap.Name], chains); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// matchSnapshot checks that the chains, relayers, and links of ic match the snapshot manifest,
+// and adds the snapshotted links if ic has none.
+// It returns the chains keyed by chain ID and the relayers keyed by name.
+func (ic *Interchain) matchSnapshot(manifest interchainSnapshot) (map[string]snapshotableChain, map[string]snapshotableRelayer, error) {
+	chains := make(map[string]snapshotableChain, len(ic.chains))
+	for c, id := range ic.chains {
+		sc, ok := c.(snapshotableChain)
+		if !ok {
+			return nil, nil, fmt.Errorf("chain %s does not support snapshots", id)
+		}
+		chains[id] = sc
+	}
+	if len(chains) != len(manifest.Chains) {
+		return nil, nil, fmt.Errorf("snapshot has %d chains, but the interchain has %d", len(manifest.Chains), len(chains))
+	}
+	for _, snap := range manifest.Chains {
+		if _, ok := chains[snap.ChainID]; !ok {
+			return nil, nil, fmt.Errorf("snapshot chain %s was not added to the interchain", snap.ChainID)
+		}
+	}
+
+	relayers := make(map[string]snapshotableRelayer, len(ic.relayers))
+	for r, name := range ic.relayers {
+		sr, ok := r.(snapshotableRelayer)
+		if !ok || !sr.HomeDirHoldsState() {
+			return nil, nil, fmt.Errorf("relayer %s does not support snapshots", name)
+		}
+		relayers[name] = sr
+	}
+	if len(relayers) != len(manifest.Relayers) {
+		return nil, nil, fmt.Errorf("snapshot has %d relayers, but the interchain has %d", len(manifest.Relayers), len(relayers))
+	}
+	for _, snap := range manifest.Relayers {
+		if _, ok := relayers[snap.Name]; !ok {
+			return nil, nil, fmt.Errorf("snapshot relayer %s was not added to the interchain", snap.Name)
+		}
+	}
+
+	if len(ic.links) == 0 {
+		for _, l := range manifest.Links {
+			ic.AddLink(InterchainLink{
+				Chain1:  chains[l.Chain1],
+				Chain2:  chains[l.Chain2],
+				Relayer: relayers[l.Relayer],
+				Path:    l.Path,
+
+				CreateClientOpts:  l.CreateClientOpts,
+				CreateChannelOpts: l.CreateChannelOpts,
+			})
+		}
+		return chains, relayers, nil
+	}
+
+	got, _ := json.Marshal(ic.linkSnapshots())
+	want, _ := json.Marshal(manifest.Links)
+	if string(got) != string(want) {
+		return nil, nil, fmt.Errorf("interchain links do not match the snapshot: got %s, want %s", got, want)
+	}
+	return chains, relayers, nil
+}
+
+// restoreChain loads the saved node volumes into the initialized chain and starts it.
+func (ic *Interchain) restoreChain(ctx context.Context, dir string, snap chainSnapshot, c snapshotableChain) error {
+	volumes := c.NodeVolumes()
+	if len(volumes) != len(snap.Nodes) {
+		return fmt.Errorf("snapshot of chain %s has %d nodes, but the chain has %d", snap.ChainID, len(snap.Nodes), len(volumes))
+	}
+
+	for _, node := range snap.Nodes {
+		volume, ok := volumes[node]
+		if !ok {
+			return fmt.Errorf("snapshot node %s does not exist in chain %s", node, snap.ChainID)
+		}
+		if err := readArchive(filepath.Join(dir, "chains", snap.ChainID, node+".tar"), func(r io.Reader) error {
+			return ic.archiver.Extract(ctx, volume, r)
+		}); err != nil {
+			return fmt.Errorf("failed to restore node %s of chain %s: %w", node, snap.ChainID, err)
+		}
+	}
+
+	if err := c.StartFromState(ctx); err != nil {
+		return fmt.Errorf("failed to start chain %s from snapshot: %w", snap.ChainID, err)
+	}
+	return nil
+}
+
+// restoreRelayer loads the saved home directory into the relayer,
+// replacing the chain addresses recorded at snapshot time with the addresses of the restored chains,
+// and restores the relayer's wallets.
+func (ic *Interchain) restoreRelayer(ctx context.Context, dir string, snap relayerSnapshot, r snapshotableRelayer, chains map[string]snapshotableChain) error {
+	var replacements []string
+	for _, rc := range snap.Chains {
+		c, ok := chains[rc.ChainID]
+		if !ok {
+			return fmt.Errorf("relayer %s was configured for unknown chain %s", snap.Name, rc.ChainID)
+		}
+		rpcAddr, grpcAddr := relayerChainAddrs(r, c)
+		replacements = append(replacements, rc.RPCAddr, rpcAddr, rc.GRPCAddr, grpcAddr)
+
+		w := cosmos.NewWallet(rc.KeyName, rc.Address, rc.Mnemonic, c.Config())
+		r.AddWallet(rc.ChainID, w)
+		ic.relayerWallets[relayerChain{R: r, C: c}] = w
+	}
+
+	if err := readArchive(filepath.Join(dir, "relayers", snap.Name+".tar"), func(rd io.Reader) error {
+		pr, pw := io.Pipe()
+		go func() {
+			pw.CloseWithError(rewriteArchive(rd, pw, addressReplacer(replacements)))
+		}()
+		err := r.ExtractHomeDir(ctx, pr)
+		_ = pr.CloseWithError(err)
+		return err
+	}); err != nil {
+		return fmt.Errorf("failed to restore relayer %s: %w", snap.Name, err)
+	}
+	return nil
+}
+
+// addressReplacer returns a replacer for the old and new address pairs.
+// Longer addresses are replaced first, so that an address that is a prefix of another does not clobber it.
+func addressReplacer(oldNew []string) *strings.Replacer {
+	type pair struct{ old, new string }
+	pairs := make([]pair, 0, len(oldNew)/2)
+	for i := 0; i+1 < len(oldNew); i += 2 {
+		if oldNew[i] == "" || oldNew[i] == oldNew[i+1] {
+			continue
+		}
+		pairs = append(pairs, pair{old: oldNew[i], new: oldNew[i+1]})
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return len(pairs[i].old) > len(pairs[j].old) })
+
+	args := make([]string, 0, 2*len(pairs))
+	for _, p := range pairs {
+		args = append(args, p.old, p.new)
+	}
+	return strings.NewReplacer(args...)
+}
+
+// rewriteArchive copies the tar archive from r to w, applying the replacer to the content of every regular file.
+func rewriteArchive(r io.Reader, w io.Writer, replacer *strings.Replacer) error {
+	tr := tar.NewReader(r)
+	tw := tar.NewWriter(w)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading archive: %w", err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if _, err := io.Copy(tw, tr); err != nil {
+				return err
+			}
+			continue
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return fmt.Errorf("reading %s from archive: %w", hdr.Name, err)
+		}
+		content = []byte(replacer.Replace(string(content)))
+		hdr.Size = int64(len(content))
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(content); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// writeArchive creates the file at path and passes it to write.
+func writeArchive(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readArchive opens the file at path and passes it to read.
+func readArchive(path string, read func(io.Reader) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return read(f)
+}
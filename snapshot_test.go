@@ -0,0 +1,137 @@
+package interchaintest
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer/hermes"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer/rly"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRewriteArchive(t *testing.T) {
+	var in bytes.Buffer
+	tw := tar.NewWriter(&in)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "config/", Typeflag: tar.TypeDir, Mode: 0o755}))
+	for name, content := range map[string]string{
+		"config/config.yaml": "rpc-addr: http://chain-1-val-0-TestOld:26657\ngrpc-addr: chain-1-val-0-TestOld:9090\n",
+		"keys/key.info":      "unrelated",
+	} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o600, Uid: 1025, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+
+	replacer := addressReplacer([]string{
+		"chain-1-val-0-TestOld:9090", "chain-1-val-0-TestNew:9090",
+		"http://chain-1-val-0-TestOld:26657", "http://chain-1-val-0-TestNew:26657",
+		"unchanged", "unchanged",
+	})
+
+	var out bytes.Buffer
+	require.NoError(t, rewriteArchive(&in, &out, replacer))
+
+	got := make(map[string]string)
+	tr := tar.NewReader(&out)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(content)), hdr.Size)
+		if hdr.Typeflag == tar.TypeReg {
+			require.Equal(t, 1025, hdr.Uid)
+		}
+		got[hdr.Name] = string(content)
+	}
+
+	require.Equal(t, map[string]string{
+		"config/":            "",
+		"config/config.yaml": "rpc-addr: http://chain-1-val-0-TestNew:26657\ngrpc-addr: chain-1-val-0-TestNew:9090\n",
+		"keys/key.info":      "unrelated",
+	}, got)
+}
+
+func TestSnapshot_RequiresBuild(t *testing.T) {
+	require.Error(t, NewInterchain().Snapshot(context.Background(), t.TempDir()))
+}
+
+func TestSnapshot_UnsupportedRelayer(t *testing.T) {
+	c := cosmos.NewCosmosChain(t.Name(), ibc.ChainConfig{Name: "a", ChainID: "a"}, 1, 0, zap.NewNop())
+	ic := NewInterchain().AddChain(c).AddRelayer(new(hermes.Relayer), "h")
+	ic.built = true
+
+	// The relayer is rejected before the chains are stopped or the snapshot directory is created.
+	dir := filepath.Join(t.TempDir(), "snapshot")
+	require.ErrorContains(t, ic.Snapshot(context.Background(), dir), "relayer h does not support snapshots")
+	require.NoDirExists(t, dir)
+}
+
+func TestRestoreInterchain_ManifestMismatch(t *testing.T) {
+	newChain := func(chainID string) *cosmos.CosmosChain {
+		return cosmos.NewCosmosChain(t.Name(), ibc.ChainConfig{Name: chainID, ChainID: chainID}, 1, 0, zap.NewNop())
+	}
+	writeManifest := func(dir string, m interchainSnapshot) {
+		bz, err := json.Marshal(m)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, snapshotManifestFile), bz, 0o644))
+	}
+	restore := func(ic *Interchain, dir string) error {
+		return RestoreInterchain(context.Background(), ic, testreporter.NewNopReporter().RelayerExecReporter(t), dir, InterchainBuildOptions{})
+	}
+
+	t.Run("missing manifest", func(t *testing.T) {
+		require.Error(t, restore(NewInterchain(), t.TempDir()))
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		dir := t.TempDir()
+		writeManifest(dir, interchainSnapshot{
+			Chains: []chainSnapshot{{Name: "other", ChainID: "other", Nodes: []string{"val-0"}}},
+		})
+
+		ic := NewInterchain().AddChain(newChain("a"))
+		require.ErrorContains(t, restore(ic, dir), "snapshot chain other was not added")
+	})
+
+	t.Run("relayer", func(t *testing.T) {
+		dir := t.TempDir()
+		writeManifest(dir, interchainSnapshot{
+			Chains:   []chainSnapshot{{Name: "a", ChainID: "a", Nodes: []string{"val-0"}}},
+			Relayers: []relayerSnapshot{{Name: "r1"}},
+		})
+
+		ic := NewInterchain().AddChain(newChain("a")).AddRelayer(new(rly.CosmosRelayer), "r2")
+		require.ErrorContains(t, restore(ic, dir), "snapshot relayer r1 was not added")
+	})
+
+	t.Run("links", func(t *testing.T) {
+		dir := t.TempDir()
+		writeManifest(dir, interchainSnapshot{
+			Chains:   []chainSnapshot{{Name: "a", ChainID: "a"}, {Name: "b", ChainID: "b"}},
+			Relayers: []relayerSnapshot{{Name: "r"}},
+			Links:    []linkSnapshot{{Relayer: "r", Path: "ab", Chain1: "a", Chain2: "b"}},
+		})
+
+		a, b := newChain("a"), newChain("b")
+		r := new(rly.CosmosRelayer)
+		ic := NewInterchain().AddChain(a).AddChain(b).AddRelayer(r, "r").AddLink(InterchainLink{
+			Chain1: a, Chain2: b, Relayer: r, Path: "other",
+		})
+		require.ErrorContains(t, restore(ic, dir), "links do not match")
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -27,6 +29,17 @@ type Reporter struct {
 	in chan Message
 
 	writerDone chan error
+
+	mu sync.Mutex
+	// Names of the tests tracked through TrackTest that have not finished yet.
+	running   map[string]bool
+	listeners []*listener
+}
+
+// listener receives the messages of a test and its subtests.
+type listener struct {
+	testName string
+	f        func(Message)
 }
 
 func NewReporter(w io.WriteCloser) *Reporter {
@@ -35,6 +48,8 @@ func NewReporter(w io.WriteCloser) *Reporter {
 
 		in:         make(chan Message, 256), // Arbitrary size that seems unlikely to be filled.
 		writerDone: make(chan error, 1),
+
+		running: make(map[string]bool),
 	}
 
 	go r.write()
@@ -54,6 +69,7 @@ func (r *Reporter) write() {
 		if err := enc.Encode(JSONMessage(m)); err != nil {
 			panic(fmt.Errorf("reporter failed to encode message; tests cannot continue: %w", err))
 		}
+		r.notify(m)
 	}
 
 	r.writerDone <- r.w.Close()
@@ -69,15 +85,70 @@ func (r *Reporter) Close() error {
 	return <-r.writerDone
 }
 
+// notify calls the listeners interested in m,
+// and removes those whose test finished with m.
+func (r *Reporter) notify(m Message) {
+	name, ok := testName(m)
+	if !ok {
+		return
+	}
+	finish, finished := m.(FinishTestMessage)
+
+	r.mu.Lock()
+	var listeners, notified []*listener
+	for _, l := range r.listeners {
+		if name == l.testName || strings.HasPrefix(name, l.testName+"/") {
+			notified = append(notified, l)
+		}
+		if !finished || finish.Name != l.testName {
+			listeners = append(listeners, l)
+		}
+	}
+	r.listeners = listeners
+	r.mu.Unlock()
+
+	for _, l := range notified {
+		l.f(m)
+	}
+}
+
+// testName returns the name of the test that m is about, if any.
+func testName(m Message) (string, bool) {
+	switch m := m.(type) {
+	case BeginTestMessage:
+		return m.Name, true
+	case FinishTestMessage:
+		return m.Name, true
+	case PauseTestMessage:
+		return m.Name, true
+	case ContinueTestMessage:
+		return m.Name, true
+	case TestErrorMessage:
+		return m.Name, true
+	case TestSkipMessage:
+		return m.Name, true
+	case RelayerExecMessage:
+		return m.Name, true
+	default:
+		return "", false
+	}
+}
+
 // trackTest tracks the test start and finish time.
 // It also records which labels are present on the test.
 func (r *Reporter) TrackTest(t T) {
 	name := t.Name()
+	r.mu.Lock()
+	r.running[name] = true
+	r.mu.Unlock()
 	r.in <- BeginTestMessage{
 		Name:      name,
 		StartedAt: time.Now(),
 	}
 	t.Cleanup(func() {
+		r.mu.Lock()
+		delete(r.running, name)
+		r.mu.Unlock()
 		r.in <- FinishTestMessage{
 			Name:       name,
 			FinishedAt: time.Now(),
@@ -156,6 +227,28 @@ func (r *RelayerExecReporter) TrackRelayerExec(
 	}
 }
 
+// TestName returns the name of the test associated with r.
+func (r *RelayerExecReporter) TestName() string {
+	return r.testName
+}
+
+// Listen calls f with each subsequent message about the test associated with r, or about its subtests,
+// up to and including the FinishTestMessage of the test.
+// Messages sent before Listen was called, but not yet written, may be received too.
+// f is called from the reporter's writing goroutine, so it must not block on the reporter.
+//
+// Listen reports false, and never calls f, if the test is not tracked through TrackTest,
+// as its finish would never be reported.
+func (r *RelayerExecReporter) Listen(f func(Message)) bool {
+	r.r.mu.Lock()
+	defer r.r.mu.Unlock()
+	if !r.r.running[r.testName] {
+		return false
+	}
+	r.r.listeners = append(r.r.listeners, &listener{testName: r.testName, f: f})
+	return true
+}
+
 // TestifyT returns a TestifyReporter which will track logged errors in test.
 // Typically you will use this with the New method on the require or assert package:
 //
@@ -259,6 +259,47 @@ func TestReporter_RelayerExec(t *testing.T) {
 	require.Empty(t, diff)
 }
 
+func TestRelayerExecReporter_Listen(t *testing.T) {
+	t.Parallel()
+
+	r := testreporter.NewReporter(nopCloser{Writer: io.Discard})
+
+	mt := mocktesting.NewT("my_test")
+	require.False(t, r.RelayerExecReporter(mt).Listen(func(testreporter.Message) {}), "listened to untracked test")
+
+	r.TrackTest(mt)
+	var msgs []testreporter.Message
+	require.True(t, r.RelayerExecReporter(mt).Listen(func(m testreporter.Message) {
+		msgs = append(msgs, m)
+	}))
+
+	other := mocktesting.NewT("my_test_other")
+	r.TrackTest(other)
+	other.RunCleanups()
+
+	sub := mocktesting.NewT("my_test/sub")
+	r.TrackTest(sub)
+	r.RelayerExecReporter(sub).TrackRelayerExec("my_container", []string{"rly"}, "", "", 0, time.Now(), time.Now(), nil)
+	sub.RunCleanups()
+	mt.RunCleanups()
+
+	// Not received, as the test finished.
+	r.RelayerExecReporter(mt).TrackRelayerExec("my_container", []string{"rly"}, "", "", 0, time.Now(), time.Now(), nil)
+
+	// Closing flushes the messages to the listener.
+	require.NoError(t, r.Close())
+
+	// The test's begin message may not have been written yet when listening started.
+	if m, ok := msgs[0].(testreporter.BeginTestMessage); ok && m.Name == "my_test" {
+		msgs = msgs[1:]
+	}
+	require.Len(t, msgs, 4)
+	require.Equal(t, "my_test/sub", msgs[0].(testreporter.BeginTestMessage).Name)
+	require.Equal(t, "my_test/sub", msgs[1].(testreporter.RelayerExecMessage).Name)
+	require.Equal(t, "my_test/sub", msgs[2].(testreporter.FinishTestMessage).Name)
+	require.Equal(t, "my_test", msgs[3].(testreporter.FinishTestMessage).Name)
+}
+
 // requireTimeInRange is a helper to assert that a time occurs between a given start and end.
 func requireTimeInRange(t *testing.T, actual, notBefore, notAfter time.Time) {
 	t.Helper()
@@ -0,0 +1,378 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// PacketStage is a stage in the lifecycle of an IBC packet.
+type PacketStage string
+
+const (
+	// PacketStageSend is the packet being committed on the source chain.
+	PacketStageSend PacketStage = "send"
+	// PacketStageRecv is the packet being received on the destination chain.
+	PacketStageRecv PacketStage = "recv"
+	// PacketStageWriteAck is the destination chain writing the acknowledgement of the packet.
+	PacketStageWriteAck PacketStage = "write_ack"
+	// PacketStageAck is the acknowledgement being relayed back to the source chain.
+	PacketStageAck PacketStage = "ack"
+	// PacketStageTimeout is the timeout of the packet being relayed back to the source chain, in place of recv and ack.
+	PacketStageTimeout PacketStage = "timeout"
+)
+
+// DefaultPacketStageBlocks is the default number of blocks a PacketTracker waits for each stage.
+const DefaultPacketStageBlocks = 20
+
+// PacketSourceChain is a chain that packets are sent from.
+type PacketSourceChain interface {
+	ChainAcker
+	Timeouts(ctx context.Context, height uint64) ([]ibc.PacketTimeout, error)
+}
+
+// PacketDestinationChain is a chain that can get the packets it received,
+// and the acknowledgements it wrote, at a specified height.
+type PacketDestinationChain interface {
+	ChainHeighter
+	ReceivedPackets(ctx context.Context, height uint64) ([]ibc.Packet, error)
+	WrittenAcknowledgements(ctx context.Context, height uint64) ([]ibc.PacketAcknowledgement, error)
+}
+
+// ChainBlockTimer is optionally implemented by chains that can report the time of a block.
+// A PacketTracker uses block times in timelines when available, and otherwise the time each stage was observed.
+type ChainBlockTimer interface {
+	BlockTime(ctx context.Context, height uint64) (time.Time, error)
+}
+
+// PacketEvent is a stage of a packet's lifecycle, as observed on the chain where it happened.
+type PacketEvent struct {
+	Stage  PacketStage
+	Height uint64
+	Time   time.Time
+}
+
+// PacketTimeline is the lifecycle of a packet, as tracked by a PacketTracker.
+type PacketTimeline struct {
+	Packet ibc.Packet
+
+	// Completed stages, in order.
+	Events []PacketEvent
+
+	// The acknowledgement written by the destination chain, if the packet was received.
+	Acknowledgement []byte
+
+	// Whether the packet timed out instead of being received.
+	TimedOut bool
+}
+
+// Event returns the event of the stage, if the stage was completed.
+func (tl PacketTimeline) Event(stage PacketStage) (PacketEvent, bool) {
+	for _, e := range tl.Events {
+		if e.Stage == stage {
+			return e, true
+		}
+	}
+	return PacketEvent{}, false
+}
+
+// PacketStageError is returned by a PacketTracker when a packet does not complete a stage.
+type PacketStageError struct {
+	// The stage that did not complete.
+	Stage PacketStage
+
+	// The stages completed before the failure.
+	Timeline PacketTimeline
+
+	// ErrNotFound if the stage did not happen within the tracker's block limit,
+	// or the error encountered while querying for it.
+	Err error
+}
+
+func (e *PacketStageError) Error() string {
+	p := e.Timeline.Packet
+	return fmt.Sprintf("packet %d on %s/%s stalled at %s stage: %v", p.Sequence, p.SourcePort, p.SourceChannel, e.Stage, e.Err)
+}
+
+func (e *PacketStageError) Unwrap() error {
+	return e.Err
+}
+
+// PacketTracker follows packets through their lifecycle across a source and destination chain:
+// send, recv on the destination, write-ack, and ack back on the source, or timeout back on the source.
+type PacketTracker struct {
+	Src PacketSourceChain
+	Dst PacketDestinationChain
+
+	// Maximum number of blocks to wait for each stage, counted on the chain where the stage happens.
+	// Defaults to DefaultPacketStageBlocks.
+	StageBlocks uint64
+
+	// Height of the destination chain from which to look for the received packet.
+	// If zero, the search starts StageBlocks below the destination height at the time Track is called,
+	// so that packets relayed before the call are found.
+	DstStartHeight uint64
+
+	// Delay between queries when waiting for new blocks. Defaults to 100ms.
+	PollInterval time.Duration
+}
+
+// NewPacketTracker returns a PacketTracker for packets sent from src to dst, with default limits.
+func NewPacketTracker(src PacketSourceChain, dst PacketDestinationChain) *PacketTracker {
+	return &PacketTracker{Src: src, Dst: dst}
+}
+
+// Track follows the packet sent by tx, such as one returned by ibc.Chain.SendIBCTransfer,
+// until it is acknowledged or timed out on the source chain.
+// If the packet stalls, the returned error is a *PacketStageError naming the stage,
+// and the returned timeline contains the stages completed so far.
+func (pt *PacketTracker) Track(ctx context.Context, tx ibc.Tx) (PacketTimeline, error) {
+	tl := PacketTimeline{Packet: tx.Packet}
+	stageErr := func(stage PacketStage, err error) (PacketTimeline, error) {
+		return tl, &PacketStageError{Stage: stage, Timeline: tl, Err: err}
+	}
+
+	if tx.Height == 0 || tx.Packet.Sequence == 0 {
+		return stageErr(PacketStageSend, errors.New("transaction did not send a packet"))
+	}
+	if err := pt.record(ctx, &tl, pt.Src, PacketStageSend, tx.Height); err != nil {
+		return stageErr(PacketStageSend, err)
+	}
+
+	// The packet is either received on the destination, or times out on the source.
+	dstStart := pt.DstStartHeight
+	if dstStart == 0 {
+		h, err := pt.Dst.Height(ctx)
+		if err != nil {
+			return stageErr(PacketStageRecv, err)
+		}
+		dstStart = 1
+		if h > pt.stageBlocks() {
+			dstStart = h - pt.stageBlocks()
+		}
+	}
+	dst, err := pt.newScanner(ctx, pt.Dst, dstStart)
+	if err != nil {
+		return stageErr(PacketStageRecv, err)
+	}
+	src, err := pt.newScanner(ctx, pt.Src, tx.Height)
+	if err != nil {
+		return stageErr(PacketStageRecv, err)
+	}
+
+	var recvHeight uint64
+	for recvHeight == 0 {
+		if dst.exhausted() && src.exhausted() {
+			return stageErr(PacketStageRecv, ErrNotFound)
+		}
+
+		progressed := false
+
+		if h, ok, err := dst.next(ctx); err != nil {
+			return stageErr(PacketStageRecv, err)
+		} else if ok {
+			progressed = true
+			packets, err := pt.Dst.ReceivedPackets(ctx, h)
+			if err != nil {
+				return stageErr(PacketStageRecv, err)
+			}
+			for _, p := range packets {
+				if samePacket(p, tx.Packet) {
+					recvHeight = h
+					break
+				}
+			}
+			if recvHeight != 0 {
+				break
+			}
+		}
+
+		if h, ok, err := src.next(ctx); err != nil {
+			return stageErr(PacketStageRecv, err)
+		} else if ok {
+			progressed = true
+			timeouts, err := pt.Src.Timeouts(ctx, h)
+			if err != nil {
+				return stageErr(PacketStageRecv, err)
+			}
+			for _, t := range timeouts {
+				if samePacket(t.Packet, tx.Packet) {
+					tl.TimedOut = true
+					if err := pt.record(ctx, &tl, pt.Src, PacketStageTimeout, h); err != nil {
+						return stageErr(PacketStageTimeout, err)
+					}
+					return tl, nil
+				}
+			}
+		}
+
+		if !progressed {
+			if err := pt.sleep(ctx); err != nil {
+				return stageErr(PacketStageRecv, err)
+			}
+		}
+	}
+	if err := pt.record(ctx, &tl, pt.Dst, PacketStageRecv, recvHeight); err != nil {
+		return stageErr(PacketStageRecv, err)
+	}
+
+	// Synchronous acknowledgements are written along with the receive; asynchronous ones later.
+	dst, err = pt.newScanner(ctx, pt.Dst, recvHeight)
+	if err != nil {
+		return stageErr(PacketStageWriteAck, err)
+	}
+	ackHeight, err := pt.scan(ctx, dst, func(h uint64) (bool, error) {
+		acks, err := pt.Dst.WrittenAcknowledgements(ctx, h)
+		if err != nil {
+			return false, err
+		}
+		for _, a := range acks {
+			if samePacket(a.Packet, tx.Packet) {
+				tl.Acknowledgement = a.Acknowledgement
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return stageErr(PacketStageWriteAck, err)
+	}
+	if err := pt.record(ctx, &tl, pt.Dst, PacketStageWriteAck, ackHeight); err != nil {
+		return stageErr(PacketStageWriteAck, err)
+	}
+
+	// The acknowledgement may already have been relayed while the earlier stages were searched.
+	src, err = pt.newScanner(ctx, pt.Src, tx.Height)
+	if err != nil {
+		return stageErr(PacketStageAck, err)
+	}
+	ackHeight, err = pt.scan(ctx, src, func(h uint64) (bool, error) {
+		acks, err := pt.Src.Acknowledgements(ctx, h)
+		if err != nil {
+			return false, err
+		}
+		for _, a := range acks {
+			if samePacket(a.Packet, tx.Packet) {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return stageErr(PacketStageAck, err)
+	}
+	if err := pt.record(ctx, &tl, pt.Src, PacketStageAck, ackHeight); err != nil {
+		return stageErr(PacketStageAck, err)
+	}
+
+	return tl, nil
+}
+
+// record appends the stage to the timeline, using the block time if the chain reports it.
+func (pt *PacketTracker) record(ctx context.Context, tl *PacketTimeline, chain any, stage PacketStage, height uint64) error {
+	t := time.Now()
+	if bt, ok := chain.(ChainBlockTimer); ok {
+		var err error
+		t, err = bt.BlockTime(ctx, height)
+		if err != nil {
+			return err
+		}
+	}
+	tl.Events = append(tl.Events, PacketEvent{Stage: stage, Height: height, Time: t})
+	return nil
+}
+
+// scan checks each height of the scanner with found until it returns true,
+// returning the height, or ErrNotFound once the scanner is exhausted.
+func (pt *PacketTracker) scan(ctx context.Context, s *heightScanner, found func(height uint64) (bool, error)) (uint64, error) {
+	for !s.exhausted() {
+		h, ok, err := s.next(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			if err := pt.sleep(ctx); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		ok, err = found(h)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return h, nil
+		}
+	}
+	return 0, ErrNotFound
+}
+
+func (pt *PacketTracker) sleep(ctx context.Context) error {
+	interval := pt.PollInterval
+	if interval == 0 {
+		interval = 100 * time.Millisecond
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(interval):
+		return nil
+	}
+}
+
+func (pt *PacketTracker) stageBlocks() uint64 {
+	if pt.StageBlocks == 0 {
+		return DefaultPacketStageBlocks
+	}
+	return pt.StageBlocks
+}
+
+// newScanner returns a scanner over the heights of the chain from start,
+// up to StageBlocks past the later of start and the current height.
+func (pt *PacketTracker) newScanner(ctx context.Context, chain ChainHeighter, start uint64) (*heightScanner, error) {
+	h, err := chain.Height(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if h < start {
+		h = start
+	}
+	return &heightScanner{chain: chain, cursor: start, max: h + pt.stageBlocks()}, nil
+}
+
+// heightScanner yields each height of a chain in a range, as the chain produces them.
+type heightScanner struct {
+	chain       ChainHeighter
+	cursor, max uint64
+}
+
+func (s *heightScanner) exhausted() bool {
+	return s.cursor > s.max
+}
+
+// next returns the next height to check, and false if the chain has not yet produced it or the scanner is exhausted.
+func (s *heightScanner) next(ctx context.Context) (uint64, bool, error) {
+	if s.exhausted() {
+		return 0, false, nil
+	}
+	h, err := s.chain.Height(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	if s.cursor > h {
+		return 0, false, nil
+	}
+	s.cursor++
+	return s.cursor - 1, true, nil
+}
+
+// samePacket reports whether both packets have the same identity,
+// regardless of differences in how their data and timeouts are reported by each chain.
+func samePacket(a, b ibc.Packet) bool {
+	return a.Sequence == b.Sequence &&
+		a.SourcePort == b.SourcePort && a.SourceChannel == b.SourceChannel &&
+		a.DestPort == b.DestPort && a.DestChannel == b.DestChannel
+}
@@ -0,0 +1,157 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+// trackerChain is a chain whose height advances by one on every height query,
+// with packet events at fixed heights.
+type trackerChain struct {
+	height uint64
+
+	recvs    map[uint64][]ibc.Packet
+	written  map[uint64][]ibc.PacketAcknowledgement
+	acks     map[uint64][]ibc.PacketAcknowledgement
+	timeouts map[uint64][]ibc.PacketTimeout
+
+	err error
+}
+
+func (c *trackerChain) Height(context.Context) (uint64, error) {
+	c.height++
+	return c.height, nil
+}
+
+func (c *trackerChain) ReceivedPackets(_ context.Context, h uint64) ([]ibc.Packet, error) {
+	return c.recvs[h], c.err
+}
+
+func (c *trackerChain) WrittenAcknowledgements(_ context.Context, h uint64) ([]ibc.PacketAcknowledgement, error) {
+	return c.written[h], nil
+}
+
+func (c *trackerChain) Acknowledgements(_ context.Context, h uint64) ([]ibc.PacketAcknowledgement, error) {
+	return c.acks[h], nil
+}
+
+func (c *trackerChain) Timeouts(_ context.Context, h uint64) ([]ibc.PacketTimeout, error) {
+	return c.timeouts[h], nil
+}
+
+func (c *trackerChain) BlockTime(_ context.Context, h uint64) (time.Time, error) {
+	return time.Unix(int64(h), 0), nil
+}
+
+func TestPacketTracker(t *testing.T) {
+	ctx := context.Background()
+	packet := ibc.Packet{Sequence: 3, SourcePort: "transfer", SourceChannel: "channel-0", DestPort: "transfer", DestChannel: "channel-1", Data: []byte("data")}
+	// The destination chain reports the data differently, which must not matter.
+	received := packet
+	received.Data = []byte("other encoding")
+	other := packet
+	other.Sequence = 4
+
+	newTracker := func(src, dst *trackerChain) *PacketTracker {
+		pt := NewPacketTracker(src, dst)
+		pt.StageBlocks = 5
+		pt.PollInterval = time.Millisecond
+		return pt
+	}
+
+	t.Run("acknowledged", func(t *testing.T) {
+		src := &trackerChain{height: 10, acks: map[uint64][]ibc.PacketAcknowledgement{
+			13: {{Packet: other}},
+			14: {{Packet: packet}},
+		}}
+		dst := &trackerChain{height: 20,
+			recvs:   map[uint64][]ibc.Packet{22: {other, received}},
+			written: map[uint64][]ibc.PacketAcknowledgement{22: {{Packet: received, Acknowledgement: []byte(`{"result":"AQ=="}`)}}},
+		}
+
+		tl, err := newTracker(src, dst).Track(ctx, ibc.Tx{Height: 10, Packet: packet})
+		require.NoError(t, err)
+		require.False(t, tl.TimedOut)
+		require.Equal(t, []byte(`{"result":"AQ=="}`), tl.Acknowledgement)
+		require.Equal(t, []PacketEvent{
+			{Stage: PacketStageSend, Height: 10, Time: time.Unix(10, 0)},
+			{Stage: PacketStageRecv, Height: 22, Time: time.Unix(22, 0)},
+			{Stage: PacketStageWriteAck, Height: 22, Time: time.Unix(22, 0)},
+			{Stage: PacketStageAck, Height: 14, Time: time.Unix(14, 0)},
+		}, tl.Events)
+	})
+
+	t.Run("received before tracking", func(t *testing.T) {
+		src := &trackerChain{height: 30, acks: map[uint64][]ibc.PacketAcknowledgement{12: {{Packet: packet}}}}
+		dst := &trackerChain{height: 20,
+			recvs:   map[uint64][]ibc.Packet{17: {received}},
+			written: map[uint64][]ibc.PacketAcknowledgement{18: {{Packet: received}}},
+		}
+
+		tl, err := newTracker(src, dst).Track(ctx, ibc.Tx{Height: 10, Packet: packet})
+		require.NoError(t, err)
+		recv, ok := tl.Event(PacketStageRecv)
+		require.True(t, ok)
+		require.Equal(t, uint64(17), recv.Height)
+		writeAck, _ := tl.Event(PacketStageWriteAck)
+		require.Equal(t, uint64(18), writeAck.Height)
+	})
+
+	t.Run("timed out", func(t *testing.T) {
+		src := &trackerChain{height: 10, timeouts: map[uint64][]ibc.PacketTimeout{13: {{Packet: packet}}}}
+		dst := &trackerChain{height: 20}
+
+		tl, err := newTracker(src, dst).Track(ctx, ibc.Tx{Height: 10, Packet: packet})
+		require.NoError(t, err)
+		require.True(t, tl.TimedOut)
+		require.Len(t, tl.Events, 2)
+		timeout, ok := tl.Event(PacketStageTimeout)
+		require.True(t, ok)
+		require.Equal(t, uint64(13), timeout.Height)
+		_, ok = tl.Event(PacketStageRecv)
+		require.False(t, ok)
+	})
+
+	t.Run("stalled at recv", func(t *testing.T) {
+		_, err := newTracker(&trackerChain{height: 10}, &trackerChain{height: 20}).Track(ctx, ibc.Tx{Height: 10, Packet: packet})
+
+		var stageErr *PacketStageError
+		require.ErrorAs(t, err, &stageErr)
+		require.Equal(t, PacketStageRecv, stageErr.Stage)
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Len(t, stageErr.Timeline.Events, 1)
+		require.Contains(t, err.Error(), "packet 3 on transfer/channel-0 stalled at recv stage")
+	})
+
+	t.Run("stalled at ack", func(t *testing.T) {
+		dst := &trackerChain{height: 20,
+			recvs:   map[uint64][]ibc.Packet{21: {received}},
+			written: map[uint64][]ibc.PacketAcknowledgement{21: {{Packet: received}}},
+		}
+		_, err := newTracker(&trackerChain{height: 10}, dst).Track(ctx, ibc.Tx{Height: 10, Packet: packet})
+
+		var stageErr *PacketStageError
+		require.ErrorAs(t, err, &stageErr)
+		require.Equal(t, PacketStageAck, stageErr.Stage)
+		require.Len(t, stageErr.Timeline.Events, 3)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		queryErr := errors.New("boom")
+		_, err := newTracker(&trackerChain{height: 10}, &trackerChain{height: 20, err: queryErr}).Track(ctx, ibc.Tx{Height: 10, Packet: packet})
+		require.ErrorIs(t, err, queryErr)
+	})
+
+	t.Run("no packet", func(t *testing.T) {
+		_, err := newTracker(&trackerChain{}, &trackerChain{}).Track(ctx, ibc.Tx{Height: 10})
+
+		var stageErr *PacketStageError
+		require.ErrorAs(t, err, &stageErr)
+		require.Equal(t, PacketStageSend, stageErr.Stage)
+	})
+}
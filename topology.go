@@ -0,0 +1,293 @@
+package interchaintest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+)
+
+// Topology is a declarative description of an Interchain:
+// its chains, relayers, and the links between them.
+//
+// Topologies are usually written as YAML or JSON files and read with ReadTopologyFile.
+// Field names are the same in both formats, and match the Go field names.
+type Topology struct {
+	Chains   []TopologyChain
+	Relayers []TopologyRelayer
+	Links    []TopologyLink
+}
+
+// TopologyChain describes a chain in a Topology.
+// All fields of ChainSpec, including those of the embedded ibc.ChainConfig, may be set directly on the chain.
+type TopologyChain struct {
+	*ChainSpec
+
+	// Additional wallets to fund in the chain's genesis.
+	GenesisWallets []ibc.WalletAmount
+}
+
+// TopologyRelayer describes a relayer in a Topology.
+type TopologyRelayer struct {
+	// Name of the relayer instance, referenced by links.
+	Name string
+
+	// Relayer type registered with RegisterRelayerType, such as rly, hermes, or hyperspace.
+	Type string
+
+	// Optional overrides, corresponding to the relayer.RelayerOption values.
+	Image        *ibc.DockerImage
+	HomeDir      string
+	Pull         *bool
+	StartupFlags []string
+}
+
+// TopologyLink describes a path between two chains in a Topology.
+type TopologyLink struct {
+	// Names of the linked chains: the ChainName of the chain if set, and otherwise its Name.
+	Chain1, Chain2 string
+
+	// Name of the relayer relaying the path.
+	Relayer string
+
+	// Name of the path.
+	Path string
+
+	// Optional options for creating the clients and channel of the path.
+	// Omitted fields use those of ibc.DefaultClientOpts and ibc.DefaultChannelOpts,
+	// so that e.g. an ordered transfer channel only needs its Order set.
+	CreateClientOpts  ibc.CreateClientOptions
+	CreateChannelOpts ibc.CreateChannelOptions
+}
+
+// clientOpts returns the client options of the link, with defaults for the omitted fields.
+func (l TopologyLink) clientOpts() ibc.CreateClientOptions {
+	opts := ibc.DefaultClientOpts()
+	if l.CreateClientOpts.TrustingPeriod != "" {
+		opts.TrustingPeriod = l.CreateClientOpts.TrustingPeriod
+	}
+	return opts
+}
+
+// channelOpts returns the channel options of the link, with defaults for the omitted fields.
+func (l TopologyLink) channelOpts() ibc.CreateChannelOptions {
+	opts := ibc.DefaultChannelOpts()
+	if l.CreateChannelOpts.SourcePortName != "" {
+		opts.SourcePortName = l.CreateChannelOpts.SourcePortName
+	}
+	if l.CreateChannelOpts.DestPortName != "" {
+		opts.DestPortName = l.CreateChannelOpts.DestPortName
+	}
+	if l.CreateChannelOpts.Order != ibc.Invalid {
+		opts.Order = l.CreateChannelOpts.Order
+	}
+	if l.CreateChannelOpts.Version != "" {
+		opts.Version = l.CreateChannelOpts.Version
+	}
+	return opts
+}
+
+// ReadTopologyFile reads a topology from a YAML or JSON file.
+// Files with a .yaml or .yml extension are read as YAML, and any other file as JSON.
+func ReadTopologyFile(path string) (*Topology, error) {
+	var topo Topology
+	if err := readConfigFile(path, "topology", &topo); err != nil {
+		return nil, err
+	}
+	return &topo, nil
+}
+
+// ReadChainSpecFile reads a list of chain specs from a YAML or JSON file,
+// with the same fields as the Chains of a topology file.
+// Files with a .yaml or .yml extension are read as YAML, and any other file as JSON.
+func ReadChainSpecFile(path string) ([]*ChainSpec, error) {
+	var specs []*ChainSpec
+	if err := readConfigFile(path, "chain spec", &specs); err != nil {
+		return nil, err
+	}
+	return specs, nil
+}
+
+// readConfigFile unmarshals the YAML or JSON file at path into v.
+// The kind of file is used in errors.
+func readConfigFile(path, kind string, v any) error {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s file: %w", kind, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		bz, err = yaml.YAMLToJSON(bz)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s file %s: %w", kind, path, err)
+		}
+	}
+
+	if err := json.Unmarshal(bz, v); err != nil {
+		return fmt.Errorf("failed to parse %s file %s: %w", kind, path, err)
+	}
+	return nil
+}
+
+// Validate checks that the topology is internally consistent,
+// i.e. that every chain is defined, every relayer is known, and every link refers to a defined chain and relayer.
+func (topo *Topology) Validate(log *zap.Logger) error {
+	if len(topo.Chains) == 0 {
+		return errors.New("topology must contain at least one chain")
+	}
+
+	chainNames := make(map[string]struct{}, len(topo.Chains))
+	for i, c := range topo.Chains {
+		if c.ChainSpec == nil {
+			return fmt.Errorf("topology chain at index %d is empty", i)
+		}
+		if _, err := c.Config(log); err != nil {
+			return fmt.Errorf("invalid topology chain at index %d: %w", i, err)
+		}
+		name := c.name()
+		if _, ok := chainNames[name]; ok {
+			return fmt.Errorf("topology chain name %s is used more than once", name)
+		}
+		chainNames[name] = struct{}{}
+	}
+
+	relayerNames := make(map[string]struct{}, len(topo.Relayers))
+	for i, r := range topo.Relayers {
+		if r.Name == "" {
+			return fmt.Errorf("topology relayer at index %d has no name", i)
+		}
+		if _, ok := relayerNames[r.Name]; ok {
+			return fmt.Errorf("topology relayer name %s is used more than once", r.Name)
+		}
+		if _, err := NewRelayerFactory(r.Type, zap.NewNop()); err != nil {
+			return fmt.Errorf("invalid topology relayer %s: %w", r.Name, err)
+		}
+		relayerNames[r.Name] = struct{}{}
+	}
+
+	paths := make(map[string]struct{}, len(topo.Links))
+	for i, l := range topo.Links {
+		for _, name := range []string{l.Chain1, l.Chain2} {
+			if _, ok := chainNames[name]; !ok {
+				return fmt.Errorf("topology link at index %d refers to undefined chain %q", i, name)
+			}
+		}
+		if l.Chain1 == l.Chain2 {
+			return fmt.Errorf("topology link at index %d links chain %s to itself", i, l.Chain1)
+		}
+		if _, ok := relayerNames[l.Relayer]; !ok {
+			return fmt.Errorf("topology link at index %d refers to undefined relayer %q", i, l.Relayer)
+		}
+		if l.Path == "" {
+			return fmt.Errorf("topology link at index %d has no path", i)
+		}
+		if err := l.clientOpts().Validate(); err != nil {
+			return fmt.Errorf("topology link at index %d has invalid client options: %w", i, err)
+		}
+		if err := l.channelOpts().Validate(); err != nil {
+			return fmt.Errorf("topology link at index %d has invalid channel options: %w", i, err)
+		}
+		key := l.Relayer + "/" + l.Path
+		if _, ok := paths[key]; ok {
+			return fmt.Errorf("topology path %s is used more than once for relayer %s", l.Path, l.Relayer)
+		}
+		paths[key] = struct{}{}
+	}
+
+	return nil
+}
+
+// NewInterchainFromTopology returns a new Interchain containing the chains, relayers, and links of the topology.
+// The returned Interchain is ready to Build.
+func NewInterchainFromTopology(t *testing.T, log *zap.Logger, cli *client.Client, networkID string, topo *Topology) (*Interchain, error) {
+	if err := topo.Validate(log); err != nil {
+		return nil, err
+	}
+
+	specs := make([]*ChainSpec, len(topo.Chains))
+	for i, c := range topo.Chains {
+		specs[i] = c.ChainSpec
+	}
+	chains, err := NewBuiltinChainFactory(log, specs).Chains(t.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create topology chains: %w", err)
+	}
+
+	ic := NewInterchain().WithLog(log)
+
+	chainsByName := make(map[string]ibc.Chain, len(chains))
+	for i, c := range chains {
+		chainsByName[topo.Chains[i].name()] = c
+		ic.AddChain(c, topo.Chains[i].GenesisWallets...)
+	}
+
+	relayersByName := make(map[string]ibc.Relayer, len(topo.Relayers))
+	for _, r := range topo.Relayers {
+		rf, _ := NewRelayerFactory(r.Type, log, r.options()...) // Already validated.
+		built := rf.Build(t, cli, networkID)
+		relayersByName[r.Name] = built
+		ic.AddRelayer(built, r.Name)
+	}
+
+	for _, l := range topo.Links {
+		ic.AddLink(InterchainLink{
+			Chain1:  chainsByName[l.Chain1],
+			Chain2:  chainsByName[l.Chain2],
+			Relayer: relayersByName[l.Relayer],
+			Path:    l.Path,
+
+			CreateClientOpts:  l.clientOpts(),
+			CreateChannelOpts: l.channelOpts(),
+		})
+	}
+
+	return ic, nil
+}
+
+// LoadTopology reads the topology file at path and returns a new Interchain for it,
+// as a combination of ReadTopologyFile and NewInterchainFromTopology.
+func LoadTopology(t *testing.T, log *zap.Logger, cli *client.Client, networkID string, path string) (*Interchain, error) {
+	topo, err := ReadTopologyFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewInterchainFromTopology(t, log, cli, networkID, topo)
+}
+
+// name returns the name by which links refer to the chain:
+// the ChainName if set, and otherwise the Name.
+// It must be called after the ChainSpec's Config method, which fills in Name from the ChainConfig.
+func (c TopologyChain) name() string {
+	if c.ChainName != "" {
+		return c.ChainName
+	}
+	return c.Name
+}
+
+// options returns the relayer options corresponding to the set fields of r.
+func (r TopologyRelayer) options() relayer.RelayerOptions {
+	var opts relayer.RelayerOptions
+	if r.Image != nil {
+		opts = append(opts, relayer.CustomDockerImage(r.Image.Repository, r.Image.Version, r.Image.UidGid))
+	}
+	if r.HomeDir != "" {
+		opts = append(opts, relayer.HomeDir(r.HomeDir))
+	}
+	if r.Pull != nil {
+		opts = append(opts, relayer.ImagePull(*r.Pull))
+	}
+	if len(r.StartupFlags) > 0 {
+		opts = append(opts, relayer.StartupFlags(r.StartupFlags...))
+	}
+	return opts
+}
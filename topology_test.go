@@ -0,0 +1,134 @@
+package interchaintest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestReadTopologyFile(t *testing.T) {
+	const topoYAML = `
+Chains:
+  - Name: gaia
+    Version: v7.0.1
+    ChainID: cosmoshub-0
+    NumValidators: 1
+    GenesisWallets:
+      - Address: cosmos1abc
+        Denom: uatom
+        Amount: 100
+  - Name: osmosis
+    Version: v11.0.1
+    ChainName: osmo
+    GasAdjustment: 2.5
+Relayers:
+  - Name: r
+    Type: hermes
+    Pull: false
+Links:
+  - Chain1: gaia
+    Chain2: osmo
+    Relayer: r
+    Path: p
+    CreateChannelOpts:
+      SourcePortName: icacontroller-x
+      DestPortName: icahost
+      Order: ordered
+      Version: ics27-1
+`
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "topology.yml")
+	require.NoError(t, os.WriteFile(path, []byte(topoYAML), 0o644))
+
+	topo, err := interchaintest.ReadTopologyFile(path)
+	require.NoError(t, err)
+	require.NoError(t, topo.Validate(zaptest.NewLogger(t)))
+
+	require.Len(t, topo.Chains, 2)
+	require.Equal(t, "cosmoshub-0", topo.Chains[0].ChainID)
+	require.Equal(t, 1, *topo.Chains[0].NumValidators)
+	require.Equal(t, []ibc.WalletAmount{{Address: "cosmos1abc", Denom: "uatom", Amount: 100}}, topo.Chains[0].GenesisWallets)
+	require.Equal(t, 2.5, *topo.Chains[1].GasAdjustment)
+
+	require.Equal(t, "hermes", topo.Relayers[0].Type)
+	require.False(t, *topo.Relayers[0].Pull)
+
+	require.Equal(t, ibc.CreateChannelOptions{
+		SourcePortName: "icacontroller-x",
+		DestPortName:   "icahost",
+		Order:          ibc.Ordered,
+		Version:        "ics27-1",
+	}, topo.Links[0].CreateChannelOpts)
+}
+
+func TestTopology_Validate(t *testing.T) {
+	chain := func(name string) interchaintest.TopologyChain {
+		return interchaintest.TopologyChain{ChainSpec: &interchaintest.ChainSpec{Name: name, Version: "v1.0.0"}}
+	}
+	valid := func() *interchaintest.Topology {
+		return &interchaintest.Topology{
+			Chains:   []interchaintest.TopologyChain{chain("gaia"), chain("osmosis")},
+			Relayers: []interchaintest.TopologyRelayer{{Name: "r", Type: "rly"}},
+			Links:    []interchaintest.TopologyLink{{Chain1: "gaia", Chain2: "osmosis", Relayer: "r", Path: "p"}},
+		}
+	}
+	log := zaptest.NewLogger(t)
+
+	require.NoError(t, valid().Validate(log))
+
+	for _, tc := range []struct {
+		name   string
+		modify func(*interchaintest.Topology)
+		err    string
+	}{
+		{"no chains", func(topo *interchaintest.Topology) { topo.Chains = nil }, "at least one chain"},
+		{"duplicate chain", func(topo *interchaintest.Topology) { topo.Chains[1] = chain("gaia") }, "used more than once"},
+		{"unknown relayer type", func(topo *interchaintest.Topology) { topo.Relayers[0].Type = "bogus" }, "unknown relayer type"},
+		{"undefined chain", func(topo *interchaintest.Topology) { topo.Links[0].Chain2 = "juno" }, `undefined chain "juno"`},
+		{"undefined relayer", func(topo *interchaintest.Topology) { topo.Links[0].Relayer = "x" }, `undefined relayer "x"`},
+		{"self link", func(topo *interchaintest.Topology) { topo.Links[0].Chain2 = "gaia" }, "to itself"},
+		{"duplicate path", func(topo *interchaintest.Topology) { topo.Links = append(topo.Links, topo.Links[0]) }, "path p is used more than once"},
+		{"invalid channel port", func(topo *interchaintest.Topology) { topo.Links[0].CreateChannelOpts.SourcePortName = "a" }, "invalid channel options"},
+		{"invalid trusting period", func(topo *interchaintest.Topology) { topo.Links[0].CreateClientOpts.TrustingPeriod = "1y" }, "invalid client options"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			topo := valid()
+			tc.modify(topo)
+			require.ErrorContains(t, topo.Validate(log), tc.err)
+		})
+	}
+}
+
+func TestTopologyLink_Opts(t *testing.T) {
+	// Omitted fields use the defaults, field by field.
+	l := interchaintest.TopologyLink{CreateChannelOpts: ibc.CreateChannelOptions{Order: ibc.Ordered}}
+	require.Equal(t, ibc.CreateChannelOptions{
+		SourcePortName: "transfer",
+		DestPortName:   "transfer",
+		Order:          ibc.Ordered,
+		Version:        "ics20-1",
+	}, l.ChannelOpts())
+	require.Equal(t, ibc.DefaultClientOpts(), l.ClientOpts())
+
+	l = interchaintest.TopologyLink{
+		CreateClientOpts: ibc.CreateClientOptions{TrustingPeriod: "336h"},
+		CreateChannelOpts: ibc.CreateChannelOptions{
+			SourcePortName: "icacontroller-x",
+			DestPortName:   "icahost",
+			Version:        "ics27-1",
+		},
+	}
+	require.Equal(t, ibc.CreateChannelOptions{
+		SourcePortName: "icacontroller-x",
+		DestPortName:   "icahost",
+		Order:          ibc.Unordered,
+		Version:        "ics27-1",
+	}, l.ChannelOpts())
+	require.Equal(t, "336h", l.ClientOpts().TrustingPeriod)
+}